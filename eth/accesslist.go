@@ -0,0 +1,60 @@
+package eth
+
+import (
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/sirupsen/logrus"
+)
+
+// accessListResult is the eth_createAccessList response. Error is set when the call reverts.
+type accessListResult struct {
+	AccessList types.AccessList `json:"accessList"`
+	GasUsed    hexutil.Uint64   `json:"gasUsed"`
+	Error      string           `json:"error,omitempty"`
+}
+
+// attachAccessList sets tx.AccessList to the node-generated access list if estimating gas with it
+// gives a lower result than without. It is an optimization only: failures are logged and leave
+// tx unchanged.
+func (es *ghostClient) attachAccessList(tx *Transaction) {
+	var result accessListResult
+	if err := es.rpcClient().CallContext(es.ctx, &result, "eth_createAccessList", toCallArg(tx), "latest"); err != nil {
+		es.log.WithError(err).Warn("Failed to create access list, sending without")
+		return
+	}
+	if result.Error != "" || len(result.AccessList) == 0 {
+		es.log.WithField("error", result.Error).Info("No access list to attach")
+		return
+	}
+
+	from := tx.From
+	if tx.EstimateFrom != (common.Address{}) {
+		from = tx.EstimateFrom
+	}
+	msg := ethereum.CallMsg{From: from, To: tx.recipient(), Value: tx.Value, Data: tx.Data}
+	without, err := es.readClient().EstimateGas(es.ctx, msg)
+	if err != nil {
+		es.log.WithError(err).Warn("Failed to estimate gas without access list, sending without")
+		return
+	}
+	msg.AccessList = result.AccessList
+	with, err := es.readClient().EstimateGas(es.ctx, msg)
+	if err != nil {
+		es.log.WithError(err).Warn("Failed to estimate gas with access list, sending without")
+		return
+	}
+
+	fields := logrus.Fields{
+		"without": without,
+		"with":    with,
+		"entries": len(result.AccessList),
+	}
+	if with >= without {
+		es.log.WithFields(fields).Info("Access list does not save gas, sending without")
+		return
+	}
+	tx.AccessList = result.AccessList
+	es.log.WithFields(fields).Info("Attached access list")
+}
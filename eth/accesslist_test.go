@@ -0,0 +1,137 @@
+package eth
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGhostClient_SignTransaction_AutoAccessList(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	token := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	list := types.AccessList{{
+		Address:     token,
+		StorageKeys: []common.Hash{common.HexToHash("0x01"), common.HexToHash("0x02")},
+	}}
+	withoutList := mock.MatchedBy(func(msg ethereum.CallMsg) bool { return msg.AccessList == nil })
+	withList := mock.MatchedBy(func(msg ethereum.CallMsg) bool { return len(msg.AccessList) == 1 })
+
+	tests := []struct {
+		name     string
+		withGas  uint64
+		attached bool
+	}{
+		{"cheaper", 48000, true},
+		{"not cheaper", 52000, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRPC := &internalmocks.RPCClient{}
+			mockRPC.On("CallContext", mock.Anything, mock.Anything, "eth_createAccessList", mock.Anything, "latest").
+				Run(func(args mock.Arguments) {
+					*args.Get(1).(*accessListResult) = accessListResult{AccessList: list, GasUsed: hexutil.Uint64(tt.withGas)}
+				}).
+				Return(nil).Once()
+			mockClient := &internalmocks.EthClient{}
+			mockClient.On("PendingNonceAt", mock.Anything, acc.Address).Return(uint64(1), nil)
+			mockClient.On("EstimateGas", mock.Anything, withoutList).Return(uint64(50000), nil)
+			mockClient.On("EstimateGas", mock.Anything, withList).Return(tt.withGas, nil)
+			mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{GasLimit: 30000000, BaseFee: big.NewInt(100)}, nil)
+			gc := &ghostClient{
+				client:  mockClient,
+				rpc:     mockRPC,
+				ctx:     context.Background(),
+				chainId: 1,
+				account: acc,
+				config:  cfg,
+				log:     newTestLogger(),
+			}
+			WithAutoAccessList()(gc)
+
+			tx := &Transaction{From: acc.Address, To: token, Data: []byte{0xa9, 0x05, 0x9c, 0xbb}}
+			signedTx, err := gc.SignTransaction(tx)
+			assert.NoError(t, err)
+			if tt.attached {
+				assert.Equal(t, list, signedTx.AccessList())
+				assert.Equal(t, uint64(float64(tt.withGas)*cfg.GasLimitBufferComplex()), signedTx.Gas())
+			} else {
+				assert.Empty(t, signedTx.AccessList())
+				assert.Equal(t, uint64(float64(50000)*cfg.GasLimitBufferComplex()), signedTx.Gas())
+			}
+			mockRPC.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGhostClient_SignTransaction_AutoAccessListSkipsTransfers(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockRPC := &internalmocks.RPCClient{}
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("PendingNonceAt", mock.Anything, acc.Address).Return(uint64(1), nil)
+	mockClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(21000), nil).Once()
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{GasLimit: 30000000, BaseFee: big.NewInt(100)}, nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		rpc:     mockRPC,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	WithAutoAccessList()(gc)
+
+	signedTx, err := gc.SignTransaction(&Transaction{From: acc.Address, To: acc.Address, Value: big.NewInt(1)})
+	assert.NoError(t, err)
+	assert.Empty(t, signedTx.AccessList())
+	mockRPC.AssertNotCalled(t, "CallContext", mock.Anything, mock.Anything, "eth_createAccessList", mock.Anything, mock.Anything)
+	mockClient.AssertExpectations(t)
+}
+
+// On a chain without a base fee the fees resolve to a GasPrice; the attached list must still be
+// sent, so the transaction goes out as EIP-2930 rather than plain legacy
+func TestGhostClient_SignTransaction_AutoAccessListLegacyChain(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	token := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	list := types.AccessList{{Address: token, StorageKeys: []common.Hash{common.HexToHash("0x01")}}}
+	withoutList := mock.MatchedBy(func(msg ethereum.CallMsg) bool { return msg.AccessList == nil })
+	withList := mock.MatchedBy(func(msg ethereum.CallMsg) bool { return len(msg.AccessList) == 1 })
+
+	mockRPC := &internalmocks.RPCClient{}
+	mockRPC.On("CallContext", mock.Anything, mock.Anything, "eth_createAccessList", mock.Anything, "latest").
+		Run(func(args mock.Arguments) {
+			*args.Get(1).(*accessListResult) = accessListResult{AccessList: list, GasUsed: hexutil.Uint64(48000)}
+		}).
+		Return(nil).Once()
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("PendingNonceAt", mock.Anything, acc.Address).Return(uint64(1), nil)
+	mockClient.On("EstimateGas", mock.Anything, withoutList).Return(uint64(50000), nil)
+	mockClient.On("EstimateGas", mock.Anything, withList).Return(uint64(48000), nil)
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{GasLimit: 30000000}, nil)
+	mockClient.On("SuggestGasPrice", mock.Anything).Return(big.NewInt(12345), nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		rpc:     mockRPC,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	WithAutoAccessList()(gc)
+
+	signedTx, err := gc.SignTransaction(&Transaction{From: acc.Address, To: token, Data: []byte{0xa9, 0x05, 0x9c, 0xbb}})
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(types.AccessListTxType), signedTx.Type())
+	assert.Equal(t, list, signedTx.AccessList())
+	assert.Equal(t, big.NewInt(12345), signedTx.GasPrice())
+	mockRPC.AssertExpectations(t)
+}
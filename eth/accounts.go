@@ -0,0 +1,70 @@
+package eth
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// SignTransactionWithAccount signs tx with the configured account named label (matched
+// case-insensitively) instead of the client's account, e.g. a dedicated fee payer next to a
+// treasury. A zero tx.From is set to that account's address; any other From must match it.
+func (es *ghostClient) SignTransactionWithAccount(label string, tx *Transaction) (*types.Transaction, error) {
+	acc, err := es.signingAccount(label)
+	if err != nil {
+		return nil, err
+	}
+	if err := setSender(tx, acc); err != nil {
+		return nil, err
+	}
+	return es.signTransaction(acc, tx)
+}
+
+// SendTransactionWithAccount signs tx with the configured account named label, as
+// SignTransactionWithAccount, and sends it
+func (es *ghostClient) SendTransactionWithAccount(label string, tx *Transaction) (*TransactionReceipt, error) {
+	acc, err := es.signingAccount(label)
+	if err != nil {
+		return nil, err
+	}
+	if err := setSender(tx, acc); err != nil {
+		return nil, err
+	}
+	signedTx, err := es.signTransaction(acc, tx)
+	if err != nil {
+		return nil, err
+	}
+	return es.sendTransaction(acc, signedTx)
+}
+
+// signingAccount returns the configured account named label if it can sign on this client's
+// chain and endpoint. An account with its own chain ID or RPC URL needs a client of its own.
+func (es *ghostClient) signingAccount(label string) (*Account, error) {
+	acc, ok := es.config.Account(label)
+	if !ok {
+		return nil, fmt.Errorf("unknown account label %q", label)
+	}
+	if acc.PrivateKey == nil {
+		return nil, fmt.Errorf("account %q has no private key", label)
+	}
+	if acc.ChainId != es.chainId {
+		return nil, fmt.Errorf("account %q chain ID %d does not match connected chain %d; create a client for the account with NewGhostClient", label, acc.ChainId, es.chainId)
+	}
+	if acc.RPCURL != "" && acc.RPCURL != es.readURL && acc.RPCURL != es.writeURL {
+		return nil, fmt.Errorf("account %q uses its own RPC URL; create a client for the account with NewGhostClient", label)
+	}
+	return acc, nil
+}
+
+// setSender makes acc the sender of tx, refusing a different From
+func setSender(tx *Transaction, acc *Account) error {
+	if tx.From == (common.Address{}) {
+		tx.From = acc.Address
+		return nil
+	}
+	if tx.From != acc.Address {
+		return fmt.Errorf("invalid transaction: from %s is not account %q (%s)", tx.From.Hex(), acc.Label, acc.Address.Hex())
+	}
+	return nil
+}
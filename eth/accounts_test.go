@@ -0,0 +1,125 @@
+package eth
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testTreasuryAccount(t *testing.T) *Account {
+	privKey, err := crypto.HexToECDSA("8f2a55949038a9610f50fb23b5883af3b4ecb3c3bb792cbcefbd1542c692be63")
+	assert.NoError(t, err)
+	return &Account{
+		Address:    crypto.PubkeyToAddress(privKey.PublicKey),
+		PublicKey:  &privKey.PublicKey,
+		ChainId:    1,
+		Label:      "treasury",
+		PrivateKey: privKey,
+	}
+}
+
+func TestGhostClient_SignTransactionWithAccount(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	treasury := testTreasuryAccount(t)
+	cfg.acounts = append(cfg.acounts, treasury)
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("PendingNonceAt", mock.Anything, treasury.Address).Return(uint64(4), nil)
+	mockClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(21000), nil)
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{GasLimit: 30000000, BaseFee: big.NewInt(100)}, nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	tx := &Transaction{To: acc.Address, Value: big.NewInt(1)}
+	signedTx, err := gc.SignTransactionWithAccount("Treasury", tx)
+	assert.NoError(t, err)
+	assert.Equal(t, treasury.Address, tx.From)
+	from, err := types.Sender(gc.Signer(), signedTx)
+	assert.NoError(t, err)
+	assert.Equal(t, treasury.Address, from)
+	assert.Equal(t, uint64(4), signedTx.Nonce())
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_SendTransactionWithAccount(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	treasury := testTreasuryAccount(t)
+	cfg.acounts = append(cfg.acounts, treasury)
+	var sent *types.Transaction
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("PendingNonceAt", mock.Anything, treasury.Address).Return(uint64(4), nil)
+	mockClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(21000), nil)
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{GasLimit: 30000000, BaseFee: big.NewInt(100)}, nil)
+	mockClient.On("SendTransaction", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { sent = args.Get(1).(*types.Transaction) }).
+		Return(nil).Once()
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	receipt, err := gc.SendTransactionWithAccount("treasury", &Transaction{From: treasury.Address, To: acc.Address, Value: big.NewInt(1)})
+	assert.NoError(t, err)
+	assert.Equal(t, treasury.Address, receipt.From)
+	assert.Equal(t, sent.Hash(), receipt.TxHash)
+	from, err := types.Sender(gc.Signer(), sent)
+	assert.NoError(t, err)
+	assert.Equal(t, treasury.Address, from)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_SignTransactionWithAccount_Errors(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	treasury := testTreasuryAccount(t)
+	watchOnly := &Account{Address: treasury.Address, ChainId: 1, Label: "watch"}
+	cfg.acounts = append(cfg.acounts, treasury, watchOnly)
+	mockClient := &internalmocks.EthClient{}
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	_, err := gc.SignTransactionWithAccount("payer", &Transaction{To: acc.Address})
+	assert.ErrorContains(t, err, `unknown account label "payer"`)
+
+	_, err = gc.SignTransactionWithAccount("watch", &Transaction{To: acc.Address})
+	assert.ErrorContains(t, err, "has no private key")
+
+	_, err = gc.SignTransactionWithAccount("treasury", &Transaction{From: acc.Address, To: acc.Address})
+	assert.ErrorContains(t, err, "is not account \"treasury\"")
+
+	// -- accounts on another chain or endpoint need a client of their own, strict mode or not
+	otherChain := testTreasuryAccount(t)
+	otherChain.Label, otherChain.ChainId = "base", 8453
+	otherURL := testTreasuryAccount(t)
+	otherURL.Label, otherURL.RPCURL = "archive", "https://archive.example"
+	cfg.acounts = append(cfg.acounts, otherChain, otherURL)
+
+	_, err = gc.SignTransactionWithAccount("base", &Transaction{To: acc.Address})
+	assert.ErrorContains(t, err, "chain ID 8453 does not match connected chain 1")
+	assert.ErrorContains(t, err, "create a client for the account")
+
+	_, err = gc.SendTransactionWithAccount("archive", &Transaction{To: acc.Address})
+	assert.ErrorContains(t, err, "uses its own RPC URL")
+
+	mockClient.AssertNotCalled(t, "PendingNonceAt", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,78 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sirupsen/logrus"
+)
+
+// ActivityRange returns the blocks that included the first and the last transaction sent by addr,
+// found by binary searching its nonce over historical state, so it needs an archive node:
+// providers that can't serve old state fail with ErrHistoricalStateUnavailable. Incoming
+// transfers don't count, and an address that has never sent a transaction fails with ErrNoActivity.
+func (es *ghostClient) ActivityRange(ctx context.Context, addr common.Address) (firstBlock, lastBlock uint64, err error) {
+	header, err := es.readClient().HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get latest header: %w", err)
+	}
+	latest := header.Number.Uint64()
+
+	nonce, err := es.nonceAtBlock(ctx, addr, latest)
+	if err != nil {
+		return 0, 0, err
+	}
+	if nonce == 0 {
+		return 0, 0, fmt.Errorf("%w: %s", ErrNoActivity, addr.Hex())
+	}
+
+	firstBlock, err = es.searchNonce(ctx, addr, 1, 0, latest)
+	if err != nil {
+		return 0, 0, err
+	}
+	lastBlock, err = es.searchNonce(ctx, addr, nonce, firstBlock, latest)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	es.log.WithFields(logrus.Fields{
+		"address":     addr.Hex(),
+		"first_block": firstBlock,
+		"last_block":  lastBlock,
+		"nonce":       nonce,
+	}).Debug("Found address activity range")
+	return firstBlock, lastBlock, nil
+}
+
+// searchNonce returns the lowest block in [lo, hi] at which addr's nonce has reached target. The
+// nonce at hi must already have reached it.
+func (es *ghostClient) searchNonce(ctx context.Context, addr common.Address, target, lo, hi uint64) (uint64, error) {
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		nonce, err := es.nonceAtBlock(ctx, addr, mid)
+		if err != nil {
+			return 0, err
+		}
+		if nonce >= target {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo, nil
+}
+
+// nonceAtBlock returns addr's nonce after block, mapping errors from pruned state to
+// ErrHistoricalStateUnavailable
+func (es *ghostClient) nonceAtBlock(ctx context.Context, addr common.Address, block uint64) (uint64, error) {
+	nonce, err := es.readClient().NonceAt(ctx, addr, new(big.Int).SetUint64(block))
+	if err != nil {
+		if isMethodNotFound(err) || isMissingState(err) {
+			return 0, fmt.Errorf("%w: nonce at block %d: %v", ErrHistoricalStateUnavailable, block, err)
+		}
+		return 0, fmt.Errorf("failed to get nonce of %s at block %d: %w", addr.Hex(), block, err)
+	}
+	return nonce, nil
+}
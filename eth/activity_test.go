@@ -0,0 +1,139 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// testNonceHistory answers NonceAt as if addr sent one transaction in each of blocks
+func testNonceHistory(blocks ...uint64) func(context.Context, common.Address, *big.Int) uint64 {
+	return func(_ context.Context, _ common.Address, number *big.Int) uint64 {
+		var nonce uint64
+		for _, block := range blocks {
+			if number.Uint64() >= block {
+				nonce++
+			}
+		}
+		return nonce
+	}
+}
+
+func TestGhostClient_ActivityRange(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	addr := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).
+		Return(&types.Header{Number: big.NewInt(20_000_000)}, nil)
+	mockClient.On("NonceAt", mock.Anything, addr, mock.Anything).
+		Return(testNonceHistory(4_370_001, 4_370_001, 12_965_000, 19_999_999), nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	first, last, err := gc.ActivityRange(context.Background(), addr)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4_370_001), first)
+	assert.Equal(t, uint64(19_999_999), last)
+	// -- two binary searches over 20M blocks, not a scan
+	assert.LessOrEqual(t, len(mockClient.Calls), 60)
+}
+
+func TestGhostClient_ActivityRange_SingleTransaction(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	addr := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).
+		Return(&types.Header{Number: big.NewInt(1000)}, nil)
+	mockClient.On("NonceAt", mock.Anything, addr, mock.Anything).
+		Return(testNonceHistory(1000), nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	first, last, err := gc.ActivityRange(context.Background(), addr)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1000), first)
+	assert.Equal(t, uint64(1000), last)
+}
+
+func TestGhostClient_ActivityRange_NoActivity(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	addr := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).
+		Return(&types.Header{Number: big.NewInt(1000)}, nil)
+	mockClient.On("NonceAt", mock.Anything, addr, big.NewInt(1000)).Return(uint64(0), nil).Once()
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	_, _, err := gc.ActivityRange(context.Background(), addr)
+	assert.ErrorIs(t, err, ErrNoActivity)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_ActivityRange_PrunedState(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	addr := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).
+		Return(&types.Header{Number: big.NewInt(1000)}, nil)
+	mockClient.On("NonceAt", mock.Anything, addr, big.NewInt(1000)).Return(uint64(3), nil).Once()
+	mockClient.On("NonceAt", mock.Anything, addr, mock.Anything).
+		Return(uint64(0), &testRPCError{code: -32000, message: "missing trie node 1f0c3a (path ) state 0x1f0c3a is not available"})
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	_, _, err := gc.ActivityRange(context.Background(), addr)
+	assert.ErrorIs(t, err, ErrHistoricalStateUnavailable)
+}
+
+func TestGhostClient_ActivityRange_Error(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	addr := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).
+		Return(&types.Header{Number: big.NewInt(1000)}, nil)
+	mockClient.On("NonceAt", mock.Anything, addr, mock.Anything).Return(uint64(0), errors.New("connection reset"))
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	_, _, err := gc.ActivityRange(context.Background(), addr)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrHistoricalStateUnavailable)
+}
@@ -0,0 +1,95 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/sirupsen/logrus"
+)
+
+// TokenSpend declares that a transaction lets its recipient (e.g. a router) pull Amount of Token
+// from the sender, so the allowance can be checked, and with WithAutoApprove raised, first
+type TokenSpend struct {
+	Token  common.Address `json:"token"`
+	Amount *big.Int       `json:"amount"`
+}
+
+// Allowance returns how much of token spender may transfer on behalf of owner
+func (es *ghostClient) Allowance(ctx context.Context, token, owner, spender common.Address) (*big.Int, error) {
+	data, err := erc20ABI.Pack("allowance", owner, spender)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode allowance: %w", err)
+	}
+	out, err := es.readClient().CallContract(ctx, ethereum.CallMsg{To: &token, Data: data}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("allowance failed for token %s: %w", token.Hex(), err)
+	}
+	allowance, err := unpackUint256(erc20ABI, "allowance", out)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode allowance of token %s: %w", token.Hex(), err)
+	}
+	return allowance, nil
+}
+
+// EnsureAllowance makes sure spender may transfer at least amount of token from the account. If
+// the current allowance is lower it sends an approve transaction and waits for it to be mined,
+// reporting whether it did. The approval is for exactly amount unless WithAutoApprove(true)
+// configured unlimited approvals.
+func (es *ghostClient) EnsureAllowance(ctx context.Context, token, spender common.Address, amount *big.Int) (bool, error) {
+	return es.ensureAllowance(ctx, es.account, token, spender, amount)
+}
+
+// ensureAllowance is EnsureAllowance for the tokens of acc, which signs any approve
+func (es *ghostClient) ensureAllowance(ctx context.Context, acc *Account, token, spender common.Address, amount *big.Int) (bool, error) {
+	if amount == nil || amount.Sign() <= 0 {
+		return false, nil
+	}
+	owner := acc.Address
+
+	allowance, err := es.Allowance(ctx, token, owner, spender)
+	if err != nil {
+		return false, err
+	}
+	if allowance.Cmp(amount) >= 0 {
+		return false, nil
+	}
+
+	approveAmount := amount
+	if es.approveUnlimited {
+		approveAmount = math.MaxBig256
+	}
+	tx, err := NewContractTx(token, erc20ABI, "approve", big.NewInt(0), spender, approveAmount)
+	if err != nil {
+		return false, err
+	}
+	tx.From = owner
+
+	signedTx, err := es.signTransaction(acc, tx)
+	if err != nil {
+		return false, fmt.Errorf("failed to sign approve of token %s: %w", token.Hex(), err)
+	}
+	if _, err := es.sendTransaction(acc, signedTx); err != nil {
+		return false, fmt.Errorf("failed to send approve of token %s: %w", token.Hex(), err)
+	}
+	es.log.WithFields(logrus.Fields{
+		"token":     token.Hex(),
+		"spender":   spender.Hex(),
+		"allowance": allowance.String(),
+		"approve":   approveAmount.String(),
+		"hash":      signedTx.Hash().Hex(),
+	}).Info("Allowance too low, approve sent")
+
+	receipt, err := es.waitForTransaction(ctx, signedTx.Hash())
+	if err != nil {
+		return true, fmt.Errorf("failed waiting for approve of token %s: %w", token.Hex(), err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return true, fmt.Errorf("approve of token %s reverted: %s", token.Hex(), receipt.TxHash.Hex())
+	}
+	return true, nil
+}
@@ -0,0 +1,196 @@
+package eth
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	testToken   = common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	testSpender = common.HexToAddress("0x00000000000000000000000000000000000000a2")
+)
+
+// testApproveClient mocks an allowance lookup returning allowance, plus signing, sending and
+// mining of any transaction; sent transactions are appended to *sent
+func testApproveClient(t *testing.T, acc *Account, allowance *big.Int, sent *[]*types.Transaction) *internalmocks.EthClient {
+	mockClient := &internalmocks.EthClient{}
+	allowanceCall := mock.MatchedBy(func(msg ethereum.CallMsg) bool {
+		return msg.To != nil && *msg.To == testToken && len(msg.Data) >= 4 && common.Bytes2Hex(msg.Data[:4]) == "dd62ed3e"
+	})
+	mockClient.On("CallContract", mock.Anything, allowanceCall, (*big.Int)(nil)).
+		Return(testReturn(t, "allowance", allowance).ReturnData, nil)
+	mockClient.On("PendingNonceAt", mock.Anything, acc.Address).Return(
+		func(context.Context, common.Address) uint64 { return uint64(len(*sent)) }, nil)
+	mockClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(50000), nil)
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{GasLimit: 30000000, BaseFee: big.NewInt(100)}, nil)
+	mockClient.On("SendTransaction", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { *sent = append(*sent, args.Get(1).(*types.Transaction)) }).
+		Return(nil)
+	mockClient.On("SubscribeNewHead", mock.Anything, mock.Anything).Return(newTestSubscription(), nil)
+	mockClient.On("Close").Return()
+	mockClient.On("TransactionReceipt", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, hash common.Hash) *types.Receipt {
+			return &types.Receipt{TxHash: hash, Status: types.ReceiptStatusSuccessful, BlockNumber: big.NewInt(10)}
+		}, nil)
+	return mockClient
+}
+
+func testApproveGhostClient(mockClient *internalmocks.EthClient, acc *Account, cfg Config) *ghostClient {
+	return &ghostClient{
+		client:         mockClient,
+		ctx:            context.Background(),
+		chainId:        1,
+		account:        acc,
+		config:         cfg,
+		log:            newTestLogger(),
+		subscribeHeads: true,
+		lightReceipts:  true,
+	}
+}
+
+func TestGhostClient_EnsureAllowance_Approves(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	var sent []*types.Transaction
+	gc := testApproveGhostClient(testApproveClient(t, acc, big.NewInt(100), &sent), acc, cfg)
+
+	approved, err := gc.EnsureAllowance(context.Background(), testToken, testSpender, big.NewInt(500))
+	assert.NoError(t, err)
+	assert.True(t, approved)
+	assert.Len(t, sent, 1)
+	assert.Equal(t, testToken, *sent[0].To())
+	expected, err := EncodeCall(erc20ABI, "approve", testSpender, big.NewInt(500)) // exact amount by default
+	assert.NoError(t, err)
+	assert.Equal(t, expected, sent[0].Data())
+}
+
+func TestGhostClient_EnsureAllowance_Sufficient(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	var sent []*types.Transaction
+	mockClient := testApproveClient(t, acc, big.NewInt(500), &sent)
+	gc := testApproveGhostClient(mockClient, acc, cfg)
+
+	approved, err := gc.EnsureAllowance(context.Background(), testToken, testSpender, big.NewInt(500))
+	assert.NoError(t, err)
+	assert.False(t, approved)
+	assert.Empty(t, sent)
+	mockClient.AssertNotCalled(t, "SendTransaction", mock.Anything, mock.Anything)
+}
+
+func TestGhostClient_SendAsync_AutoApprove(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	var sent []*types.Transaction
+	gc := testApproveGhostClient(testApproveClient(t, acc, big.NewInt(0), &sent), acc, cfg)
+	ctx, cancel := context.WithCancel(context.Background())
+	gc.ctx, gc.cancel = ctx, cancel
+	WithAutoApprove(true)(gc)
+
+	swap := &Transaction{
+		From:       acc.Address,
+		To:         testSpender,
+		Value:      big.NewInt(0),
+		Data:       []byte{0x01, 0x02, 0x03, 0x04},
+		TokenSpend: &TokenSpend{Token: testToken, Amount: big.NewInt(500)},
+	}
+	f, err := gc.SendAsync(context.Background(), swap)
+	assert.NoError(t, err)
+	gc.Close()
+
+	assert.Len(t, sent, 2)
+	expected, err := EncodeCall(erc20ABI, "approve", testSpender, maxUint256())
+	assert.NoError(t, err)
+	assert.Equal(t, expected, sent[0].Data())
+	assert.Equal(t, uint64(0), sent[0].Nonce())
+	assert.Equal(t, f.Hash(), sent[1].Hash())
+	assert.Equal(t, testSpender, *sent[1].To())
+	assert.Equal(t, uint64(1), sent[1].Nonce())
+}
+
+func TestGhostClient_SendAsync_AutoApproveSkippedWhenSufficient(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	var sent []*types.Transaction
+	gc := testApproveGhostClient(testApproveClient(t, acc, big.NewInt(1000), &sent), acc, cfg)
+	ctx, cancel := context.WithCancel(context.Background())
+	gc.ctx, gc.cancel = ctx, cancel
+	WithAutoApprove(false)(gc)
+
+	swap := &Transaction{
+		From:       acc.Address,
+		To:         testSpender,
+		Value:      big.NewInt(0),
+		Data:       []byte{0x01, 0x02, 0x03, 0x04},
+		TokenSpend: &TokenSpend{Token: testToken, Amount: big.NewInt(500)},
+	}
+	_, err := gc.SendAsync(context.Background(), swap)
+	assert.NoError(t, err)
+	gc.Close()
+
+	assert.Len(t, sent, 1)
+	assert.Equal(t, testSpender, *sent[0].To())
+}
+
+func TestGhostClient_SignTransaction_AutoApprove(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	var sent []*types.Transaction
+	gc := testApproveGhostClient(testApproveClient(t, acc, big.NewInt(0), &sent), acc, cfg)
+	WithAutoApprove(false)(gc)
+
+	swap := &Transaction{
+		From:       acc.Address,
+		To:         testSpender,
+		Value:      big.NewInt(0),
+		Data:       []byte{0x01, 0x02, 0x03, 0x04},
+		TokenSpend: &TokenSpend{Token: testToken, Amount: big.NewInt(500)},
+	}
+	signedTx, err := gc.SignTransaction(swap)
+	assert.NoError(t, err)
+
+	// -- the approve is sent and mined at sign time; the swap itself is left to the caller
+	assert.Len(t, sent, 1)
+	expected, err := EncodeCall(erc20ABI, "approve", testSpender, big.NewInt(500))
+	assert.NoError(t, err)
+	assert.Equal(t, expected, sent[0].Data())
+	assert.Equal(t, uint64(1), signedTx.Nonce())
+}
+
+func TestGhostClient_SendTransactionWithAccount_AutoApprove(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	treasury := testTreasuryAccount(t)
+	cfg.acounts = append(cfg.acounts, treasury)
+	var sent []*types.Transaction
+	mockClient := testApproveClient(t, acc, big.NewInt(0), &sent)
+	mockClient.On("PendingNonceAt", mock.Anything, treasury.Address).Return(
+		func(context.Context, common.Address) uint64 { return uint64(len(sent)) }, nil)
+	gc := testApproveGhostClient(mockClient, acc, cfg)
+	WithAutoApprove(false)(gc)
+
+	_, err := gc.SendTransactionWithAccount("treasury", &Transaction{
+		To:         testSpender,
+		Value:      big.NewInt(0),
+		Data:       []byte{0x01, 0x02, 0x03, 0x04},
+		TokenSpend: &TokenSpend{Token: testToken, Amount: big.NewInt(500)},
+	})
+	assert.NoError(t, err)
+
+	// -- the allowance is the treasury's, so the treasury signs the approve
+	assert.Len(t, sent, 2)
+	signer := types.LatestSignerForChainID(big.NewInt(1))
+	for _, tx := range sent {
+		from, err := types.Sender(signer, tx)
+		assert.NoError(t, err)
+		assert.Equal(t, treasury.Address, from)
+	}
+	assert.Equal(t, testToken, *sent[0].To())
+	assert.Equal(t, testSpender, *sent[1].To())
+}
+
+func maxUint256() *big.Int {
+	return new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+}
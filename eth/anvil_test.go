@@ -0,0 +1,28 @@
+package eth
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadAnvilAccounts(t *testing.T) {
+	accounts, err := LoadAnvilAccounts(3, 31337)
+	assert.NoError(t, err)
+	assert.Len(t, accounts, 3)
+
+	assert.Equal(t, common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"), accounts[0].Address)
+	assert.Equal(t, common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"), accounts[1].Address)
+	assert.Equal(t, "anvil_0", accounts[0].Label)
+	assert.Equal(t, int64(31337), accounts[0].ChainId)
+	assert.NotNil(t, accounts[0].PublicKey)
+	assert.NotNil(t, accounts[0].PrivateKey)
+}
+
+func TestLoadAnvilAccounts_InvalidCount(t *testing.T) {
+	_, err := LoadAnvilAccounts(0, 31337)
+	assert.Error(t, err)
+	_, err = LoadAnvilAccounts(11, 31337)
+	assert.Error(t, err)
+}
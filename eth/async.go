@@ -0,0 +1,77 @@
+package eth
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TxFuture tracks a sent transaction until it is mined. The hash is available immediately;
+// the receipt once the background wait completes.
+type TxFuture struct {
+	hash common.Hash
+	done chan struct{}
+
+	// set before done is closed
+	receipt *TransactionReceipt
+	err     error
+}
+
+// Hash returns the hash of the sent transaction
+func (f *TxFuture) Hash() common.Hash {
+	return f.hash
+}
+
+// Done returns a channel that is closed once the transaction is mined or waiting fails
+func (f *TxFuture) Done() <-chan struct{} {
+	return f.done
+}
+
+// Wait blocks until the future resolves or ctx is done. Cancelling ctx only stops this call;
+// the background wait continues until the transaction is mined, times out or the client closes.
+func (f *TxFuture) Wait(ctx context.Context) (*TransactionReceipt, error) {
+	select {
+	case <-f.done:
+		return f.receipt, f.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// SendAsync signs and sends a transaction, then waits for it in a background goroutine. ctx is
+// checked before signing and again before broadcasting, so a cancelled ctx never sends the
+// transaction; the RPC calls themselves, like those of SignTransaction and SendTransaction, run on
+// the client's context. The background wait is bound to the client and stops when it is closed.
+func (es *ghostClient) SendAsync(ctx context.Context, tx *Transaction) (*TxFuture, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	signedTx, err := es.SignTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		es.resyncNonce(tx.From, signedTx.Nonce())
+		return nil, err
+	}
+	if _, err := es.SendTransaction(signedTx); err != nil {
+		return nil, err
+	}
+
+	f := &TxFuture{
+		hash: signedTx.Hash(),
+		done: make(chan struct{}),
+	}
+	waitCtx := es.ctx
+	es.async.Add(1)
+	go func() {
+		defer es.async.Done()
+		defer close(f.done)
+		f.receipt, f.err = es.waitForTransaction(waitCtx, f.hash)
+		if f.err != nil {
+			es.log.WithError(f.err).WithField("hash", f.hash.Hex()).Warn("Async transaction wait failed")
+		}
+	}()
+	return f, nil
+}
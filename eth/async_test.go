@@ -0,0 +1,155 @@
+package eth
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// testAsyncClient mocks signing and sending a transfer, with heads delivered on demand
+func testAsyncClient(acc *Account) (*internalmocks.EthClient, chan<- *types.Header) {
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("PendingNonceAt", mock.Anything, acc.Address).Return(uint64(1), nil)
+	mockClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(21000), nil)
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{Number: big.NewInt(42), GasLimit: 30000000, BaseFee: big.NewInt(100)}, nil)
+	mockClient.On("SendTransaction", mock.Anything, mock.Anything).Return(nil)
+
+	heads := make(chan *types.Header)
+	mockClient.On("SubscribeNewHead", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			out := args.Get(1).(chan<- *types.Header)
+			go func() {
+				for h := range heads {
+					out <- h
+				}
+			}()
+		}).
+		Return(newTestSubscription(), nil)
+	return mockClient, heads
+}
+
+func TestGhostClient_SendAsync(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient, heads := testAsyncClient(acc)
+	defer close(heads)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	gc := &ghostClient{
+		client:         mockClient,
+		ctx:            ctx,
+		cancel:         cancel,
+		chainId:        1,
+		account:        acc,
+		config:         cfg,
+		log:            newTestLogger(),
+		subscribeHeads: true,
+	}
+
+	mined := make(chan struct{})
+	mockClient.On("TransactionReceipt", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, hash common.Hash) *types.Receipt {
+			select {
+			case <-mined:
+				receipt, _ := testMinedTransaction(hash, 42)
+				return receipt
+			default:
+				return nil
+			}
+		},
+		func(_ context.Context, _ common.Hash) error {
+			select {
+			case <-mined:
+				return nil
+			default:
+				return ethereum.NotFound
+			}
+		})
+	mockClient.On("TransactionByHash", mock.Anything, mock.Anything).Return(types.NewTx(&types.DynamicFeeTx{To: &acc.Address}), true, nil)
+
+	future, err := gc.SendAsync(context.Background(), &Transaction{From: acc.Address, To: acc.Address, Value: big.NewInt(1)})
+	assert.NoError(t, err)
+	assert.NotEqual(t, common.Hash{}, future.Hash())
+
+	select {
+	case <-future.Done():
+		t.Fatal("future resolved before the transaction was mined")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(mined)
+	heads <- &types.Header{Number: big.NewInt(42)}
+
+	receipt, err := future.Wait(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, future.Hash(), receipt.TxHash)
+	assert.Equal(t, uint64(42), receipt.BlockNumber)
+}
+
+func TestGhostClient_SendAsync_StopsOnClose(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient, heads := testAsyncClient(acc)
+	defer close(heads)
+	mockClient.On("TransactionReceipt", mock.Anything, mock.Anything).Return(nil, ethereum.NotFound)
+	mockClient.On("Close").Return()
+	ctx, cancel := context.WithCancel(context.Background())
+	gc := &ghostClient{
+		client:         mockClient,
+		ctx:            ctx,
+		cancel:         cancel,
+		chainId:        1,
+		account:        acc,
+		config:         cfg,
+		log:            newTestLogger(),
+		subscribeHeads: true,
+	}
+
+	future, err := gc.SendAsync(context.Background(), &Transaction{From: acc.Address, To: acc.Address, Value: big.NewInt(1)})
+	assert.NoError(t, err)
+
+	gc.Close()
+
+	select {
+	case <-future.Done():
+	default:
+		t.Fatal("future not resolved after Close")
+	}
+	_, err = future.Wait(context.Background())
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestGhostClient_SendAsync_CancelledWhileSigning(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	ctx, cancel := context.WithCancel(context.Background())
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("PendingNonceAt", mock.Anything, acc.Address).Run(func(mock.Arguments) { cancel() }).Return(uint64(1), nil)
+	mockClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(21000), nil)
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{GasLimit: 30000000, BaseFee: big.NewInt(100)}, nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	_, err := gc.SendAsync(ctx, &Transaction{From: acc.Address, To: acc.Address, Value: big.NewInt(1)})
+	assert.ErrorIs(t, err, context.Canceled)
+	mockClient.AssertNotCalled(t, "SendTransaction", mock.Anything, mock.Anything)
+}
+
+func TestTxFuture_WaitRespectsCallerContext(t *testing.T) {
+	f := &TxFuture{done: make(chan struct{})}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := f.Wait(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
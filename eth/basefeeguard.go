@@ -0,0 +1,79 @@
+package eth
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/sirupsen/logrus"
+)
+
+// guardBaseFee re-reads the base fee just before the EIP-1559 transaction signedTx is broadcast.
+// While signedTx's fee cap still covers it, signedTx is returned unchanged. Otherwise it is
+// re-signed with acc at twice the current base fee plus its tip, capped at ETH_MAX_FEE_PER_GAS,
+// and ErrBaseFeeAboveCeiling is returned when even the ceiling doesn't cover the base fee.
+func (es *ghostClient) guardBaseFee(acc *Account, signedTx *types.Transaction) (*types.Transaction, error) {
+	header, err := es.feeHeader(es.ctx)
+	if err != nil {
+		return nil, err
+	}
+	baseFee := header.BaseFee
+	if baseFee == nil || baseFee.Cmp(signedTx.GasFeeCap()) <= 0 {
+		return signedTx, nil
+	}
+
+	ceiling := es.config.MaxFeePerGas()
+	if baseFee.Cmp(ceiling) > 0 {
+		return nil, fmt.Errorf("%w: base fee %s, max fee per gas %s", ErrBaseFeeAboveCeiling, baseFee, ceiling)
+	}
+	tip := new(big.Int).Set(signedTx.GasTipCap())
+	maxFee := new(big.Int).Add(new(big.Int).Mul(baseFee, big.NewInt(2)), tip)
+	if maxFee.Cmp(ceiling) > 0 {
+		maxFee.Set(ceiling)
+	}
+	if tip.Cmp(maxFee) > 0 {
+		tip.Set(maxFee)
+	}
+
+	es.log.WithFields(logrus.Fields{
+		"hash":                signedTx.Hash().Hex(),
+		"base_fee":            baseFee.String(),
+		"old_max_fee_per_gas": signedTx.GasFeeCap().String(),
+		"max_fee_per_gas":     maxFee.String(),
+	}).Warn("Base fee rose above max fee per gas, re-signing transaction")
+	return es.resignWithFees(acc, signedTx, maxFee, tip)
+}
+
+// resignWithFees re-signs the EIP-1559 transaction signedTx with acc with the same nonce,
+// recipient, value, gas limit, data and access list, and the given fees. signedTx must have been
+// signed by acc.
+func (es *ghostClient) resignWithFees(acc *Account, signedTx *types.Transaction, maxFee, tip *big.Int) (*types.Transaction, error) {
+	from, err := types.Sender(es.Signer(), signedTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover sender: %w", err)
+	}
+	if from != acc.Address {
+		return nil, fmt.Errorf("transaction is from %s, not the signing account", from.Hex())
+	}
+
+	tx := &Transaction{
+		From:                 from,
+		Value:                signedTx.Value(),
+		Data:                 signedTx.Data(),
+		GasLimit:             signedTx.Gas(),
+		MaxFeePerGas:         maxFee,
+		MaxPriorityFeePerGas: tip,
+		Nonce:                signedTx.Nonce(),
+		AccessList:           signedTx.AccessList(),
+	}
+	if to := signedTx.To(); to != nil {
+		tx.To = *to
+	} else {
+		tx.IsContractCreation = true
+	}
+	ethereumTx, err := es.buildTx(tx)
+	if err != nil {
+		return nil, err
+	}
+	return types.SignTx(ethereumTx, es.Signer(), acc.PrivateKey)
+}
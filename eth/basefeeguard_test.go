@@ -0,0 +1,155 @@
+package eth
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGhostClient_SendTransaction_BaseFeeGuard_Resigns(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	signedTx := testBroadcastTx(t, acc) // max fee 100 wei, tip 1 wei
+
+	var sent *types.Transaction
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).
+		Return(&types.Header{Number: big.NewInt(42), BaseFee: big.NewInt(150)}, nil).Once()
+	mockClient.On("SendTransaction", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { sent = args.Get(1).(*types.Transaction) }).
+		Return(nil).Once()
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	WithBaseFeeGuard()(gc)
+
+	receipt, err := gc.SendTransaction(signedTx)
+	assert.NoError(t, err)
+	assert.NotNil(t, sent)
+	assert.NotEqual(t, signedTx.Hash(), sent.Hash())
+	assert.Equal(t, sent.Hash(), receipt.TxHash)
+
+	assert.Equal(t, big.NewInt(301), sent.GasFeeCap())
+	assert.Equal(t, signedTx.GasTipCap(), sent.GasTipCap())
+	assert.Equal(t, signedTx.Nonce(), sent.Nonce())
+	assert.Equal(t, signedTx.To(), sent.To())
+	assert.Equal(t, signedTx.Gas(), sent.Gas())
+	from, err := types.Sender(types.LatestSignerForChainID(big.NewInt(1)), sent)
+	assert.NoError(t, err)
+	assert.Equal(t, acc.Address, from)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_SendTransaction_BaseFeeGuard_CappedAtCeiling(t *testing.T) {
+	t.Setenv(envMaxFeePerGas, "200")
+	acc, cfg := testAccountAndConfig()
+	signedTx := testBroadcastTx(t, acc)
+
+	var sent *types.Transaction
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).
+		Return(&types.Header{Number: big.NewInt(42), BaseFee: big.NewInt(150)}, nil).Once()
+	mockClient.On("SendTransaction", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { sent = args.Get(1).(*types.Transaction) }).
+		Return(nil).Once()
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	WithBaseFeeGuard()(gc)
+
+	_, err := gc.SendTransaction(signedTx)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(200), sent.GasFeeCap())
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_SendTransaction_BaseFeeGuard_AboveCeiling(t *testing.T) {
+	t.Setenv(envMaxFeePerGas, "120")
+	acc, cfg := testAccountAndConfig()
+	signedTx := testBroadcastTx(t, acc)
+
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).
+		Return(&types.Header{Number: big.NewInt(42), BaseFee: big.NewInt(150)}, nil).Once()
+	mockClient.On("PendingNonceAt", mock.Anything, acc.Address).Return(signedTx.Nonce(), nil).Once()
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	WithBaseFeeGuard()(gc)
+	gc.nonces = gc.newNonceManager()
+	_, err := gc.nonces.Next(context.Background(), acc.Address)
+	assert.NoError(t, err)
+
+	_, err = gc.SendTransaction(signedTx)
+	assert.ErrorIs(t, err, ErrBaseFeeAboveCeiling)
+	mockClient.AssertNotCalled(t, "SendTransaction", mock.Anything, mock.Anything)
+
+	// -- the transaction never went out, so its nonce is handed out again
+	nonce, err := gc.nonces.Next(context.Background(), acc.Address)
+	assert.NoError(t, err)
+	assert.Equal(t, signedTx.Nonce(), nonce)
+}
+
+func TestGhostClient_SendTransaction_BaseFeeGuard_BelowMaxFee(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	signedTx := testBroadcastTx(t, acc)
+
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).
+		Return(&types.Header{Number: big.NewInt(42), BaseFee: big.NewInt(90)}, nil).Once()
+	mockClient.On("SendTransaction", mock.Anything, signedTx).Return(nil).Once()
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	WithBaseFeeGuard()(gc)
+
+	receipt, err := gc.SendTransaction(signedTx)
+	assert.NoError(t, err)
+	assert.Equal(t, signedTx.Hash(), receipt.TxHash)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_SendTransaction_BaseFeeGuardDisabled(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	signedTx := testBroadcastTx(t, acc)
+
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("SendTransaction", mock.Anything, signedTx).Return(nil).Once()
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	receipt, err := gc.SendTransaction(signedTx)
+	assert.NoError(t, err)
+	assert.Equal(t, signedTx.Hash(), receipt.TxHash)
+	mockClient.AssertNotCalled(t, "HeaderByNumber", mock.Anything, mock.Anything)
+}
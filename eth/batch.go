@@ -0,0 +1,114 @@
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// batchCall sends batch as consecutive JSON-RPC batches of at most ETH_RPC_MAX_BATCH_SIZE calls.
+// When a whole batch fails, e.g. on a transport error, the error is set on each of its elements,
+// so callers only need to check elem.Error.
+func (es *ghostClient) batchCall(ctx context.Context, batch []rpc.BatchElem) {
+	size := es.config.RPCMaxBatchSize()
+	for start := 0; start < len(batch); start += size {
+		chunk := batch[start:min(start+size, len(batch))]
+		if err := es.rpcClient().BatchCallContext(ctx, chunk); err != nil {
+			es.log.WithError(err).WithField("calls", len(chunk)).Error("JSON-RPC batch failed")
+			for i := range chunk {
+				chunk[i].Error = err
+			}
+		}
+	}
+}
+
+// GetBalances returns the latest ETH balance of each address, read in JSON-RPC batches of at
+// most ETH_RPC_MAX_BATCH_SIZE. Results are in the order of addrs with a per-address error.
+func (es *ghostClient) GetBalances(ctx context.Context, addrs []common.Address) ([]*big.Int, []error) {
+	balances := make([]*big.Int, len(addrs))
+	errs := make([]error, len(addrs))
+
+	results := make([]hexutil.Big, len(addrs))
+	batch := make([]rpc.BatchElem, len(addrs))
+	for i, addr := range addrs {
+		batch[i] = rpc.BatchElem{
+			Method: "eth_getBalance",
+			Args:   []interface{}{addr, "latest"},
+			Result: &results[i],
+		}
+	}
+	es.batchCall(ctx, batch)
+
+	for i, elem := range batch {
+		if elem.Error != nil {
+			errs[i] = fmt.Errorf("failed to get balance of %s: %w", addrs[i].Hex(), elem.Error)
+			continue
+		}
+		balances[i] = results[i].ToInt()
+	}
+
+	es.log.WithField("addresses", len(addrs)).Info("Batch balance lookup complete")
+	return balances, errs
+}
+
+// GetReceipts returns the receipt of each transaction, read in JSON-RPC batches of at most
+// ETH_RPC_MAX_BATCH_SIZE. Results are in the order of hashes with a per-hash error, wrapping
+// ethereum.NotFound for transactions that are pending or unknown. From and To are those the node
+// reports; Value is left unset.
+func (es *ghostClient) GetReceipts(ctx context.Context, hashes []common.Hash) ([]*TransactionReceipt, []error) {
+	receipts := make([]*TransactionReceipt, len(hashes))
+	errs := make([]error, len(hashes))
+
+	// -- kept raw to decode the sender and recipient the node reports alongside the receipt; nil
+	// when the node returned null
+	results := make([]*json.RawMessage, len(hashes))
+	batch := make([]rpc.BatchElem, len(hashes))
+	for i, hash := range hashes {
+		batch[i] = rpc.BatchElem{
+			Method: "eth_getTransactionReceipt",
+			Args:   []interface{}{hash},
+			Result: &results[i],
+		}
+	}
+	es.batchCall(ctx, batch)
+
+	for i, elem := range batch {
+		if elem.Error != nil {
+			errs[i] = fmt.Errorf("failed to get receipt for %s: %w", hashes[i].Hex(), elem.Error)
+			continue
+		}
+		if results[i] == nil {
+			errs[i] = fmt.Errorf("failed to get receipt for %s: %w", hashes[i].Hex(), ethereum.NotFound)
+			continue
+		}
+		var receipt types.Receipt
+		var parties struct {
+			From common.Address  `json:"from"`
+			To   *common.Address `json:"to"`
+		}
+		if err := json.Unmarshal(*results[i], &receipt); err != nil {
+			errs[i] = fmt.Errorf("failed to decode receipt for %s: %w", hashes[i].Hex(), err)
+			continue
+		}
+		if err := json.Unmarshal(*results[i], &parties); err != nil {
+			errs[i] = fmt.Errorf("failed to decode receipt for %s: %w", hashes[i].Hex(), err)
+			continue
+		}
+		r := newTransactionReceipt(&receipt, nil, parties.From)
+		if parties.To != nil {
+			r.To = *parties.To
+		}
+		r.Metadata = es.metadata.get(hashes[i])
+		receipts[i] = r
+	}
+
+	es.log.WithField("transactions", len(hashes)).Info("Batch receipt lookup complete")
+	return receipts, errs
+}
@@ -0,0 +1,149 @@
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGhostClient_GetBalances_SplitsBatches(t *testing.T) {
+	t.Setenv(envRPCMaxBatchSize, "100")
+	acc, cfg := testAccountAndConfig()
+	addrs := make([]common.Address, 250)
+	for i := range addrs {
+		addrs[i] = common.BigToAddress(big.NewInt(int64(i + 1)))
+	}
+
+	var sizes []int
+	mockRPC := &internalmocks.RPCClient{}
+	mockRPC.On("BatchCallContext", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			batch := args.Get(1).([]rpc.BatchElem)
+			sizes = append(sizes, len(batch))
+			for _, elem := range batch {
+				// -- each address holds its own number in wei
+				addr := elem.Args[0].(common.Address)
+				*elem.Result.(*hexutil.Big) = hexutil.Big(*new(big.Int).SetBytes(addr.Bytes()))
+			}
+		}).
+		Return(nil)
+	gc := &ghostClient{
+		rpc:     mockRPC,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	balances, errs := gc.GetBalances(context.Background(), addrs)
+	assert.Equal(t, []int{100, 100, 50}, sizes)
+	mockRPC.AssertNumberOfCalls(t, "BatchCallContext", 3)
+	for i := range addrs {
+		assert.NoError(t, errs[i])
+		assert.Equal(t, int64(i+1), balances[i].Int64())
+	}
+}
+
+func TestGhostClient_GetBalances_BatchError(t *testing.T) {
+	t.Setenv(envRPCMaxBatchSize, "2")
+	acc, cfg := testAccountAndConfig()
+	addrs := []common.Address{common.HexToAddress("0x01"), common.HexToAddress("0x02"), common.HexToAddress("0x03")}
+
+	mockRPC := &internalmocks.RPCClient{}
+	mockRPC.On("BatchCallContext", mock.Anything, mock.MatchedBy(func(b []rpc.BatchElem) bool { return len(b) == 2 })).
+		Return(errors.New("connection reset")).Once()
+	mockRPC.On("BatchCallContext", mock.Anything, mock.MatchedBy(func(b []rpc.BatchElem) bool { return len(b) == 1 })).
+		Run(func(args mock.Arguments) {
+			batch := args.Get(1).([]rpc.BatchElem)
+			*batch[0].Result.(*hexutil.Big) = hexutil.Big(*big.NewInt(5))
+		}).
+		Return(nil).Once()
+	gc := &ghostClient{
+		rpc:     mockRPC,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	balances, errs := gc.GetBalances(context.Background(), addrs)
+	assert.ErrorContains(t, errs[0], "connection reset")
+	assert.ErrorContains(t, errs[1], "connection reset")
+	assert.NoError(t, errs[2])
+	assert.Equal(t, big.NewInt(5), balances[2])
+	mockRPC.AssertExpectations(t)
+}
+
+func TestGhostClient_GetReceipts_SplitsBatches(t *testing.T) {
+	t.Setenv(envRPCMaxBatchSize, "100")
+	acc, cfg := testAccountAndConfig()
+	to := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	hashes := make([]common.Hash, 250)
+	for i := range hashes {
+		hashes[i] = common.BigToHash(big.NewInt(int64(i + 1)))
+	}
+	pending := hashes[249]
+
+	mockRPC := &internalmocks.RPCClient{}
+	mockRPC.On("BatchCallContext", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			for _, elem := range args.Get(1).([]rpc.BatchElem) {
+				hash := elem.Args[0].(common.Hash)
+				if hash == pending {
+					continue
+				}
+				receipt := fmt.Sprintf(`{
+					"transactionHash": "%s", "blockHash": "0x%064x", "blockNumber": "0x64", "transactionIndex": "0x0",
+					"status": "0x1", "gasUsed": "0x5208", "cumulativeGasUsed": "0x5208", "effectiveGasPrice": "0x3b9aca00",
+					"logs": [], "logsBloom": "0x%0512x", "type": "0x2", "from": "%s", "to": "%s"
+				}`, hash.Hex(), 1, 0, acc.Address.Hex(), to.Hex())
+				raw := json.RawMessage(receipt)
+				*elem.Result.(**json.RawMessage) = &raw
+			}
+		}).
+		Return(nil)
+	gc := &ghostClient{
+		rpc:     mockRPC,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	receipts, errs := gc.GetReceipts(context.Background(), hashes)
+	mockRPC.AssertNumberOfCalls(t, "BatchCallContext", 3)
+	for i, hash := range hashes[:249] {
+		assert.NoError(t, errs[i])
+		assert.Equal(t, hash, receipts[i].TxHash)
+		assert.Equal(t, uint64(100), receipts[i].BlockNumber)
+		assert.Equal(t, acc.Address, receipts[i].From)
+		assert.Equal(t, to, receipts[i].To)
+		assert.Equal(t, big.NewInt(GWEI), receipts[i].EffectiveGasPrice)
+	}
+	assert.Nil(t, receipts[249])
+	assert.ErrorIs(t, errs[249], ethereum.NotFound)
+}
+
+func TestRPCMaxBatchSize(t *testing.T) {
+	cfg := &config{}
+	t.Setenv(envRPCMaxBatchSize, "")
+	assert.Equal(t, DEFAULT_RPC_MAX_BATCH_SIZE, cfg.RPCMaxBatchSize())
+	t.Setenv(envRPCMaxBatchSize, "25")
+	assert.Equal(t, 25, cfg.RPCMaxBatchSize())
+	t.Setenv(envRPCMaxBatchSize, "0")
+	assert.Equal(t, DEFAULT_RPC_MAX_BATCH_SIZE, cfg.RPCMaxBatchSize())
+}
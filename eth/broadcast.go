@@ -0,0 +1,52 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// broadcastCheckInterval is how often a broadcast transaction is looked up while confirming it
+const broadcastCheckInterval = 250 * time.Millisecond
+
+// confirmBroadcast polls the write endpoint until it reports the transaction as known, failing
+// with ErrBroadcastNotAccepted if it doesn't within the configured window. Some providers accept
+// eth_sendRawTransaction and then silently drop the transaction.
+func (es *ghostClient) confirmBroadcast(hash common.Hash) error {
+	ctx, cancel := context.WithTimeout(es.ctx, es.broadcastCheck)
+	defer cancel()
+
+	interval := broadcastCheckInterval
+	if es.broadcastCheck < interval {
+		interval = es.broadcastCheck
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var lastErr error
+	for {
+		_, _, err := es.writeClient().TransactionByHash(ctx, hash)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, ethereum.NotFound) {
+			lastErr = err
+		}
+
+		select {
+		case <-ctx.Done():
+			if es.ctx.Err() != nil {
+				return es.ctx.Err()
+			}
+			if lastErr != nil {
+				return fmt.Errorf("%w: %s not found after %s: %v", ErrBroadcastNotAccepted, hash.Hex(), es.broadcastCheck, lastErr)
+			}
+			return fmt.Errorf("%w: %s not found after %s", ErrBroadcastNotAccepted, hash.Hex(), es.broadcastCheck)
+		case <-ticker.C:
+		}
+	}
+}
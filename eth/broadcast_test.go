@@ -0,0 +1,88 @@
+package eth
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testBroadcastTx(t *testing.T, acc *Account) *types.Transaction {
+	to := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	tx, err := types.SignNewTx(acc.PrivateKey, types.LatestSignerForChainID(big.NewInt(1)), &types.DynamicFeeTx{
+		ChainID: big.NewInt(1), To: &to, Gas: 21000, GasFeeCap: big.NewInt(100), GasTipCap: big.NewInt(1),
+	})
+	assert.NoError(t, err)
+	return tx
+}
+
+func TestGhostClient_SendTransaction_BroadcastCheck_Found(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	tx := testBroadcastTx(t, acc)
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("SendTransaction", mock.Anything, tx).Return(nil)
+	// unknown on the first lookup, then propagated
+	mockClient.On("TransactionByHash", mock.Anything, tx.Hash()).Return(nil, false, ethereum.NotFound).Once()
+	mockClient.On("TransactionByHash", mock.Anything, tx.Hash()).Return(tx, true, nil).Once()
+	gc := &ghostClient{
+		client:         mockClient,
+		ctx:            context.Background(),
+		chainId:        1,
+		account:        acc,
+		config:         cfg,
+		log:            newTestLogger(),
+		broadcastCheck: time.Second,
+	}
+
+	receipt, err := gc.SendTransaction(tx)
+	assert.NoError(t, err)
+	assert.Equal(t, tx.Hash(), receipt.TxHash)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_SendTransaction_BroadcastCheck_NotFound(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	tx := testBroadcastTx(t, acc)
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("SendTransaction", mock.Anything, tx).Return(nil)
+	mockClient.On("TransactionByHash", mock.Anything, tx.Hash()).Return(nil, false, ethereum.NotFound)
+	gc := &ghostClient{
+		client:         mockClient,
+		ctx:            context.Background(),
+		chainId:        1,
+		account:        acc,
+		config:         cfg,
+		log:            newTestLogger(),
+		broadcastCheck: 50 * time.Millisecond,
+	}
+
+	_, err := gc.SendTransaction(tx)
+	assert.ErrorIs(t, err, ErrBroadcastNotAccepted)
+	assert.ErrorContains(t, err, tx.Hash().Hex())
+}
+
+func TestGhostClient_SendTransaction_BroadcastCheck_Disabled(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	tx := testBroadcastTx(t, acc)
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("SendTransaction", mock.Anything, tx).Return(nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	_, err := gc.SendTransaction(tx)
+	assert.NoError(t, err)
+	mockClient.AssertNotCalled(t, "TransactionByHash", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,67 @@
+package eth
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// chainsJSON holds default fee settings, block times and, for beacon chains, finality epoch
+// lengths of widely used chains
+//
+//go:embed chains.json
+var chainsJSON []byte
+
+// ChainInfo is the built-in registry entry for a chain
+type ChainInfo struct {
+	ChainID     int64         `json:"chain_id"`
+	Name        string        `json:"name"`
+	PriorityFee *big.Int      `json:"-"` // default priority fee per gas in wei
+	BlockTime   time.Duration `json:"-"`
+	EpochBlocks uint64        `json:"epoch_blocks"` // blocks the finalized block advances by at a time; 0 if not per epoch
+}
+
+// chainRegistry maps chain IDs to their registry entries, loaded from chains.json at init
+var chainRegistry = mustLoadChains(chainsJSON)
+
+// LookupChain returns the built-in registry entry for a chain
+func LookupChain(chainID int64) (ChainInfo, bool) {
+	info, ok := chainRegistry[chainID]
+	if !ok {
+		return ChainInfo{}, false
+	}
+	info.PriorityFee = copyBig(info.PriorityFee)
+	return info, true
+}
+
+// mustLoadChains parses the embedded registry, panicking if it is malformed
+func mustLoadChains(data []byte) map[int64]ChainInfo {
+	var entries []struct {
+		ChainID        int64  `json:"chain_id"`
+		Name           string `json:"name"`
+		PriorityFeeWei string `json:"priority_fee_wei"`
+		BlockTimeMs    int64  `json:"block_time_ms"`
+		EpochBlocks    uint64 `json:"epoch_blocks"`
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		panic(fmt.Sprintf("invalid embedded chain registry: %v", err))
+	}
+
+	registry := make(map[int64]ChainInfo, len(entries))
+	for _, e := range entries {
+		fee, ok := new(big.Int).SetString(e.PriorityFeeWei, 10)
+		if !ok || fee.Sign() < 0 {
+			panic(fmt.Sprintf("invalid priority fee %q for chain %d in embedded chain registry", e.PriorityFeeWei, e.ChainID))
+		}
+		registry[e.ChainID] = ChainInfo{
+			ChainID:     e.ChainID,
+			Name:        e.Name,
+			PriorityFee: fee,
+			BlockTime:   time.Duration(e.BlockTimeMs) * time.Millisecond,
+			EpochBlocks: e.EpochBlocks,
+		}
+	}
+	return registry
+}
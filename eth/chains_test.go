@@ -0,0 +1,57 @@
+package eth
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLookupChain(t *testing.T) {
+	info, ok := LookupChain(137)
+	assert.True(t, ok)
+	assert.Equal(t, "Polygon", info.Name)
+	assert.Equal(t, big.NewInt(30*GWEI), info.PriorityFee)
+	assert.Equal(t, 2*time.Second, info.BlockTime)
+
+	// the registry can't be mutated through a lookup
+	info.PriorityFee.SetInt64(0)
+	again, _ := LookupChain(137)
+	assert.Equal(t, big.NewInt(30*GWEI), again.PriorityFee)
+
+	_, ok = LookupChain(999999)
+	assert.False(t, ok)
+}
+
+func TestGhostClient_CalculateOptimalFees_PolygonRegistryDefault(t *testing.T) {
+	t.Setenv("ETH_PRIORITY_FEE_DEFAULT", "")
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	header := &types.Header{GasLimit: 30000000, BaseFee: big.NewInt(100)}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(header, nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 137,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	tx := &Transaction{}
+	assert.NoError(t, gc.calculateOptimalFees(tx))
+	assert.Equal(t, big.NewInt(30*GWEI), tx.MaxPriorityFeePerGas)
+	mockClient.AssertExpectations(t)
+}
+
+func TestLookupChain_EpochBlocks(t *testing.T) {
+	mainnet, _ := LookupChain(1)
+	assert.Equal(t, uint64(32), mainnet.EpochBlocks)
+	base, _ := LookupChain(8453)
+	assert.Zero(t, base.EpochBlocks)
+}
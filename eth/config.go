@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"math/big"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/crypto"
 )
@@ -15,10 +17,21 @@ const (
 	envRpcURL  = "ETH_RPC_URL"
 	envChainID = "ETH_CHAIN_ID"
 
+	// -- optional read/write endpoint split, both default to ETH_RPC_URL
+	envRpcURLRead  = "ETH_RPC_URL_READ"
+	envRpcURLWrite = "ETH_RPC_URL_WRITE"
+	// Headers sent with every RPC request, as comma-separated "Name: value" pairs
+	// (e.g. "Authorization: Bearer <token>, X-Api-Key: <key>")
+	envRpcHeaders = "ETH_RPC_HEADERS"
+
 	// -- accounts and private keys
 	envAccountsList         = "ETH_ACCOUNTS"
 	envAccountPrivateKeyFmt = "ETH_ACCOUNT_%s_PRIVATE_KEY"
 	envAccountPublicKeyFmt  = "ETH_ACCOUNT_%s_PUBLIC_KEY"
+	// -- per-account chain and endpoint, for processes managing accounts across chains (default:
+	// ETH_CHAIN_ID and the configured RPC URLs)
+	envAccountChainIDFmt = "ETH_ACCOUNT_%s_CHAIN_ID"
+	envAccountRPCURLFmt  = "ETH_ACCOUNT_%s_RPC_URL"
 
 	// -- gas configuration
 	// Recommended settings:
@@ -33,6 +46,8 @@ const (
 	//   ETH_GAS_LIMIT_BUFFER_COMPLEX=1.25
 	envGasLimitBufferSimple  = "1.2" // Buffer for simple ETH transfers
 	envGasLimitBufferComplex = "1.4" // Buffer for complex transactions
+	// Gas added on top of the multiplied estimate, e.g. 5000 (default: 0)
+	envGasLimitBufferAbsolute = "ETH_GAS_LIMIT_BUFFER_ABSOLUTE"
 
 	// -- fee configuration
 	// Max fee per gas in wei (default: 500 gwei)
@@ -41,6 +56,44 @@ const (
 	envPriorityFeeMainnet = "ETH_PRIORITY_FEE_MAINNET"
 	envPriorityFeeBase    = "ETH_PRIORITY_FEE_BASE"
 	envPriorityFeeDefault = "ETH_PRIORITY_FEE_DEFAULT"
+	// Priority fee override for any other chain by ID (e.g. ETH_PRIORITY_FEE_137 for Polygon),
+	// taking precedence over ETH_PRIORITY_FEE_DEFAULT and the built-in chain registry
+	envPriorityFeeChainFmt = "ETH_PRIORITY_FEE_%d"
+	// How long fee readings (base fee and tip suggestion) are cached, in seconds
+	envFeeCacheTTLSeconds = "ETH_FEE_CACHE_TTL_SECONDS"
+	// Which block's base fee the fee calculation uses: latest, pending or next (projected from latest)
+	envBaseFeeSource = "ETH_BASE_FEE_SOURCE"
+	// How the priority fee is chosen: fixed (the priority fee settings above) or history (a
+	// percentile of the rewards paid in recent blocks, per eth_feeHistory)
+	envFeeStrategy = "ETH_FEE_STRATEGY"
+	// Blocks sampled and reward percentile used by the history fee strategy (defaults: 20, 50)
+	envFeeHistoryBlocks     = "ETH_FEE_HISTORY_BLOCKS"
+	envFeeHistoryPercentile = "ETH_FEE_HISTORY_PERCENTILE"
+
+	// -- most calls sent in one JSON-RPC batch; larger batches are split (default: 100)
+	envRPCMaxBatchSize = "ETH_RPC_MAX_BATCH_SIZE"
+
+	// -- confirmations WaitForConfirmations waits for when not given, i.e. blocks mined on top of the
+	// including one (default: 0, return once mined)
+	envConfirmations = "ETH_CONFIRMATIONS"
+
+	// -- strict mode, turns tolerated suspicious conditions into errors:
+	//   - a transaction with a nil Value (otherwise treated as zero)
+	//   - sending ETH to a contract whose code rejects it
+	//   - a gas limit that can't be checked against, or exceeds, the block gas cap (also for preset limits)
+	//   - an account or signed transaction whose chain ID doesn't match the connected chain
+	envStrictMode = "ETH_STRICT_MODE"
+	// -- refuse to sign legacy transactions, or anything on a chain without a base fee
+	envRequireEIP1559 = "ETH_REQUIRE_EIP1559"
+	// -- largest RLP-encoded transaction that may be broadcast, in bytes (default: 128 KiB, the
+	// mempool limit of go-ethereum-derived nodes)
+	envMaxTxSizeBytes = "ETH_MAX_TX_SIZE_BYTES"
+	// -- balance CheckFunds compares a transaction's cost against: pending (net of pending
+	// transactions, the default) or latest (confirmed only)
+	envBalanceBlockTag = "ETH_BALANCE_BLOCK_TAG"
+	// -- hand out nonces locally after reading the pending nonce once, instead of reading it for
+	// every transaction
+	envNonceManager = "ETH_NONCE_MANAGER"
 
 	// --- Units and defaults ---
 	GWEI = 1000000000 // 1 gwei in wei
@@ -53,29 +106,92 @@ const (
 	// --- Transaction monitoring defaults ---
 	DEFAULT_TRANSACTION_TIMEOUT_SECONDS = 300 // 5 minutes
 	DEFAULT_TRANSACTION_TICKER_SECONDS  = 3   // 3 seconds
+	DEFAULT_CONFIRMATIONS               = 0   // return once mined
+
+	// --- Fee cache defaults ---
+	DEFAULT_FEE_CACHE_TTL_SECONDS = 2 // 2 seconds
+
+	// --- Fee history strategy defaults ---
+	DEFAULT_FEE_HISTORY_BLOCKS     = 20 // recent blocks sampled
+	DEFAULT_FEE_HISTORY_PERCENTILE = 50 // median reward
+
+	// --- JSON-RPC batch defaults ---
+	DEFAULT_RPC_MAX_BATCH_SIZE = 100 // a common provider cap
+
+	// --- Transaction size defaults ---
+	DEFAULT_MAX_TX_SIZE_BYTES = 128 * 1024 // 128 KiB
+)
+
+// --- Base fee sources ---
+const (
+	BASE_FEE_SOURCE_LATEST  = "latest"  // base fee of the latest block
+	BASE_FEE_SOURCE_PENDING = "pending" // base fee of the pending block, as reported by the node
+	BASE_FEE_SOURCE_NEXT    = "next"    // next block's base fee projected from the latest block
+)
+
+// --- Balance block tags ---
+const (
+	BALANCE_BLOCK_TAG_PENDING = "pending" // balance after the account's pending transactions
+	BALANCE_BLOCK_TAG_LATEST  = "latest"  // balance as of the latest block
+)
+
+// --- Priority fee strategies ---
+const (
+	FEE_STRATEGY_FIXED   = "fixed"   // the configured per-chain priority fee
+	FEE_STRATEGY_HISTORY = "history" // a percentile of recent block rewards, falling back to fixed
 )
 
 type Config interface {
 	ChainID() int64
 	Accounts() []*Account
+	Account(label string) (*Account, bool)
 	RPCURL() string
+	RPCURLRead() string
+	RPCURLWrite() string
+	RPCHeaders() map[string]string
+	RPCMaxBatchSize() int
 
 	GasLimitBufferSimple() float64
 	GasLimitBufferComplex() float64
+	GasLimitBufferAbsolute() uint64
 
 	MaxFeePerGas() *big.Int
 	PriorityFeeMainnet() *big.Int
 	PriorityFeeBase() *big.Int
 	PriorityFeeDefault() *big.Int
+	PriorityFeeForChain(chainID int64) *big.Int
+	FeeCacheTTLSeconds() int
+	BaseFeeSource() string
+	FeeStrategy() string
+	FeeHistoryBlocks() uint64
+	FeeHistoryPercentile() float64
 
 	TransactionTimeoutSeconds() int
 	TransactionTickerSeconds() int
+	TransactionMaxPolls() int
+	Confirmations() uint64
+
+	StrictMode() bool
+	RequireEIP1559() bool
+	MaxTxSizeBytes() uint64
+	NonceManager() bool
+	BalanceBlockTag() string
+
+	// Reload re-reads the tunable settings from the environment
+	Reload() error
 }
 
 type config struct {
-	chainId int64
-	acounts []*Account
-	rpcURL  string
+	chainId     int64
+	acounts     []*Account
+	rpcURL      string
+	rpcURLRead  string
+	rpcURLWrite string
+
+	// env is the snapshot of tunable settings the getters read, replaced by Reload. A config
+	// without one (e.g. built directly in tests) reads the live environment.
+	mu  sync.RWMutex
+	env map[string]string
 }
 
 func NewConfiguration() (Config, error) {
@@ -102,12 +218,81 @@ func NewConfiguration() (Config, error) {
 	rpcURL := os.Getenv(envRpcURL)
 
 	return &config{
-		rpcURL:  rpcURL,
-		chainId: chainId,
-		acounts: accounts,
+		rpcURL:      rpcURL,
+		rpcURLRead:  os.Getenv(envRpcURLRead),
+		rpcURLWrite: os.Getenv(envRpcURLWrite),
+		chainId:     chainId,
+		acounts:     accounts,
+		env:         tunableEnv(),
 	}, nil
 }
 
+// Reload re-reads the fee, gas, timeout and other tunable settings from the environment, so they
+// can be adjusted at runtime (e.g. after godotenv.Overload of an edited .env file). The chain ID,
+// RPC URLs and accounts are fixed for the life of the client; if they changed, the tunables are
+// still applied and ErrConfigRequiresReconnect names the settings that need a new client.
+func (c *config) Reload() error {
+	env := tunableEnv()
+	c.mu.Lock()
+	c.env = env
+	c.mu.Unlock()
+
+	var stale []string
+	if os.Getenv(envChainID) != strconv.FormatInt(c.chainId, 10) {
+		stale = append(stale, envChainID)
+	}
+	for key, current := range map[string]string{envRpcURL: c.rpcURL, envRpcURLRead: c.rpcURLRead, envRpcURLWrite: c.rpcURLWrite} {
+		if os.Getenv(key) != current {
+			stale = append(stale, key)
+		}
+	}
+	if accounts, err := loadAccountsFromEnv(c.chainId); err != nil || !sameAccounts(accounts, c.acounts) {
+		stale = append(stale, envAccountsList)
+	}
+	if len(stale) > 0 {
+		sort.Strings(stale)
+		return fmt.Errorf("%w: %s changed", ErrConfigRequiresReconnect, strings.Join(stale, ", "))
+	}
+	return nil
+}
+
+// getenv returns a tunable setting from the snapshot, or the live environment without one
+func (c *config) getenv(key string) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.env == nil {
+		return os.Getenv(key)
+	}
+	return c.env[key]
+}
+
+// tunableEnv snapshots the settings Reload may change: every ETH_ variable except the account list
+// and keys, plus the gas buffer variables
+func tunableEnv() map[string]string {
+	env := make(map[string]string)
+	for _, kv := range os.Environ() {
+		key, value, _ := strings.Cut(kv, "=")
+		if key == envGasLimitBufferSimple || key == envGasLimitBufferComplex ||
+			strings.HasPrefix(key, "ETH_") && key != envAccountsList && !strings.HasPrefix(key, "ETH_ACCOUNT_") {
+			env[key] = value
+		}
+	}
+	return env
+}
+
+// sameAccounts reports whether both lists hold the same labels and addresses in the same order
+func sameAccounts(a, b []*Account) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Label != b[i].Label || a[i].Address != b[i].Address {
+			return false
+		}
+	}
+	return true
+}
+
 func (c *config) ChainID() int64 {
 	return c.chainId
 }
@@ -115,13 +300,60 @@ func (c *config) Accounts() []*Account {
 	return c.acounts
 }
 
+// Account returns the configured account with the given label, compared case-insensitively
+func (c *config) Account(label string) (*Account, bool) {
+	label = strings.TrimSpace(label)
+	for _, account := range c.acounts {
+		if strings.EqualFold(account.Label, label) {
+			return account, true
+		}
+	}
+	return nil, false
+}
+
 func (c *config) RPCURL() string {
 	return c.rpcURL
 }
 
+// RPCURLRead returns the endpoint used for reads (default: RPCURL)
+func (c *config) RPCURLRead() string {
+	if c.rpcURLRead != "" {
+		return c.rpcURLRead
+	}
+	return c.rpcURL
+}
+
+// RPCURLWrite returns the endpoint transactions are broadcast to (default: RPCURL)
+func (c *config) RPCURLWrite() string {
+	if c.rpcURLWrite != "" {
+		return c.rpcURLWrite
+	}
+	return c.rpcURL
+}
+
+// RPCHeaders returns the headers sent with every RPC request (default: none). Entries without
+// a name or a colon are ignored.
+func (c *config) RPCHeaders() map[string]string {
+	headersStr := c.getenv(envRpcHeaders)
+	if headersStr == "" {
+		return nil
+	}
+
+	headers := make(map[string]string)
+	for _, entry := range strings.Split(headersStr, ",") {
+		name, value, ok := strings.Cut(entry, ":")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			continue
+		}
+		headers[name] = strings.TrimSpace(value)
+	}
+	return headers
+}
+
 // GasLimitBufferSimple returns the buffer multiplier for simple ETH transfers
 func (c *config) GasLimitBufferSimple() float64 {
-	bufferStr := os.Getenv(envGasLimitBufferSimple)
+	bufferStr := c.getenv(envGasLimitBufferSimple)
 	if bufferStr == "" {
 		return 1.1 // Default 10% buffer for simple transfers
 	}
@@ -132,7 +364,7 @@ func (c *config) GasLimitBufferSimple() float64 {
 	}
 
 	// Validate reasonable bounds (0.5 to 3.0)
-	if buffer < 0.5 || buffer > 3.0 {
+	if buffer < minGasLimitBuffer || buffer > maxGasLimitBuffer {
 		return 1.1
 	}
 
@@ -141,7 +373,7 @@ func (c *config) GasLimitBufferSimple() float64 {
 
 // GasLimitBufferComplex returns the buffer multiplier for complex transactions
 func (c *config) GasLimitBufferComplex() float64 {
-	bufferStr := os.Getenv(envGasLimitBufferComplex)
+	bufferStr := c.getenv(envGasLimitBufferComplex)
 	if bufferStr == "" {
 		return 1.2 // Default 20% buffer for complex transactions
 	}
@@ -152,16 +384,25 @@ func (c *config) GasLimitBufferComplex() float64 {
 	}
 
 	// Ennsure reasonable bounds (0.5 to 3.0)
-	if buffer < 0.5 || buffer > 3.0 {
+	if buffer < minGasLimitBuffer || buffer > maxGasLimitBuffer {
 		return 1.2
 	}
 
 	return buffer
 }
 
+// GasLimitBufferAbsolute returns the gas added to every buffered estimate (default: 0)
+func (c *config) GasLimitBufferAbsolute() uint64 {
+	buffer, err := strconv.ParseUint(c.getenv(envGasLimitBufferAbsolute), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return buffer
+}
+
 // MaxFeePerGas returns the max fee per gas in wei (default: 500 gwei)
 func (c *config) MaxFeePerGas() *big.Int {
-	maxFeeStr := os.Getenv(envMaxFeePerGas)
+	maxFeeStr := c.getenv(envMaxFeePerGas)
 	if maxFeeStr == "" {
 		return big.NewInt(DEFAULT_MAX_FEE_PER_GAS)
 	}
@@ -174,7 +415,7 @@ func (c *config) MaxFeePerGas() *big.Int {
 
 // PriorityFeeMainnet returns the fixed priority fee for Ethereum mainnet (default: 2 gwei)
 func (c *config) PriorityFeeMainnet() *big.Int {
-	feeStr := os.Getenv(envPriorityFeeMainnet)
+	feeStr := c.getenv(envPriorityFeeMainnet)
 	if feeStr == "" {
 		return big.NewInt(DEFAULT_PRIORITY_FEE_MAINNET)
 	}
@@ -187,7 +428,7 @@ func (c *config) PriorityFeeMainnet() *big.Int {
 
 // PriorityFeeBase returns the fixed priority fee for Base (default: 1 gwei)
 func (c *config) PriorityFeeBase() *big.Int {
-	feeStr := os.Getenv(envPriorityFeeBase)
+	feeStr := c.getenv(envPriorityFeeBase)
 	if feeStr == "" {
 		return big.NewInt(DEFAULT_PRIORITY_FEE_BASE)
 	}
@@ -200,7 +441,7 @@ func (c *config) PriorityFeeBase() *big.Int {
 
 // PriorityFeeDefault returns the fixed priority fee for other networks (default: 1.5 gwei)
 func (c *config) PriorityFeeDefault() *big.Int {
-	feeStr := os.Getenv(envPriorityFeeDefault)
+	feeStr := c.getenv(envPriorityFeeDefault)
 	if feeStr == "" {
 		return big.NewInt(DEFAULT_PRIORITY_FEE_OTHER)
 	}
@@ -211,6 +452,37 @@ func (c *config) PriorityFeeDefault() *big.Int {
 	return fee
 }
 
+// PriorityFeeForChain returns the priority fee for a chain other than mainnet and Base. In order of
+// precedence: ETH_PRIORITY_FEE_<chainID>, an explicitly set ETH_PRIORITY_FEE_DEFAULT, the chain's
+// built-in registry default, and finally DEFAULT_PRIORITY_FEE_OTHER.
+func (c *config) PriorityFeeForChain(chainID int64) *big.Int {
+	if feeStr := c.getenv(fmt.Sprintf(envPriorityFeeChainFmt, chainID)); feeStr != "" {
+		if fee, ok := new(big.Int).SetString(feeStr, 10); ok && fee.Sign() >= 0 {
+			return fee
+		}
+	}
+	if c.getenv(envPriorityFeeDefault) != "" {
+		return c.PriorityFeeDefault()
+	}
+	if info, ok := LookupChain(chainID); ok {
+		return info.PriorityFee
+	}
+	return big.NewInt(DEFAULT_PRIORITY_FEE_OTHER)
+}
+
+// FeeCacheTTLSeconds returns how long fee readings are cached in seconds (default: 2)
+func (c *config) FeeCacheTTLSeconds() int {
+	ttlStr := c.getenv(envFeeCacheTTLSeconds)
+	if ttlStr == "" {
+		return DEFAULT_FEE_CACHE_TTL_SECONDS
+	}
+	ttl, err := strconv.Atoi(ttlStr)
+	if err != nil || ttl <= 0 {
+		return DEFAULT_FEE_CACHE_TTL_SECONDS
+	}
+	return ttl
+}
+
 func loadAccountsFromEnv(chainID int64) ([]*Account, error) {
 	var accounts []*Account
 	accountLabels := os.Getenv(envAccountsList)
@@ -227,6 +499,17 @@ func loadAccountsFromEnv(chainID int64) ([]*Account, error) {
 		pubkeyEnv := fmt.Sprintf(envAccountPublicKeyFmt, strings.ToUpper(label))
 		pubHex := os.Getenv(pubkeyEnv)
 
+		// -- per-account overrides
+		accountChainID := chainID
+		if chainIDStr := os.Getenv(fmt.Sprintf(envAccountChainIDFmt, strings.ToUpper(label))); chainIDStr != "" {
+			id, err := strconv.ParseInt(chainIDStr, 10, 64)
+			if err != nil || id <= 0 {
+				return nil, fmt.Errorf("invalid chain ID %q for %s", chainIDStr, label)
+			}
+			accountChainID = id
+		}
+		rpcURL := os.Getenv(fmt.Sprintf(envAccountRPCURLFmt, strings.ToUpper(label)))
+
 		// -- validate
 		// if both private and public keys are provided, they must match
 		if privHex == "" && pubHex == "" {
@@ -244,9 +527,10 @@ func loadAccountsFromEnv(chainID int64) ([]*Account, error) {
 			account = &Account{
 				Address:    address,
 				PublicKey:  pubKey,
-				ChainId:    chainID,
+				ChainId:    accountChainID,
 				Label:      label,
 				PrivateKey: privKey,
+				RPCURL:     rpcURL,
 			}
 			// continue to next account if account has been created
 			accounts = append(accounts, account)
@@ -266,8 +550,9 @@ func loadAccountsFromEnv(chainID int64) ([]*Account, error) {
 			account = &Account{
 				Address:   address,
 				PublicKey: pubKey,
-				ChainId:   chainID,
+				ChainId:   accountChainID,
 				Label:     label,
+				RPCURL:    rpcURL,
 			}
 			// continue to next account if account has been created
 			accounts = append(accounts, account)
@@ -280,9 +565,46 @@ func loadAccountsFromEnv(chainID int64) ([]*Account, error) {
 
 // Account represents an Ethereum account with its address, public key, chain ID, and an optional label.
 
+// BaseFeeSource returns which block's base fee is used to calculate fees (default: latest)
+func (c *config) BaseFeeSource() string {
+	switch source := strings.ToLower(c.getenv(envBaseFeeSource)); source {
+	case BASE_FEE_SOURCE_PENDING, BASE_FEE_SOURCE_NEXT:
+		return source
+	default:
+		return BASE_FEE_SOURCE_LATEST
+	}
+}
+
+// FeeStrategy returns how the priority fee is chosen (default: fixed)
+func (c *config) FeeStrategy() string {
+	if strings.ToLower(c.getenv(envFeeStrategy)) == FEE_STRATEGY_HISTORY {
+		return FEE_STRATEGY_HISTORY
+	}
+	return FEE_STRATEGY_FIXED
+}
+
+// FeeHistoryBlocks returns how many recent blocks the history fee strategy samples (default: 20)
+func (c *config) FeeHistoryBlocks() uint64 {
+	blocks, err := strconv.ParseUint(c.getenv(envFeeHistoryBlocks), 10, 64)
+	if err != nil || blocks == 0 || blocks > maxFeeHistoryBlocks {
+		return DEFAULT_FEE_HISTORY_BLOCKS
+	}
+	return blocks
+}
+
+// FeeHistoryPercentile returns the reward percentile, from 0 to 100, the history fee strategy
+// uses (default: 50)
+func (c *config) FeeHistoryPercentile() float64 {
+	percentile, err := strconv.ParseFloat(c.getenv(envFeeHistoryPercentile), 64)
+	if err != nil || percentile < 0 || percentile > 100 {
+		return DEFAULT_FEE_HISTORY_PERCENTILE
+	}
+	return percentile
+}
+
 // TransactionTimeoutSeconds returns the transaction timeout in seconds (default: 300)
 func (c *config) TransactionTimeoutSeconds() int {
-	timeoutStr := os.Getenv("ETH_TRANSACTION_TIMEOUT_SECONDS")
+	timeoutStr := c.getenv("ETH_TRANSACTION_TIMEOUT_SECONDS")
 	if timeoutStr == "" {
 		return DEFAULT_TRANSACTION_TIMEOUT_SECONDS
 	}
@@ -295,7 +617,7 @@ func (c *config) TransactionTimeoutSeconds() int {
 
 // TransactionTickerSeconds returns the transaction ticker interval in seconds (default: 3)
 func (c *config) TransactionTickerSeconds() int {
-	tickerStr := os.Getenv("ETH_TRANSACTION_TICKER_SECONDS")
+	tickerStr := c.getenv("ETH_TRANSACTION_TICKER_SECONDS")
 	if tickerStr == "" {
 		return DEFAULT_TRANSACTION_TICKER_SECONDS
 	}
@@ -305,3 +627,79 @@ func (c *config) TransactionTickerSeconds() int {
 	}
 	return ticker
 }
+
+// TransactionMaxPolls returns the maximum number of receipt checks while waiting for a transaction (default: 0, unlimited)
+func (c *config) TransactionMaxPolls() int {
+	pollsStr := c.getenv("ETH_TRANSACTION_MAX_POLLS")
+	if pollsStr == "" {
+		return 0
+	}
+	polls, err := strconv.Atoi(pollsStr)
+	if err != nil || polls < 0 {
+		return 0
+	}
+	return polls
+}
+
+// RPCMaxBatchSize returns the most calls sent in one JSON-RPC batch (default: 100)
+func (c *config) RPCMaxBatchSize() int {
+	size, err := strconv.Atoi(c.getenv(envRPCMaxBatchSize))
+	if err != nil || size <= 0 {
+		return DEFAULT_RPC_MAX_BATCH_SIZE
+	}
+	return size
+}
+
+// Confirmations returns the default number of confirmations WaitForConfirmations waits for, i.e.
+// blocks mined on top of the one including the transaction (default: 0)
+func (c *config) Confirmations() uint64 {
+	confirmations, err := strconv.ParseUint(c.getenv(envConfirmations), 10, 64)
+	if err != nil {
+		return DEFAULT_CONFIRMATIONS
+	}
+	return confirmations
+}
+
+// StrictMode reports whether suspicious conditions should fail instead of being tolerated (default: false)
+func (c *config) StrictMode() bool {
+	strict, err := strconv.ParseBool(c.getenv(envStrictMode))
+	if err != nil {
+		return false
+	}
+	return strict
+}
+
+// RequireEIP1559 reports whether only EIP-1559 transactions may be signed (default: false)
+func (c *config) RequireEIP1559() bool {
+	required, err := strconv.ParseBool(c.getenv(envRequireEIP1559))
+	if err != nil {
+		return false
+	}
+	return required
+}
+
+// NonceManager reports whether nonces are tracked locally per address (default: false)
+func (c *config) NonceManager() bool {
+	enabled, err := strconv.ParseBool(c.getenv(envNonceManager))
+	if err != nil {
+		return false
+	}
+	return enabled
+}
+
+// BalanceBlockTag returns which balance CheckFunds checks against (default: pending)
+func (c *config) BalanceBlockTag() string {
+	if strings.ToLower(c.getenv(envBalanceBlockTag)) == BALANCE_BLOCK_TAG_LATEST {
+		return BALANCE_BLOCK_TAG_LATEST
+	}
+	return BALANCE_BLOCK_TAG_PENDING
+}
+
+// MaxTxSizeBytes returns the largest RLP-encoded transaction size that may be broadcast (default: 128 KiB)
+func (c *config) MaxTxSizeBytes() uint64 {
+	size, err := strconv.ParseUint(c.getenv(envMaxTxSizeBytes), 10, 64)
+	if err != nil || size == 0 {
+		return DEFAULT_MAX_TX_SIZE_BYTES
+	}
+	return size
+}
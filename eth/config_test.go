@@ -1,8 +1,10 @@
 package eth
 
 import (
+	"errors"
 	"math/big"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -92,3 +94,317 @@ func TestTransactionTimeoutDefaults(t *testing.T) {
 		t.Errorf("expected default ticker 3, got %d", cfg.TransactionTickerSeconds())
 	}
 }
+
+func TestRPCURLReadWrite(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("ETH_CHAIN_ID", "1")
+	os.Setenv("ETH_ACCOUNTS", "main")
+	os.Setenv("ETH_ACCOUNT_MAIN_PRIVATE_KEY", "4f3edf983ac636a65a842ce7c78d9aa706d3b113b37e5a4d5e1e4e6a1f7a1e08")
+	os.Setenv("ETH_RPC_URL", "http://localhost:8545")
+	cfg, err := NewConfiguration()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.RPCURLRead() != "http://localhost:8545" || cfg.RPCURLWrite() != "http://localhost:8545" {
+		t.Errorf("expected read/write URLs to default to ETH_RPC_URL, got %s and %s", cfg.RPCURLRead(), cfg.RPCURLWrite())
+	}
+
+	os.Setenv("ETH_RPC_URL_READ", "http://reader:8545")
+	os.Setenv("ETH_RPC_URL_WRITE", "http://writer:8545")
+	cfg, err = NewConfiguration()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.RPCURLRead() != "http://reader:8545" {
+		t.Errorf("expected read URL http://reader:8545, got %s", cfg.RPCURLRead())
+	}
+	if cfg.RPCURLWrite() != "http://writer:8545" {
+		t.Errorf("expected write URL http://writer:8545, got %s", cfg.RPCURLWrite())
+	}
+}
+
+func TestTransactionMaxPolls(t *testing.T) {
+	os.Clearenv()
+	cfg := &config{}
+	if cfg.TransactionMaxPolls() != 0 {
+		t.Errorf("expected default max polls 0, got %d", cfg.TransactionMaxPolls())
+	}
+	os.Setenv("ETH_TRANSACTION_MAX_POLLS", "25")
+	if cfg.TransactionMaxPolls() != 25 {
+		t.Errorf("expected max polls 25, got %d", cfg.TransactionMaxPolls())
+	}
+	os.Setenv("ETH_TRANSACTION_MAX_POLLS", "-1")
+	if cfg.TransactionMaxPolls() != 0 {
+		t.Errorf("expected invalid max polls to fall back to 0, got %d", cfg.TransactionMaxPolls())
+	}
+}
+
+func TestStrictMode(t *testing.T) {
+	os.Clearenv()
+	cfg := &config{}
+	if cfg.StrictMode() {
+		t.Errorf("expected strict mode to be off by default")
+	}
+	os.Setenv("ETH_STRICT_MODE", "true")
+	if !cfg.StrictMode() {
+		t.Errorf("expected strict mode to be on")
+	}
+	os.Setenv("ETH_STRICT_MODE", "sometimes")
+	if cfg.StrictMode() {
+		t.Errorf("expected invalid strict mode to fall back to off")
+	}
+}
+
+func TestBaseFeeSource(t *testing.T) {
+	os.Clearenv()
+	cfg := &config{}
+	if cfg.BaseFeeSource() != BASE_FEE_SOURCE_LATEST {
+		t.Errorf("expected default base fee source latest, got %s", cfg.BaseFeeSource())
+	}
+	os.Setenv("ETH_BASE_FEE_SOURCE", "Pending")
+	if cfg.BaseFeeSource() != BASE_FEE_SOURCE_PENDING {
+		t.Errorf("expected base fee source pending, got %s", cfg.BaseFeeSource())
+	}
+	os.Setenv("ETH_BASE_FEE_SOURCE", "finalized")
+	if cfg.BaseFeeSource() != BASE_FEE_SOURCE_LATEST {
+		t.Errorf("expected unknown base fee source to fall back to latest, got %s", cfg.BaseFeeSource())
+	}
+}
+
+func TestFeeStrategy(t *testing.T) {
+	os.Clearenv()
+	cfg := &config{}
+	if cfg.FeeStrategy() != FEE_STRATEGY_FIXED {
+		t.Errorf("expected default fee strategy fixed, got %s", cfg.FeeStrategy())
+	}
+	if cfg.FeeHistoryBlocks() != DEFAULT_FEE_HISTORY_BLOCKS || cfg.FeeHistoryPercentile() != DEFAULT_FEE_HISTORY_PERCENTILE {
+		t.Errorf("expected default fee history settings, got %d blocks at percentile %v", cfg.FeeHistoryBlocks(), cfg.FeeHistoryPercentile())
+	}
+	t.Setenv("ETH_FEE_STRATEGY", "History")
+	t.Setenv("ETH_FEE_HISTORY_BLOCKS", "10")
+	t.Setenv("ETH_FEE_HISTORY_PERCENTILE", "75")
+	if cfg.FeeStrategy() != FEE_STRATEGY_HISTORY {
+		t.Errorf("expected fee strategy history, got %s", cfg.FeeStrategy())
+	}
+	if cfg.FeeHistoryBlocks() != 10 || cfg.FeeHistoryPercentile() != 75 {
+		t.Errorf("expected 10 blocks at percentile 75, got %d blocks at percentile %v", cfg.FeeHistoryBlocks(), cfg.FeeHistoryPercentile())
+	}
+	t.Setenv("ETH_FEE_STRATEGY", "oracle")
+	t.Setenv("ETH_FEE_HISTORY_BLOCKS", "5000")
+	t.Setenv("ETH_FEE_HISTORY_PERCENTILE", "101")
+	if cfg.FeeStrategy() != FEE_STRATEGY_FIXED {
+		t.Errorf("expected unknown fee strategy to fall back to fixed, got %s", cfg.FeeStrategy())
+	}
+	if cfg.FeeHistoryBlocks() != DEFAULT_FEE_HISTORY_BLOCKS || cfg.FeeHistoryPercentile() != DEFAULT_FEE_HISTORY_PERCENTILE {
+		t.Errorf("expected out-of-range fee history settings to fall back to defaults, got %d blocks at percentile %v", cfg.FeeHistoryBlocks(), cfg.FeeHistoryPercentile())
+	}
+}
+
+func TestBalanceBlockTag(t *testing.T) {
+	os.Clearenv()
+	cfg := &config{}
+	if cfg.BalanceBlockTag() != BALANCE_BLOCK_TAG_PENDING {
+		t.Errorf("expected default balance block tag pending, got %s", cfg.BalanceBlockTag())
+	}
+	t.Setenv("ETH_BALANCE_BLOCK_TAG", "Latest")
+	if cfg.BalanceBlockTag() != BALANCE_BLOCK_TAG_LATEST {
+		t.Errorf("expected balance block tag latest, got %s", cfg.BalanceBlockTag())
+	}
+	t.Setenv("ETH_BALANCE_BLOCK_TAG", "safe")
+	if cfg.BalanceBlockTag() != BALANCE_BLOCK_TAG_PENDING {
+		t.Errorf("expected unknown balance block tag to fall back to pending, got %s", cfg.BalanceBlockTag())
+	}
+}
+
+func TestConfigAccountByLabel(t *testing.T) {
+	treasury := &Account{Label: "treasury"}
+	cfg := &config{acounts: []*Account{{Label: "main"}, treasury}}
+	if acc, ok := cfg.Account("TREASURY"); !ok || acc != treasury {
+		t.Errorf("expected to find the treasury account by label")
+	}
+	if _, ok := cfg.Account("payroll"); ok {
+		t.Errorf("expected unknown label not to be found")
+	}
+}
+
+func TestPriorityFeeForChain(t *testing.T) {
+	os.Clearenv()
+	cfg := &config{}
+	if fee := cfg.PriorityFeeForChain(137); fee.Cmp(big.NewInt(30*GWEI)) != 0 {
+		t.Errorf("expected Polygon registry default 30 gwei, got %s", fee)
+	}
+	if fee := cfg.PriorityFeeForChain(999999); fee.Cmp(big.NewInt(DEFAULT_PRIORITY_FEE_OTHER)) != 0 {
+		t.Errorf("expected unknown chain to use the built-in default, got %s", fee)
+	}
+	os.Setenv("ETH_PRIORITY_FEE_DEFAULT", "5000000000")
+	if fee := cfg.PriorityFeeForChain(137); fee.Cmp(big.NewInt(5*GWEI)) != 0 {
+		t.Errorf("expected explicit default to override the registry, got %s", fee)
+	}
+	os.Setenv("ETH_PRIORITY_FEE_137", "40000000000")
+	if fee := cfg.PriorityFeeForChain(137); fee.Cmp(big.NewInt(40*GWEI)) != 0 {
+		t.Errorf("expected per-chain override 40 gwei, got %s", fee)
+	}
+}
+
+func TestRequireEIP1559(t *testing.T) {
+	os.Clearenv()
+	cfg := &config{}
+	if cfg.RequireEIP1559() {
+		t.Errorf("expected EIP-1559 not to be required by default")
+	}
+	t.Setenv("ETH_REQUIRE_EIP1559", "true")
+	if !cfg.RequireEIP1559() {
+		t.Errorf("expected EIP-1559 to be required")
+	}
+}
+
+func TestNonceManagerConfig(t *testing.T) {
+	os.Clearenv()
+	cfg := &config{}
+	if cfg.NonceManager() {
+		t.Errorf("expected the nonce manager to be off by default")
+	}
+	t.Setenv("ETH_NONCE_MANAGER", "true")
+	if !cfg.NonceManager() {
+		t.Errorf("expected the nonce manager to be on")
+	}
+}
+
+func TestRPCHeaders(t *testing.T) {
+	os.Clearenv()
+	cfg := &config{}
+	if headers := cfg.RPCHeaders(); headers != nil {
+		t.Errorf("expected no headers by default, got %v", headers)
+	}
+
+	t.Setenv("ETH_RPC_HEADERS", "Authorization: Bearer abc:def , X-Api-Key:key123,malformed, : novalue")
+	headers := cfg.RPCHeaders()
+	if len(headers) != 2 {
+		t.Fatalf("expected 2 headers, got %v", headers)
+	}
+	if headers["Authorization"] != "Bearer abc:def" {
+		t.Errorf("expected Authorization 'Bearer abc:def', got %q", headers["Authorization"])
+	}
+	if headers["X-Api-Key"] != "key123" {
+		t.Errorf("expected X-Api-Key 'key123', got %q", headers["X-Api-Key"])
+	}
+}
+
+func TestGasLimitBufferAbsolute(t *testing.T) {
+	os.Clearenv()
+	cfg := &config{}
+	if buffer := cfg.GasLimitBufferAbsolute(); buffer != 0 {
+		t.Errorf("expected default absolute buffer 0, got %d", buffer)
+	}
+
+	t.Setenv("ETH_GAS_LIMIT_BUFFER_ABSOLUTE", "5000")
+	if buffer := cfg.GasLimitBufferAbsolute(); buffer != 5000 {
+		t.Errorf("expected absolute buffer 5000, got %d", buffer)
+	}
+
+	t.Setenv("ETH_GAS_LIMIT_BUFFER_ABSOLUTE", "-1")
+	if buffer := cfg.GasLimitBufferAbsolute(); buffer != 0 {
+		t.Errorf("expected invalid absolute buffer to fall back to 0, got %d", buffer)
+	}
+}
+
+func TestConfirmations(t *testing.T) {
+	os.Clearenv()
+	cfg := &config{}
+	if confirmations := cfg.Confirmations(); confirmations != 0 {
+		t.Errorf("expected default confirmations 0, got %d", confirmations)
+	}
+
+	t.Setenv("ETH_CONFIRMATIONS", "12")
+	if confirmations := cfg.Confirmations(); confirmations != 12 {
+		t.Errorf("expected confirmations 12, got %d", confirmations)
+	}
+}
+
+func TestMaxTxSizeBytes(t *testing.T) {
+	os.Clearenv()
+	cfg := &config{}
+	if size := cfg.MaxTxSizeBytes(); size != 128*1024 {
+		t.Errorf("expected default max tx size 131072, got %d", size)
+	}
+
+	t.Setenv("ETH_MAX_TX_SIZE_BYTES", "65536")
+	if size := cfg.MaxTxSizeBytes(); size != 65536 {
+		t.Errorf("expected max tx size 65536, got %d", size)
+	}
+
+	t.Setenv("ETH_MAX_TX_SIZE_BYTES", "0")
+	if size := cfg.MaxTxSizeBytes(); size != 128*1024 {
+		t.Errorf("expected zero max tx size to fall back to 131072, got %d", size)
+	}
+}
+
+func TestConfigReload(t *testing.T) {
+	os.Clearenv()
+	t.Setenv("ETH_CHAIN_ID", "1")
+	t.Setenv("ETH_RPC_URL", "http://localhost:8545")
+	t.Setenv("ETH_ACCOUNTS", "main")
+	t.Setenv("ETH_ACCOUNT_MAIN_PRIVATE_KEY", "4f3edf983ac636a65a842ce7c78d9aa706d3b113b37e5a4d5e1e4e6a1f7a1e08")
+	cfg, err := NewConfiguration()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Changes only take effect after a reload
+	t.Setenv("ETH_MAX_FEE_PER_GAS", "100000000000")
+	if cfg.MaxFeePerGas().Cmp(big.NewInt(DEFAULT_MAX_FEE_PER_GAS)) != 0 {
+		t.Errorf("expected max fee per gas %d before reload, got %s", DEFAULT_MAX_FEE_PER_GAS, cfg.MaxFeePerGas())
+	}
+	if err := cfg.Reload(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.MaxFeePerGas().Cmp(big.NewInt(100000000000)) != 0 {
+		t.Errorf("expected max fee per gas 100000000000 after reload, got %s", cfg.MaxFeePerGas())
+	}
+
+	// Connection settings are reported but tunables still apply
+	t.Setenv("ETH_RPC_URL", "http://other:8545")
+	t.Setenv("ETH_MAX_FEE_PER_GAS", "50000000000")
+	err = cfg.Reload()
+	if !errors.Is(err, ErrConfigRequiresReconnect) {
+		t.Fatalf("expected ErrConfigRequiresReconnect, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "ETH_RPC_URL") || strings.Contains(err.Error(), "ETH_ACCOUNTS") {
+		t.Errorf("expected only ETH_RPC_URL to be reported, got %v", err)
+	}
+	if cfg.RPCURL() != "http://localhost:8545" {
+		t.Errorf("expected RPC URL to stay http://localhost:8545, got %s", cfg.RPCURL())
+	}
+	if cfg.MaxFeePerGas().Cmp(big.NewInt(50000000000)) != 0 {
+		t.Errorf("expected max fee per gas 50000000000 after reload, got %s", cfg.MaxFeePerGas())
+	}
+}
+
+func TestNewConfiguration_AccountOverrides(t *testing.T) {
+	os.Clearenv()
+	defer os.Clearenv()
+	os.Setenv("ETH_CHAIN_ID", "1")
+	os.Setenv("ETH_ACCOUNTS", "main,base")
+	os.Setenv("ETH_ACCOUNT_MAIN_PRIVATE_KEY", "4f3edf983ac636a65a842ce7c78d9aa706d3b113b37e5a4d5e1e4e6a1f7a1e08")
+	os.Setenv("ETH_ACCOUNT_BASE_PRIVATE_KEY", "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	os.Setenv("ETH_ACCOUNT_BASE_CHAIN_ID", "8453")
+	os.Setenv("ETH_ACCOUNT_BASE_RPC_URL", "https://mainnet.base.org")
+
+	cfg, err := NewConfiguration()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	mainAcc, _ := cfg.Account("main")
+	if mainAcc.ChainId != 1 || mainAcc.RPCURL != "" {
+		t.Errorf("expected main on the configured chain and endpoint, got chain %d and %q", mainAcc.ChainId, mainAcc.RPCURL)
+	}
+	base, _ := cfg.Account("base")
+	if base.ChainId != 8453 || base.RPCURL != "https://mainnet.base.org" {
+		t.Errorf("expected base on chain 8453 at its own endpoint, got chain %d and %q", base.ChainId, base.RPCURL)
+	}
+
+	os.Setenv("ETH_ACCOUNT_BASE_CHAIN_ID", "base")
+	if _, err := NewConfiguration(); err == nil || !strings.Contains(err.Error(), "invalid chain ID") {
+		t.Errorf("expected invalid chain ID error, got %v", err)
+	}
+}
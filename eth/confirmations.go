@@ -0,0 +1,283 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/sirupsen/logrus"
+)
+
+// ConfirmationStatus is the state of a watched transaction reported in a ConfirmationUpdate
+type ConfirmationStatus int
+
+const (
+	ConfirmationPending   ConfirmationStatus = iota // known to the node but not mined
+	ConfirmationConfirmed                           // mined, below the target confirmations
+	ConfirmationFinal                               // reached the target confirmations; the last update
+	ConfirmationReorged                             // the block it was mined in left the canonical chain
+	ConfirmationDropped                             // no longer known to the node; the last update
+)
+
+// String returns the lowercase name of the status
+func (s ConfirmationStatus) String() string {
+	switch s {
+	case ConfirmationPending:
+		return "pending"
+	case ConfirmationConfirmed:
+		return "confirmed"
+	case ConfirmationFinal:
+		return "final"
+	case ConfirmationReorged:
+		return "reorged"
+	case ConfirmationDropped:
+		return "dropped"
+	}
+	return fmt.Sprintf("ConfirmationStatus(%d)", int(s))
+}
+
+// ConfirmationUpdate is passed to the WatchConfirmations callback. BlockNumber and BlockHash are
+// the block the transaction is mined in; for ConfirmationReorged they are the block it was removed
+// from, and they are zero while pending.
+type ConfirmationUpdate struct {
+	Hash   common.Hash
+	Status ConfirmationStatus
+	// Confirmations is the number of blocks mined on top of BlockNumber
+	Confirmations uint64
+	BlockNumber   uint64
+	BlockHash     common.Hash
+	// Metadata is the Metadata of the Transaction this client signed, if any
+	Metadata map[string]string
+}
+
+// WatchConfirmations follows hash until it has target confirmations, calling cb whenever its
+// confirmation count or status changes: once per new confirmation, and on reorgs, where the
+// transaction moves to another block or back to the mempool. Confirmations count the blocks mined
+// on top of the including one, as in WaitForConfirmations; a target of 0 is final once mined. It
+// returns nil after the ConfirmationFinal update, ErrTransactionDropped after ConfirmationDropped,
+// or the context's error. Over websocket endpoints it checks on every new head, otherwise on the
+// transaction ticker. cb runs on the calling goroutine.
+func (es *ghostClient) WatchConfirmations(ctx context.Context, hash common.Hash, target uint64, cb func(ConfirmationUpdate)) error {
+	w := &confirmationWatch{es: es, hash: hash, target: target, cb: cb}
+
+	header, err := es.readClient().HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to get latest header: %w", err)
+	}
+	if done, err := w.check(ctx, header.Number.Uint64()); done || err != nil {
+		return err
+	}
+
+	var heads chan *types.Header
+	var subErr <-chan error
+	if es.subscribeHeads {
+		heads = make(chan *types.Header, 16)
+		sub, err := es.subscribeNewHeads(ctx, heads)
+		if err != nil {
+			es.log.WithError(err).Warn("Failed to subscribe to new heads, falling back to polling")
+			heads = nil
+		} else {
+			defer sub.Unsubscribe()
+			subErr = sub.Err()
+		}
+	}
+
+	// The ticker only drives checks when there is no head subscription
+	ticker := time.NewTicker(time.Duration(es.config.TransactionTickerSeconds()) * time.Second)
+	defer ticker.Stop()
+	var tick <-chan time.Time
+	if heads == nil {
+		tick = ticker.C
+	}
+
+	for {
+		var head uint64
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("stopped watching %s: %w", hash.Hex(), ctx.Err())
+		case err := <-subErr:
+			es.log.WithError(err).Warn("Head subscription could not be re-established, falling back to polling")
+			heads, subErr, tick = nil, nil, ticker.C
+			continue
+		case header := <-heads:
+			head = header.Number.Uint64()
+		case <-tick:
+			header, err := es.readClient().HeaderByNumber(ctx, nil)
+			if err != nil {
+				es.log.WithError(err).Warn("Failed to get latest header")
+				continue
+			}
+			head = header.Number.Uint64()
+		}
+		if done, err := w.check(ctx, head); done || err != nil {
+			return err
+		}
+	}
+}
+
+// confirmationWatch holds the state of a WatchConfirmations call between checks
+type confirmationWatch struct {
+	es     *ghostClient
+	hash   common.Hash
+	target uint64
+	cb     func(ConfirmationUpdate)
+
+	last *ConfirmationUpdate
+}
+
+// check looks the transaction up against the given head and reports the changes. It returns true
+// once the watch is over.
+func (w *confirmationWatch) check(ctx context.Context, head uint64) (bool, error) {
+	receipt, err := w.es.readClient().TransactionReceipt(ctx, w.hash)
+	if err != nil && !errors.Is(err, ethereum.NotFound) {
+		w.es.log.WithError(err).Warn("Failed to get transaction receipt")
+		return false, nil
+	}
+
+	if receipt == nil {
+		if w.last != nil && w.last.Status == ConfirmationConfirmed {
+			w.emit(ConfirmationUpdate{Status: ConfirmationReorged, BlockNumber: w.last.BlockNumber, BlockHash: w.last.BlockHash})
+		}
+		_, _, err := w.es.readClient().TransactionByHash(ctx, w.hash)
+		if errors.Is(err, ethereum.NotFound) {
+			w.emit(ConfirmationUpdate{Status: ConfirmationDropped})
+			return true, fmt.Errorf("%w: %s", ErrTransactionDropped, w.hash.Hex())
+		}
+		if err != nil {
+			w.es.log.WithError(err).Warn("Failed to get transaction")
+			return false, nil
+		}
+		w.emit(ConfirmationUpdate{Status: ConfirmationPending})
+		return false, nil
+	}
+
+	if w.last != nil && w.last.Status == ConfirmationConfirmed && w.last.BlockHash != receipt.BlockHash {
+		w.emit(ConfirmationUpdate{Status: ConfirmationReorged, BlockNumber: w.last.BlockNumber, BlockHash: w.last.BlockHash})
+	}
+	update := ConfirmationUpdate{
+		Status:      ConfirmationConfirmed,
+		BlockNumber: receipt.BlockNumber.Uint64(),
+		BlockHash:   receipt.BlockHash,
+	}
+	if head > update.BlockNumber {
+		update.Confirmations = head - update.BlockNumber
+	}
+	if update.Confirmations >= w.target {
+		update.Status = ConfirmationFinal
+		w.emit(update)
+		return true, nil
+	}
+	w.emit(update)
+	return false, nil
+}
+
+// emit calls the callback unless the update repeats the previous one
+func (w *confirmationWatch) emit(update ConfirmationUpdate) {
+	update.Hash = w.hash
+	if last := w.last; last != nil && last.Status == update.Status && last.Confirmations == update.Confirmations &&
+		last.BlockNumber == update.BlockNumber && last.BlockHash == update.BlockHash {
+		return
+	}
+	update.Metadata = w.es.metadata.get(w.hash)
+	w.last = &update
+	fields := logrus.Fields{
+		"hash":          w.hash.Hex(),
+		"status":        update.Status.String(),
+		"confirmations": update.Confirmations,
+	}
+	if update.Metadata != nil {
+		fields["metadata"] = update.Metadata
+	}
+	w.es.log.WithFields(fields).Info("Transaction confirmation update")
+	w.cb(update)
+}
+
+// WaitForConfirmations waits for hash to be mined like WaitForTransaction, then polls the latest
+// header on the transaction ticker until confirmations blocks are mined on top of the one
+// including the transaction; 0 uses ETH_CONFIRMATIONS, which defaults to returning once mined.
+// The whole wait is bounded by the transaction timeout. Before returning it checks that the
+// including block is still canonical. If the receipt disappears meanwhile it fails with
+// ErrTransactionReorged; if the transaction is re-mined in another block, counting continues from
+// that block.
+func (es *ghostClient) WaitForConfirmations(hash common.Hash, confirmations uint64) (*TransactionReceipt, error) {
+	if confirmations == 0 {
+		confirmations = es.config.Confirmations()
+	}
+	deadline := time.Now().Add(time.Duration(es.config.TransactionTimeoutSeconds()) * time.Second)
+	ctx, cancel := context.WithDeadline(es.ctx, deadline)
+	defer cancel()
+
+	receipt, err := es.waitForTransaction(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	l := es.log.WithFields(logrus.Fields{"hash": hash.Hex(), "confirmations": confirmations})
+	if confirmations == 0 {
+		return receipt, nil
+	}
+	l.WithField("block_number", receipt.BlockNumber).Info("Transaction mined, waiting for confirmations")
+
+	// -- the hash of the including block, zero until the first poll
+	var blockHash common.Hash
+	ticker := time.NewTicker(time.Duration(es.config.TransactionTickerSeconds()) * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if es.ctx.Err() == nil {
+				return nil, fmt.Errorf("transaction timeout: %s waiting for %d confirmations", hash.Hex(), confirmations)
+			}
+			return nil, fmt.Errorf("stopped waiting for %s: %w", hash.Hex(), es.ctx.Err())
+		case <-ticker.C:
+		}
+
+		mined, err := es.readClient().TransactionReceipt(ctx, hash)
+		if errors.Is(err, ethereum.NotFound) {
+			l.WithField("block_number", receipt.BlockNumber).Warn("Transaction reorged out")
+			return nil, fmt.Errorf("%w: %s was in block %d", ErrTransactionReorged, hash.Hex(), receipt.BlockNumber)
+		}
+		if err != nil {
+			l.WithError(err).Warn("Failed to get transaction receipt")
+			continue
+		}
+		header, err := es.readClient().HeaderByNumber(ctx, nil)
+		if err != nil {
+			l.WithError(err).Warn("Failed to get latest header")
+			continue
+		}
+
+		block := mined.BlockNumber.Uint64()
+		if block != receipt.BlockNumber || (blockHash != (common.Hash{}) && mined.BlockHash != blockHash) {
+			l.WithFields(logrus.Fields{"old_block": receipt.BlockNumber, "block_number": block}).Warn("Transaction re-mined in another block")
+			remined, err := es.getTransactionReceipt(ctx, hash)
+			if err != nil {
+				l.WithError(err).Warn("Failed to get transaction receipt")
+				continue
+			}
+			receipt = remined
+		}
+		blockHash = mined.BlockHash
+
+		head := header.Number.Uint64()
+		if head < receipt.BlockNumber || head-receipt.BlockNumber < confirmations {
+			continue
+		}
+		// -- the receipt may lag a reorg; only return once the including block is canonical
+		canonical, err := es.readClient().HeaderByNumber(ctx, new(big.Int).SetUint64(receipt.BlockNumber))
+		if err != nil {
+			l.WithError(err).Warn("Failed to get including block header")
+			continue
+		}
+		if canonical.Hash() != blockHash {
+			l.WithField("block_number", receipt.BlockNumber).Warn("Including block is no longer canonical")
+			continue
+		}
+		l.WithField("block_number", receipt.BlockNumber).Info("Transaction confirmed")
+		return receipt, nil
+	}
+}
@@ -0,0 +1,225 @@
+package eth
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGhostClient_WatchConfirmations(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	hash := common.HexToHash("0xabc")
+	blockA := common.HexToHash("0xa")
+	blockB := common.HexToHash("0xb")
+	minedIn := func(number int64, blockHash common.Hash) *types.Receipt {
+		return &types.Receipt{TxHash: hash, BlockNumber: big.NewInt(number), BlockHash: blockHash, Status: types.ReceiptStatusSuccessful}
+	}
+
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{Number: big.NewInt(99)}, nil).Once()
+	mockClient.On("SubscribeNewHead", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			heads := args.Get(1).(chan<- *types.Header)
+			go func() {
+				for i := int64(100); i <= 103; i++ {
+					heads <- &types.Header{Number: big.NewInt(i)}
+				}
+			}()
+		}).
+		Return(newTestSubscription(), nil)
+	// head 99: pending, 100-101: mined in A, 102: reorged into B, 103: final with 2 blocks on top
+	mockClient.On("TransactionReceipt", mock.Anything, hash).Return(nil, ethereum.NotFound).Once()
+	mockClient.On("TransactionByHash", mock.Anything, hash).Return(&types.Transaction{}, true, nil).Once()
+	mockClient.On("TransactionReceipt", mock.Anything, hash).Return(minedIn(100, blockA), nil).Twice()
+	mockClient.On("TransactionReceipt", mock.Anything, hash).Return(minedIn(101, blockB), nil).Twice()
+	gc := &ghostClient{
+		client:         mockClient,
+		ctx:            context.Background(),
+		chainId:        1,
+		account:        acc,
+		config:         cfg,
+		log:            newTestLogger(),
+		subscribeHeads: true,
+	}
+
+	var updates []ConfirmationUpdate
+	err := gc.WatchConfirmations(context.Background(), hash, 2, func(u ConfirmationUpdate) {
+		updates = append(updates, u)
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []ConfirmationUpdate{
+		{Hash: hash, Status: ConfirmationPending},
+		{Hash: hash, Status: ConfirmationConfirmed, Confirmations: 0, BlockNumber: 100, BlockHash: blockA},
+		{Hash: hash, Status: ConfirmationConfirmed, Confirmations: 1, BlockNumber: 100, BlockHash: blockA},
+		{Hash: hash, Status: ConfirmationReorged, BlockNumber: 100, BlockHash: blockA},
+		{Hash: hash, Status: ConfirmationConfirmed, Confirmations: 1, BlockNumber: 101, BlockHash: blockB},
+		{Hash: hash, Status: ConfirmationFinal, Confirmations: 2, BlockNumber: 101, BlockHash: blockB},
+	}, updates)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_WatchConfirmations_Dropped(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	hash := common.HexToHash("0xabc")
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{Number: big.NewInt(100)}, nil)
+	mockClient.On("SubscribeNewHead", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			heads := args.Get(1).(chan<- *types.Header)
+			go func() { heads <- &types.Header{Number: big.NewInt(101)} }()
+		}).
+		Return(newTestSubscription(), nil)
+	// mined in block 100, then reorged out and gone from the mempool
+	mockClient.On("TransactionReceipt", mock.Anything, hash).
+		Return(&types.Receipt{TxHash: hash, BlockNumber: big.NewInt(100), BlockHash: testIncludingHeader.Hash()}, nil).Once()
+	mockClient.On("TransactionReceipt", mock.Anything, hash).Return(nil, ethereum.NotFound).Once()
+	mockClient.On("TransactionByHash", mock.Anything, hash).Return(nil, false, ethereum.NotFound).Once()
+	gc := &ghostClient{
+		client:         mockClient,
+		ctx:            context.Background(),
+		chainId:        1,
+		account:        acc,
+		config:         cfg,
+		log:            newTestLogger(),
+		subscribeHeads: true,
+	}
+
+	var statuses []ConfirmationStatus
+	err := gc.WatchConfirmations(context.Background(), hash, 12, func(u ConfirmationUpdate) {
+		statuses = append(statuses, u.Status)
+	})
+	assert.ErrorIs(t, err, ErrTransactionDropped)
+	assert.Equal(t, []ConfirmationStatus{ConfirmationConfirmed, ConfirmationReorged, ConfirmationDropped}, statuses)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_WatchConfirmations_Cancelled(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	hash := common.HexToHash("0xabc")
+	sub := newTestSubscription()
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{Number: big.NewInt(100)}, nil)
+	mockClient.On("SubscribeNewHead", mock.Anything, mock.Anything).Return(sub, nil)
+	mockClient.On("TransactionReceipt", mock.Anything, hash).Return(nil, ethereum.NotFound)
+	mockClient.On("TransactionByHash", mock.Anything, hash).Return(&types.Transaction{}, true, nil)
+	gc := &ghostClient{
+		client:         mockClient,
+		ctx:            context.Background(),
+		chainId:        1,
+		account:        acc,
+		config:         cfg,
+		log:            newTestLogger(),
+		subscribeHeads: true,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	start := time.Now()
+	err := gc.WatchConfirmations(ctx, hash, 12, func(ConfirmationUpdate) {})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.True(t, sub.unsubscribed)
+}
+
+// testIncludingHeader is the canonical header of block 100, which includes the transaction in the
+// WaitForConfirmations tests
+var testIncludingHeader = &types.Header{Number: big.NewInt(100)}
+
+// testConfirmationsClient returns a client over mockClient whose wait for hash finds it mined in
+// block 100 right away
+func testConfirmationsClient(t *testing.T, mockClient *internalmocks.EthClient, hash common.Hash) *ghostClient {
+	t.Setenv("ETH_TRANSACTION_TICKER_SECONDS", "1")
+	acc, cfg := testAccountAndConfig()
+	receipt, tx := testMinedTransaction(hash, 100)
+	receipt.BlockHash = testIncludingHeader.Hash()
+	mockClient.On("SubscribeNewHead", mock.Anything, mock.Anything).Return(newTestSubscription(), nil)
+	mockClient.On("TransactionReceipt", mock.Anything, hash).Return(receipt, nil).Once()
+	mockClient.On("TransactionByHash", mock.Anything, hash).Return(tx, false, nil)
+	return &ghostClient{
+		client:         mockClient,
+		ctx:            context.Background(),
+		chainId:        1,
+		account:        acc,
+		config:         cfg,
+		log:            newTestLogger(),
+		subscribeHeads: true,
+	}
+}
+
+func TestGhostClient_WaitForConfirmations(t *testing.T) {
+	hash := common.HexToHash("0xabc")
+	mockClient := &internalmocks.EthClient{}
+	gc := testConfirmationsClient(t, mockClient, hash)
+	mockClient.On("TransactionReceipt", mock.Anything, hash).
+		Return(&types.Receipt{TxHash: hash, BlockNumber: big.NewInt(100), BlockHash: testIncludingHeader.Hash()}, nil)
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{Number: big.NewInt(101)}, nil).Once()
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{Number: big.NewInt(102)}, nil).Once()
+	mockClient.On("HeaderByNumber", mock.Anything, big.NewInt(100)).Return(testIncludingHeader, nil).Once()
+
+	// -- two blocks on top of block 100
+	receipt, err := gc.WaitForConfirmations(hash, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), receipt.BlockNumber)
+	mockClient.AssertNumberOfCalls(t, "HeaderByNumber", 3)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_WaitForConfirmations_BlockNotCanonical(t *testing.T) {
+	hash := common.HexToHash("0xabc")
+	mockClient := &internalmocks.EthClient{}
+	gc := testConfirmationsClient(t, mockClient, hash)
+	mockClient.On("TransactionReceipt", mock.Anything, hash).
+		Return(&types.Receipt{TxHash: hash, BlockNumber: big.NewInt(100), BlockHash: testIncludingHeader.Hash()}, nil)
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{Number: big.NewInt(101)}, nil)
+	// -- block 100 was briefly replaced, so the stale receipt must not count
+	mockClient.On("HeaderByNumber", mock.Anything, big.NewInt(100)).Return(&types.Header{Number: big.NewInt(100), Extra: []byte("fork")}, nil).Once()
+	mockClient.On("HeaderByNumber", mock.Anything, big.NewInt(100)).Return(testIncludingHeader, nil).Once()
+
+	receipt, err := gc.WaitForConfirmations(hash, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), receipt.BlockNumber)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_WaitForConfirmations_Default(t *testing.T) {
+	hash := common.HexToHash("0xabc")
+	mockClient := &internalmocks.EthClient{}
+	gc := testConfirmationsClient(t, mockClient, hash)
+
+	// -- ETH_CONFIRMATIONS defaults to returning once mined
+	receipt, err := gc.WaitForConfirmations(hash, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), receipt.BlockNumber)
+	mockClient.AssertNotCalled(t, "HeaderByNumber", mock.Anything, mock.Anything)
+}
+
+func TestGhostClient_WaitForConfirmations_Reorged(t *testing.T) {
+	hash := common.HexToHash("0xabc")
+	mockClient := &internalmocks.EthClient{}
+	gc := testConfirmationsClient(t, mockClient, hash)
+	mockClient.On("TransactionReceipt", mock.Anything, hash).Return(nil, ethereum.NotFound)
+
+	_, err := gc.WaitForConfirmations(hash, 12)
+	assert.ErrorIs(t, err, ErrTransactionReorged)
+}
+
+func TestGhostClient_WaitForConfirmations_Timeout(t *testing.T) {
+	t.Setenv("ETH_TRANSACTION_TIMEOUT_SECONDS", "2")
+	hash := common.HexToHash("0xabc")
+	mockClient := &internalmocks.EthClient{}
+	gc := testConfirmationsClient(t, mockClient, hash)
+	mockClient.On("TransactionReceipt", mock.Anything, hash).
+		Return(&types.Receipt{TxHash: hash, BlockNumber: big.NewInt(100), BlockHash: testIncludingHeader.Hash()}, nil)
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{Number: big.NewInt(100)}, nil)
+
+	_, err := gc.WaitForConfirmations(hash, 12)
+	assert.ErrorContains(t, err, "transaction timeout")
+}
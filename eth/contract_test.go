@@ -0,0 +1,65 @@
+package eth
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeCall_ERC20Transfer(t *testing.T) {
+	to := common.HexToAddress("0x742d35Cc6634C0532925a3b8D4C9db96C4b4d8b6")
+	data, err := EncodeCall(erc20ABI, "transfer", to, big.NewInt(1000000))
+	assert.NoError(t, err)
+
+	expected := "a9059cbb" +
+		"000000000000000000000000742d35cc6634c0532925a3b8d4c9db96c4b4d8b6" +
+		"00000000000000000000000000000000000000000000000000000000000f4240"
+	assert.Equal(t, expected, hex.EncodeToString(data))
+}
+
+func TestEncodeCall_Errors(t *testing.T) {
+	to := common.HexToAddress("0x742d35Cc6634C0532925a3b8D4C9db96C4b4d8b6")
+
+	_, err := EncodeCall(erc20ABI, "mint", to, big.NewInt(1))
+	assert.Error(t, err)
+
+	_, err = EncodeCall(erc20ABI, "transfer", to)
+	assert.ErrorContains(t, err, "expects 2 arguments, got 1")
+
+	_, err = EncodeCall(erc20ABI, "transfer", "not-an-address", big.NewInt(1))
+	assert.Error(t, err)
+}
+
+func TestNewContractTx(t *testing.T) {
+	token := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	to := common.HexToAddress("0x742d35Cc6634C0532925a3b8D4C9db96C4b4d8b6")
+	tx, err := NewContractTx(token, erc20ABI, "transfer", nil, to, big.NewInt(1000000))
+	assert.NoError(t, err)
+	assert.Equal(t, token, tx.To)
+	assert.Equal(t, 0, tx.Value.Sign())
+	assert.Equal(t, "a9059cbb", hex.EncodeToString(tx.Data[:4]))
+	assert.Len(t, tx.Data, 68)
+}
+
+func TestFunctionSelector(t *testing.T) {
+	to := common.HexToAddress("0x742d35Cc6634C0532925a3b8D4C9db96C4b4d8b6")
+
+	transfer := types.NewTx(&types.DynamicFeeTx{To: &to, Value: big.NewInt(1)})
+	_, ok := FunctionSelector(transfer)
+	assert.False(t, ok)
+
+	data, err := EncodeCall(erc20ABI, "transfer", to, big.NewInt(1000000))
+	assert.NoError(t, err)
+	call := types.NewTx(&types.DynamicFeeTx{To: &to, Data: data})
+	selector, ok := FunctionSelector(call)
+	assert.True(t, ok)
+	assert.Equal(t, "0xa9059cbb", selector)
+
+	short := types.NewTx(&types.DynamicFeeTx{To: &to, Data: []byte{0xa9, 0x05}})
+	_, ok = FunctionSelector(short)
+	assert.False(t, ok)
+}
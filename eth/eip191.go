@@ -0,0 +1,56 @@
+package eth
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// signEIP191 signs data as an EIP-191 personal message ("\x19Ethereum Signed Message:\n" + len
+// + data), returning the 65-byte [R || S || V] signature with V as 27 or 28, as wallets do
+func signEIP191(key *ecdsa.PrivateKey, data []byte) ([]byte, error) {
+	signature, err := crypto.Sign(accounts.TextHash(data), key)
+	if err != nil {
+		return nil, err
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+	return signature, nil
+}
+
+// recoverEIP191 returns the address that signed data as an EIP-191 personal message. V may be
+// 27/28 or 0/1.
+func recoverEIP191(data, signature []byte) (common.Address, error) {
+	if len(signature) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("signature must be %d bytes, got %d", crypto.SignatureLength, len(signature))
+	}
+	sig := common.CopyBytes(signature)
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+	pub, err := crypto.SigToPub(accounts.TextHash(data), sig)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid signature: %w", err)
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+// SignMessage signs data with the account key as an EIP-191 personal message, as personal_sign
+// does, returning the 65-byte [R || S || V] signature with V as 27 or 28
+func (es *ghostClient) SignMessage(data []byte) ([]byte, error) {
+	signature, err := signEIP191(es.account.PrivateKey, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign message: %w", err)
+	}
+	es.log.WithField("address", es.account.Address.Hex()).Info("Signed message")
+	return signature, nil
+}
+
+// RecoverMessageSigner returns the address whose EIP-191 personal signature of data is signature,
+// as produced by SignMessage or a wallet's personal_sign. V may be 27/28 or 0/1. Any well-formed
+// signature recovers some address, so callers must compare it with the one they expect.
+func RecoverMessageSigner(data, signature []byte) (common.Address, error) {
+	return recoverEIP191(data, signature)
+}
@@ -0,0 +1,62 @@
+package eth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGhostClient_SignMessage_RoundTrip(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	gc := &ghostClient{
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	for _, data := range [][]byte{
+		[]byte("hello"),
+		[]byte("Sign in to example.com\nNonce: 8f3a1c2e"),
+		{},
+	} {
+		signature, err := gc.SignMessage(data)
+		assert.NoError(t, err)
+		assert.Len(t, signature, 65)
+		assert.Contains(t, []byte{27, 28}, signature[64])
+
+		signer, err := RecoverMessageSigner(data, signature)
+		assert.NoError(t, err)
+		assert.Equal(t, acc.Address, signer)
+
+		// -- V as 0/1 recovers the same address
+		raw := common.CopyBytes(signature)
+		raw[64] -= 27
+		signer, err = RecoverMessageSigner(data, raw)
+		assert.NoError(t, err)
+		assert.Equal(t, acc.Address, signer)
+	}
+}
+
+func TestRecoverMessageSigner_OtherMessage(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	signature, err := signEIP191(key, []byte("hello"))
+	assert.NoError(t, err)
+
+	signer, err := RecoverMessageSigner([]byte("hello!"), signature)
+	assert.NoError(t, err)
+	assert.NotEqual(t, crypto.PubkeyToAddress(key.PublicKey), signer)
+}
+
+func TestRecoverMessageSigner_Malformed(t *testing.T) {
+	_, err := RecoverMessageSigner([]byte("hello"), make([]byte, 64))
+	assert.Error(t, err)
+
+	_, err = RecoverMessageSigner([]byte("hello"), make([]byte, 65))
+	assert.Error(t, err)
+}
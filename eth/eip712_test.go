@@ -0,0 +1,114 @@
+package eth
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeDomainSeparator_USDC(t *testing.T) {
+	// -- DOMAIN_SEPARATOR() of USDC on Ethereum mainnet
+	usdc := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	got := ComputeDomainSeparator("USD Coin", "2", big.NewInt(1), usdc)
+	assert.Equal(t, common.HexToHash("0x06c37168a7db5138defc7866392bb87a741f9b3d104deb5094588ce041cae335"), got)
+}
+
+func TestComputeDomainSeparator_EIP712Example(t *testing.T) {
+	// -- the domain of the Mail example in the EIP-712 specification
+	verifying := common.HexToAddress("0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC")
+	got := ComputeDomainSeparator("Ether Mail", "1", big.NewInt(1), verifying)
+	assert.Equal(t, common.HexToHash("0xf2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f"), got)
+}
+
+func TestComputeDomainSeparator_DependsOnChain(t *testing.T) {
+	usdc := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	assert.NotEqual(t,
+		ComputeDomainSeparator("USD Coin", "2", big.NewInt(1), usdc),
+		ComputeDomainSeparator("USD Coin", "2", big.NewInt(8453), usdc))
+}
+
+// testMailTypedData is the Mail example from the EIP-712 specification
+func testMailTypedData() apitypes.TypedData {
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"Person": {
+				{Name: "name", Type: "string"},
+				{Name: "wallet", Type: "address"},
+			},
+			"Mail": {
+				{Name: "from", Type: "Person"},
+				{Name: "to", Type: "Person"},
+				{Name: "contents", Type: "string"},
+			},
+		},
+		PrimaryType: "Mail",
+		Domain: apitypes.TypedDataDomain{
+			Name:              "Ether Mail",
+			Version:           "1",
+			ChainId:           math.NewHexOrDecimal256(1),
+			VerifyingContract: "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC",
+		},
+		Message: apitypes.TypedDataMessage{
+			"from":     map[string]interface{}{"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+			"to":       map[string]interface{}{"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+			"contents": "Hello, Bob!",
+		},
+	}
+}
+
+func TestGhostClient_SignTypedData(t *testing.T) {
+	// -- the specification's signer, keccak256("cow")
+	key := crypto.Keccak256([]byte("cow"))
+	privKey, err := crypto.ToECDSA(key)
+	assert.NoError(t, err)
+	acc := &Account{Address: crypto.PubkeyToAddress(privKey.PublicKey), PublicKey: &privKey.PublicKey, PrivateKey: privKey, ChainId: 1}
+	assert.Equal(t, common.HexToAddress("0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"), acc.Address)
+
+	mockClient := &internalmocks.EthClient{}
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		log:     newTestLogger(),
+	}
+
+	signature, err := gc.SignTypedData(testMailTypedData())
+	assert.NoError(t, err)
+	assert.Equal(t,
+		"0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d"+
+			"07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562"+"1c",
+		hexutil.Encode(signature))
+	assert.Empty(t, mockClient.Calls)
+}
+
+func TestGhostClient_SignTypedData_Invalid(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	gc := &ghostClient{
+		client:  &internalmocks.EthClient{},
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	typedData := testMailTypedData()
+	typedData.PrimaryType = "Letter"
+
+	_, err := gc.SignTypedData(typedData)
+	assert.Error(t, err)
+}
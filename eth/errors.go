@@ -0,0 +1,186 @@
+package eth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ErrPollLimitReached is returned when waiting for a transaction exhausts ETH_TRANSACTION_MAX_POLLS before the timeout
+var ErrPollLimitReached = errors.New("transaction poll limit reached")
+
+// ErrReconnectFailed is surfaced by a head subscription once re-dialing a dropped websocket connection has been given up
+var ErrReconnectFailed = errors.New("websocket reconnect failed")
+
+// ErrStateOverridesUnsupported is returned when the provider rejects eth_estimateGas state overrides
+var ErrStateOverridesUnsupported = errors.New("provider does not support state overrides")
+
+// ErrTracingUnsupported is returned when the provider doesn't expose debug_traceCall
+var ErrTracingUnsupported = errors.New("provider does not support call tracing")
+
+// ErrTransactionDropped is returned when a watched transaction is neither mined nor known to the node any more
+var ErrTransactionDropped = errors.New("transaction dropped")
+
+// ErrTransactionReorged is returned when a mined transaction's receipt disappears before it is final
+var ErrTransactionReorged = errors.New("transaction reorged out")
+
+// ErrConfigRequiresReconnect is returned by Config.Reload when settings only read at construction, such as accounts or RPC URLs, changed
+var ErrConfigRequiresReconnect = errors.New("configuration change requires a new client")
+
+// ErrBroadcastNotAccepted is returned when a sent transaction is not known to the provider within the broadcast check window
+var ErrBroadcastNotAccepted = errors.New("transaction not accepted by provider")
+
+// ErrNonPayableRecipient is returned when simulation shows the recipient contract would reject the transaction's ETH value
+var ErrNonPayableRecipient = errors.New("recipient contract is not payable")
+
+// ErrExpired is returned when a transaction is signed or sent after its ValidUntil deadline
+var ErrExpired = errors.New("transaction deadline expired")
+
+// ErrTxPoolUnavailable is returned when the provider doesn't expose the txpool namespace
+var ErrTxPoolUnavailable = errors.New("provider does not expose the txpool")
+
+// ErrPermitUnsupported is returned by SignPermit for tokens without a standard ERC-2612 permit
+var ErrPermitUnsupported = errors.New("token does not support ERC-2612 permit")
+
+// ErrNoActivity is returned when an address has never sent a transaction
+var ErrNoActivity = errors.New("address has no transactions")
+
+// ErrHistoricalStateUnavailable is returned when the provider can't serve the state of old blocks, e.g. a pruned full node
+var ErrHistoricalStateUnavailable = errors.New("provider does not serve historical state")
+
+// ErrTxTooLarge is returned when a signed transaction's encoded size exceeds ETH_MAX_TX_SIZE_BYTES
+var ErrTxTooLarge = errors.New("transaction too large")
+
+// ErrBaseFeeAboveCeiling is returned when the current base fee exceeds ETH_MAX_FEE_PER_GAS, so no
+// transaction within the fee ceiling can be included
+var ErrBaseFeeAboveCeiling = errors.New("base fee above max fee per gas ceiling")
+
+// Typed errors for common provider rejections. Errors returned when sending or estimating
+// transactions wrap one of these when the provider's message is recognized, alongside the
+// original error.
+var (
+	ErrNonceTooLow        = errors.New("nonce too low")
+	ErrInsufficientFunds  = errors.New("insufficient funds")
+	ErrUnderpriced        = errors.New("transaction underpriced")
+	ErrAlreadyKnown       = errors.New("transaction already known")
+	ErrTxTypeNotSupported = errors.New("transaction type not supported")
+)
+
+// providerErrorPatterns maps lowercase substrings of provider error messages to typed errors
+var providerErrorPatterns = []struct {
+	substr string
+	err    error
+}{
+	{"nonce too low", ErrNonceTooLow},
+	{"nonce is too low", ErrNonceTooLow},
+	{"nonce has already been used", ErrNonceTooLow},
+	{"insufficient funds", ErrInsufficientFunds},
+	{"underpriced", ErrUnderpriced},
+	{"fee too low", ErrUnderpriced},
+	{"less than block base fee", ErrUnderpriced},
+	{"already known", ErrAlreadyKnown},
+	{"known transaction", ErrAlreadyKnown},
+	{"already imported", ErrAlreadyKnown},
+	{"transaction type not supported", ErrTxTypeNotSupported},
+	{"tx type not supported", ErrTxTypeNotSupported},
+}
+
+// classifyError wraps err with the typed error it corresponds to, if any. A classifier set with
+// WithErrorClassifier is consulted first; the built-in patterns apply when it returns nil.
+func (es *ghostClient) classifyError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if es.errorClassifier != nil {
+		if typed := es.errorClassifier(err); typed != nil {
+			if errors.Is(err, typed) {
+				return err
+			}
+			return fmt.Errorf("%w: %w", typed, err)
+		}
+	}
+	msg := strings.ToLower(err.Error())
+	for _, p := range providerErrorPatterns {
+		if strings.Contains(msg, p.substr) {
+			return fmt.Errorf("%w: %w", p.err, err)
+		}
+	}
+	return err
+}
+
+// RPCErrorCode returns the JSON-RPC error code carried by err, if any error in its chain is an rpc.Error
+func RPCErrorCode(err error) (int, bool) {
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		return rpcErr.ErrorCode(), true
+	}
+	return 0, false
+}
+
+// isExecutionReverted reports whether err is an EVM revert rather than a transport or node failure
+func isExecutionReverted(err error) bool {
+	if code, ok := RPCErrorCode(err); ok && code == 3 {
+		return true
+	}
+	return strings.Contains(strings.ToLower(err.Error()), "revert")
+}
+
+// isOutOfGas reports whether err is a call running out of the gas it was given
+func isOutOfGas(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "out of gas") ||
+		strings.Contains(msg, "gas required exceeds allowance") ||
+		strings.Contains(msg, "intrinsic gas too low")
+}
+
+// isLogRangeError reports whether err is a provider rejecting an eth_getLogs request as covering too
+// many blocks or returning too many results. Only range-specific messages match, so generic
+// failures such as rate limits aren't retried with ever smaller ranges.
+func isLogRangeError(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, substr := range []string{
+		"exceed maximum block range",
+		"query returned more than",
+		"block range too large",
+		"block range is too large",
+	} {
+		if strings.Contains(msg, substr) {
+			return true
+		}
+	}
+	// -- -32005 is also used for rate limits, so it needs a mention of the range
+	code, ok := RPCErrorCode(err)
+	return ok && code == -32005 && strings.Contains(msg, "range")
+}
+
+// isMethodNotFound reports whether err indicates the provider doesn't implement the called RPC method
+func isMethodNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+	if code, ok := RPCErrorCode(err); ok && code == -32601 {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "method not found") ||
+		strings.Contains(msg, "does not exist")
+}
+
+// isMissingState reports whether err indicates the provider has pruned the state of the requested block
+func isMissingState(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, substr := range []string{
+		"missing trie node",
+		"state is not available",
+		"historical state",
+		"pruned",
+		"header not found",
+	} {
+		if strings.Contains(msg, substr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,189 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// testRPCError mimics the structured error returned by the go-ethereum rpc client
+type testRPCError struct {
+	code    int
+	message string
+}
+
+func (e *testRPCError) Error() string  { return e.message }
+func (e *testRPCError) ErrorCode() int { return e.code }
+
+var _ rpc.Error = (*testRPCError)(nil)
+
+func TestRPCErrorCode(t *testing.T) {
+	rpcErr := &testRPCError{code: -32000, message: "nonce too low"}
+
+	code, ok := RPCErrorCode(rpcErr)
+	assert.True(t, ok)
+	assert.Equal(t, -32000, code)
+
+	wrapped := fmt.Errorf("outer: %w", fmt.Errorf("inner: %w", rpcErr))
+	code, ok = RPCErrorCode(wrapped)
+	assert.True(t, ok)
+	assert.Equal(t, -32000, code)
+
+	_, ok = RPCErrorCode(errors.New("plain error"))
+	assert.False(t, ok)
+
+	_, ok = RPCErrorCode(nil)
+	assert.False(t, ok)
+}
+
+func TestIsLogRangeError(t *testing.T) {
+	for _, err := range []error{
+		&testRPCError{code: -32005, message: "query returned more than 10000 results"},
+		&testRPCError{code: -32000, message: "exceed maximum block range: 50000"},
+		&testRPCError{code: -32005, message: "eth_getLogs is limited to a 10,000 range"},
+		errors.New("block range too large"),
+	} {
+		assert.True(t, isLogRangeError(err), err.Error())
+	}
+	for _, err := range []error{
+		&testRPCError{code: -32005, message: "limit exceeded"},
+		&testRPCError{code: -32005, message: "request rate limit exceeded"},
+		errors.New("invalid block range params"),
+		errors.New("connection reset"),
+	} {
+		assert.False(t, isLogRangeError(err), err.Error())
+	}
+}
+
+func TestIsMethodNotFound(t *testing.T) {
+	assert.True(t, isMethodNotFound(&testRPCError{code: -32601, message: "unknown"}))
+	assert.True(t, isMethodNotFound(errors.New("the method txpool_content does not exist/is not available")))
+	assert.True(t, isMethodNotFound(errors.New("Method not found")))
+
+	// -- a supported method failing for another reason must not trigger a fallback
+	assert.False(t, isMethodNotFound(&testRPCError{code: -32000, message: "transaction type not supported"}))
+	assert.False(t, isMethodNotFound(errors.New("notifications not supported")))
+	assert.False(t, isMethodNotFound(nil))
+}
+
+func TestGhostClient_GetBalance_PreservesRPCError(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	rpcErr := &testRPCError{code: -32005, message: "rate limited"}
+	mockClient.On("BalanceAt", mock.Anything, acc.Address, (*big.Int)(nil)).Return(nil, rpcErr)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	_, err := gc.GetBalance(acc.Address)
+	assert.Error(t, err)
+
+	var target rpc.Error
+	assert.True(t, errors.As(err, &target))
+	code, ok := RPCErrorCode(err)
+	assert.True(t, ok)
+	assert.Equal(t, -32005, code)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_SendTransaction_PreservesRPCError(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	rpcErr := &testRPCError{code: -32000, message: "insufficient funds for gas * price + value"}
+	mockClient.On("SendTransaction", mock.Anything, mock.Anything).Return(rpcErr)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	to := acc.Address
+	signedTx := types.NewTx(&types.DynamicFeeTx{To: &to})
+	_, err := gc.SendTransaction(signedTx)
+	assert.Error(t, err)
+	code, ok := RPCErrorCode(err)
+	assert.True(t, ok)
+	assert.Equal(t, -32000, code)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_ClassifyError(t *testing.T) {
+	gc := &ghostClient{}
+
+	err := gc.classifyError(&testRPCError{code: -32000, message: "Nonce too low: next nonce 5, tx nonce 4"})
+	assert.ErrorIs(t, err, ErrNonceTooLow)
+	code, ok := RPCErrorCode(err) // the original error stays in the chain
+	assert.True(t, ok)
+	assert.Equal(t, -32000, code)
+
+	assert.ErrorIs(t, gc.classifyError(errors.New("insufficient funds for gas * price + value")), ErrInsufficientFunds)
+	assert.ErrorIs(t, gc.classifyError(errors.New("replacement transaction underpriced")), ErrUnderpriced)
+	assert.ErrorIs(t, gc.classifyError(errors.New("already known")), ErrAlreadyKnown)
+
+	plain := errors.New("rate limited")
+	assert.Equal(t, plain, gc.classifyError(plain))
+	assert.Nil(t, gc.classifyError(nil))
+}
+
+func TestGhostClient_ClassifyError_CustomClassifier(t *testing.T) {
+	gc := &ghostClient{}
+	WithErrorClassifier(func(err error) error {
+		msg := err.Error()
+		switch {
+		case msg == "ERR_TX_SEQ_STALE":
+			return ErrNonceTooLow
+		case strings.Contains(msg, "insufficient funds"):
+			return ErrUnderpriced // deliberately overrides the built-in mapping
+		}
+		return nil
+	})(gc)
+
+	assert.ErrorIs(t, gc.classifyError(errors.New("ERR_TX_SEQ_STALE")), ErrNonceTooLow)
+
+	err := gc.classifyError(errors.New("insufficient funds for gas * price + value"))
+	assert.ErrorIs(t, err, ErrUnderpriced)
+	assert.False(t, errors.Is(err, ErrInsufficientFunds))
+
+	// unrecognized by the classifier: built-in patterns still apply
+	assert.ErrorIs(t, gc.classifyError(errors.New("already known")), ErrAlreadyKnown)
+}
+
+func TestGhostClient_SendTransaction_ClassifiedError(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	tx := testBroadcastTx(t, acc)
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("SendTransaction", mock.Anything, tx).Return(&testRPCError{code: -32010, message: "ERR_TX_SEQ_STALE"})
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+		errorClassifier: func(err error) error {
+			if strings.Contains(err.Error(), "SEQ_STALE") {
+				return ErrNonceTooLow
+			}
+			return nil
+		},
+	}
+
+	_, err := gc.SendTransaction(tx)
+	assert.ErrorIs(t, err, ErrNonceTooLow)
+	assert.ErrorContains(t, err, "failed to send transaction")
+}
@@ -0,0 +1,255 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/sirupsen/logrus"
+)
+
+// maxGasSearchIterations bounds BinarySearchGasLimit; 2^32 covers any realistic gas range
+const maxGasSearchIterations = 32
+
+// IntrinsicGas returns the gas a transaction costs before any code runs, as of Shanghai: the base
+// cost of a call or contract creation, the per-byte cost of data (plus the per-word cost of init
+// code for a creation) and the cost of each access list address and storage key. No gas limit below
+// it can be included, so it is a floor for estimates that needs no RPC call.
+func IntrinsicGas(data []byte, isCreation bool, accessList types.AccessList) (uint64, error) {
+	gas := params.TxGas
+	if isCreation {
+		gas = params.TxGasContractCreation
+	}
+
+	// -- data length is bounded far below these limits in practice, but the protocol checks them
+	if dataLen := uint64(len(data)); dataLen > 0 {
+		var nonZero uint64
+		for _, b := range data {
+			if b != 0 {
+				nonZero++
+			}
+		}
+		if (math.MaxUint64-gas)/params.TxDataNonZeroGasEIP2028 < nonZero {
+			return 0, fmt.Errorf("intrinsic gas overflows (%d non-zero data bytes)", nonZero)
+		}
+		gas += nonZero * params.TxDataNonZeroGasEIP2028
+
+		zero := dataLen - nonZero
+		if (math.MaxUint64-gas)/params.TxDataZeroGas < zero {
+			return 0, fmt.Errorf("intrinsic gas overflows (%d zero data bytes)", zero)
+		}
+		gas += zero * params.TxDataZeroGas
+
+		if isCreation {
+			words := (dataLen + 31) / 32
+			if (math.MaxUint64-gas)/params.InitCodeWordGas < words {
+				return 0, fmt.Errorf("intrinsic gas overflows (%d init code words)", words)
+			}
+			gas += words * params.InitCodeWordGas
+		}
+	}
+
+	gas += uint64(len(accessList)) * params.TxAccessListAddressGas
+	gas += uint64(accessList.StorageKeys()) * params.TxAccessListStorageKeyGas
+	return gas, nil
+}
+
+// EstimateGasBatch estimates gas for each transaction in JSON-RPC batches of at most
+// ETH_RPC_MAX_BATCH_SIZE calls. Results are returned in the order of txs with a per-transaction
+// error, e.g. for calls that revert; the estimates are the raw node values without the configured
+// buffer.
+func (es *ghostClient) EstimateGasBatch(ctx context.Context, txs []*Transaction) ([]uint64, []error) {
+	estimates := make([]uint64, len(txs))
+	errs := make([]error, len(txs))
+
+	// -- invalid transactions fail locally and are left out of the batch
+	results := make([]hexutil.Uint64, len(txs))
+	batch := make([]rpc.BatchElem, 0, len(txs))
+	indexes := make([]int, 0, len(txs))
+	for i, tx := range txs {
+		if err := tx.Validate(); err != nil {
+			errs[i] = fmt.Errorf("invalid transaction: %w", err)
+			continue
+		}
+		batch = append(batch, rpc.BatchElem{
+			Method: "eth_estimateGas",
+			Args:   []interface{}{toCallArg(tx)},
+			Result: &results[i],
+		})
+		indexes = append(indexes, i)
+	}
+	if len(batch) == 0 {
+		return estimates, errs
+	}
+
+	es.batchCall(ctx, batch)
+
+	for n, elem := range batch {
+		i := indexes[n]
+		if elem.Error != nil {
+			errs[i] = fmt.Errorf("failed to estimate gas: %w", elem.Error)
+			continue
+		}
+		estimates[i] = uint64(results[i])
+	}
+
+	es.log.WithField("transactions", len(txs)).Info("Batch gas estimation complete")
+	return estimates, errs
+}
+
+// EstimateBatchCost estimates what sending txs would cost without signing or sending anything, e.g.
+// to confirm the total outlay of a payout before executing it. Gas limits set on a transaction are
+// used as is; the others are estimated in one batch as with EstimateGasBatch and buffered as when
+// signing. Fees are the transaction's own or, as when signing, those computed from the current base
+// fee (or gas price) once for the batch. The first transaction that is invalid or can't be
+// estimated fails the report.
+func (es *ghostClient) EstimateBatchCost(ctx context.Context, txs []*Transaction) (*BatchCostReport, error) {
+	for i, tx := range txs {
+		if err := tx.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid transaction %d: %w", i, err)
+		}
+	}
+
+	// -- estimate the transactions without a gas limit in one batch
+	gasLimits := make([]uint64, len(txs))
+	var estimate []*Transaction
+	var indexes []int
+	for i, tx := range txs {
+		if tx.GasLimit != 0 {
+			gasLimits[i] = tx.GasLimit
+			continue
+		}
+		estimate = append(estimate, tx)
+		indexes = append(indexes, i)
+	}
+	if len(estimate) > 0 {
+		estimates, errs := es.EstimateGasBatch(ctx, estimate)
+		for n, i := range indexes {
+			if errs[n] != nil {
+				return nil, fmt.Errorf("transaction %d: %w", i, errs[n])
+			}
+			gasLimit, err := applyGasBuffer(estimates[n], es.gasLimitBuffer(txs[i]), es.config.GasLimitBufferAbsolute())
+			if err != nil {
+				return nil, fmt.Errorf("transaction %d: %w", i, err)
+			}
+			gasLimits[i] = gasLimit
+		}
+	}
+
+	// -- the fees a transaction without its own would get
+	defaults := &Transaction{}
+	if err := es.calculateOptimalFees(defaults); err != nil {
+		return nil, err
+	}
+
+	report := &BatchCostReport{
+		Transactions: make([]TransactionCost, len(txs)),
+		TotalFee:     new(big.Int),
+		TotalValue:   new(big.Int),
+		Total:        new(big.Int),
+	}
+	for i, tx := range txs {
+		feePerGas := defaults.GasPrice
+		if defaults.MaxFeePerGas != nil {
+			feePerGas = defaults.MaxFeePerGas
+			if tx.MaxFeePerGas != nil && tx.MaxPriorityFeePerGas != nil {
+				if err := es.validateFees(tx); err != nil {
+					return nil, fmt.Errorf("transaction %d: %w", i, err)
+				}
+				feePerGas = tx.MaxFeePerGas
+			}
+		} else if tx.GasPrice != nil {
+			feePerGas = tx.GasPrice
+		}
+
+		value := new(big.Int)
+		if tx.Value != nil {
+			value.Set(tx.Value)
+		}
+		fee := new(big.Int).Mul(new(big.Int).SetUint64(gasLimits[i]), feePerGas)
+		total := new(big.Int).Add(fee, value)
+		report.Transactions[i] = TransactionCost{
+			GasLimit:       gasLimits[i],
+			FeePerGas:      new(big.Int).Set(feePerGas),
+			Fee:            fee,
+			Value:          value,
+			Total:          total,
+			FeeFormatted:   FormatUnits(fee, 18),
+			ValueFormatted: FormatUnits(value, 18),
+			TotalFormatted: FormatUnits(total, 18),
+		}
+		report.TotalGas += gasLimits[i]
+		report.TotalFee.Add(report.TotalFee, fee)
+		report.TotalValue.Add(report.TotalValue, value)
+		report.Total.Add(report.Total, total)
+	}
+	report.FeeFormatted = FormatUnits(report.TotalFee, 18)
+	report.ValueFormatted = FormatUnits(report.TotalValue, 18)
+	report.TotalFormatted = FormatUnits(report.Total, 18)
+
+	es.log.WithFields(logrus.Fields{
+		"transactions": len(txs),
+		"total_fee":    report.TotalFee.String(),
+		"total_value":  report.TotalValue.String(),
+	}).Info("Estimated batch cost")
+	return report, nil
+}
+
+// BinarySearchGasLimit finds the lowest gas limit in [lo, hi] at which tx executes without error,
+// by binary search over eth_call with the gas capped. It is slower than eth_estimateGas but doesn't
+// depend on the node's estimator, which some contracts confuse (e.g. gas-dependent branches). tx
+// must succeed at hi. The search is bounded; if it stops early the lowest succeeding limit found
+// is returned. Only out-of-gas and revert failures narrow the search; any other error, such as a
+// timeout or rate limit, is returned. As with EstimateGasBatch, no buffer is applied.
+func (es *ghostClient) BinarySearchGasLimit(ctx context.Context, tx *Transaction, lo, hi uint64) (uint64, error) {
+	if err := tx.Validate(); err != nil {
+		return 0, fmt.Errorf("invalid transaction: %w", err)
+	}
+	if lo < params.TxGas {
+		lo = params.TxGas
+	}
+	if hi < lo {
+		return 0, fmt.Errorf("invalid gas range: upper bound %d is below lower bound %d", hi, lo)
+	}
+
+	from := tx.From
+	if tx.EstimateFrom != (common.Address{}) {
+		from = tx.EstimateFrom
+	}
+	msg := ethereum.CallMsg{From: from, To: tx.recipient(), Value: tx.Value, Data: tx.Data}
+	call := func(gas uint64) error {
+		msg.Gas = gas
+		_, err := es.readClient().CallContract(ctx, msg, nil)
+		return err
+	}
+
+	if err := call(hi); err != nil {
+		return 0, fmt.Errorf("transaction fails at upper bound %d: %w", hi, err)
+	}
+
+	// -- invariant: hi succeeds; lo-1 is treated as failing
+	for i := 0; i < maxGasSearchIterations && lo < hi; i++ {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		mid := lo + (hi-lo)/2
+		if err := call(mid); err != nil {
+			if !isOutOfGas(err) && !isExecutionReverted(err) {
+				return 0, fmt.Errorf("call at gas limit %d failed: %w", mid, err)
+			}
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+
+	es.log.WithField("gas_limit", hi).Info("Binary search gas limit complete")
+	return hi, nil
+}
@@ -0,0 +1,310 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGhostClient_EstimateGasBatch(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockRPC := &internalmocks.RPCClient{}
+	token := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	txs := []*Transaction{
+		{From: acc.Address, To: common.HexToAddress("0x02"), Value: big.NewInt(1)},
+		{From: acc.Address, To: token, Data: []byte{0xa9, 0x05, 0x9c, 0xbb}},
+		{From: acc.Address, To: token, Value: big.NewInt(-1)}, // invalid, never sent
+		{From: acc.Address, To: token, Data: []byte{0x09, 0x5e, 0xa7, 0xb3}},
+	}
+
+	mockRPC.On("BatchCallContext", mock.Anything, mock.MatchedBy(func(b []rpc.BatchElem) bool {
+		return len(b) == 3
+	})).Run(func(args mock.Arguments) {
+		batch := args.Get(1).([]rpc.BatchElem)
+		for _, elem := range batch {
+			assert.Equal(t, "eth_estimateGas", elem.Method)
+		}
+		*batch[0].Result.(*hexutil.Uint64) = 21000
+		batch[1].Error = &testRPCError{code: 3, message: "execution reverted: ERC20: transfer amount exceeds balance"}
+		*batch[2].Result.(*hexutil.Uint64) = 46000
+	}).Return(nil).Once()
+	gc := &ghostClient{
+		rpc:     mockRPC,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	estimates, errs := gc.EstimateGasBatch(context.Background(), txs)
+	assert.Equal(t, []uint64{21000, 0, 0, 46000}, estimates)
+	assert.NoError(t, errs[0])
+	assert.ErrorContains(t, errs[1], "execution reverted")
+	assert.ErrorContains(t, errs[2], "invalid transaction")
+	assert.NoError(t, errs[3])
+	mockRPC.AssertExpectations(t)
+}
+
+func TestGhostClient_EstimateGasBatch_TransportError(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockRPC := &internalmocks.RPCClient{}
+	mockRPC.On("BatchCallContext", mock.Anything, mock.Anything).Return(errors.New("connection reset"))
+	gc := &ghostClient{
+		rpc:     mockRPC,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	txs := []*Transaction{
+		{From: acc.Address, To: common.HexToAddress("0x02")},
+		{From: acc.Address, To: common.HexToAddress("0x03")},
+	}
+	_, errs := gc.EstimateGasBatch(context.Background(), txs)
+	assert.ErrorContains(t, errs[0], "connection reset")
+	assert.ErrorContains(t, errs[1], "connection reset")
+}
+
+func TestGhostClient_BinarySearchGasLimit(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	const threshold = 73519
+	mockClient := &internalmocks.EthClient{}
+	var calls int
+	mockClient.On("CallContract", mock.Anything, mock.Anything, (*big.Int)(nil)).
+		Return(func(_ context.Context, msg ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+			calls++
+			if msg.Gas < threshold {
+				return nil, errors.New("out of gas")
+			}
+			return []byte{}, nil
+		})
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	tx := &Transaction{From: acc.Address, To: common.HexToAddress("0x02"), Data: []byte{0x01}}
+
+	gas, err := gc.BinarySearchGasLimit(context.Background(), tx, 21000, 1_000_000)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(threshold), gas)
+	assert.LessOrEqual(t, calls, maxGasSearchIterations+1)
+
+	// failing at the upper bound
+	_, err = gc.BinarySearchGasLimit(context.Background(), tx, 21000, 50000)
+	assert.ErrorContains(t, err, "fails at upper bound 50000")
+
+	// inverted range
+	_, err = gc.BinarySearchGasLimit(context.Background(), tx, 90000, 80000)
+	assert.ErrorContains(t, err, "invalid gas range")
+}
+
+func TestGhostClient_BinarySearchGasLimit_TransportError(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("CallContract", mock.Anything, mock.Anything, (*big.Int)(nil)).Return([]byte{}, nil).Once()
+	mockClient.On("CallContract", mock.Anything, mock.Anything, (*big.Int)(nil)).
+		Return(nil, &testRPCError{code: 429, message: "Too Many Requests"}).Once()
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	tx := &Transaction{From: acc.Address, To: common.HexToAddress("0x02"), Data: []byte{0x01}}
+
+	// -- a rate limit says nothing about the gas limit, so it ends the search
+	_, err := gc.BinarySearchGasLimit(context.Background(), tx, 21000, 1_000_000)
+	assert.ErrorContains(t, err, "Too Many Requests")
+	mockClient.AssertExpectations(t)
+}
+
+func TestIntrinsicGas(t *testing.T) {
+	nonZero := func(n int) []byte {
+		data := make([]byte, n)
+		for i := range data {
+			data[i] = 0xff
+		}
+		return data
+	}
+	tests := []struct {
+		name       string
+		data       []byte
+		isCreation bool
+		accessList types.AccessList
+		want       uint64
+	}{
+		{name: "transfer", want: 21000},
+		{name: "selector and zero word", data: append(nonZero(4), make([]byte, 32)...), want: 21000 + 4*16 + 32*4},
+		{name: "data heavy", data: nonZero(1000), want: 21000 + 1000*16},
+		{name: "creation", data: nonZero(64), isCreation: true, want: 53000 + 64*16 + 2*2},
+		{name: "creation partial word", data: nonZero(33), isCreation: true, want: 53000 + 33*16 + 2*2},
+		{
+			name: "access list",
+			accessList: types.AccessList{{
+				Address:     common.HexToAddress("0x01"),
+				StorageKeys: []common.Hash{common.HexToHash("0x01"), common.HexToHash("0x02")},
+			}},
+			want: 21000 + 2400 + 2*1900,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gas, err := IntrinsicGas(tt.data, tt.isCreation, tt.accessList)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, gas)
+		})
+	}
+}
+
+func TestGhostClient_EstimateBatchCost(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	token := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	oneEth := big.NewInt(1e18)
+	txs := []*Transaction{
+		{From: acc.Address, To: common.HexToAddress("0x02"), Value: oneEth},
+		{From: acc.Address, To: token, Data: []byte{0xa9, 0x05, 0x9c, 0xbb}},
+		{From: acc.Address, To: common.HexToAddress("0x03"), Value: big.NewInt(5e17), GasLimit: 30000, MaxFeePerGas: big.NewInt(40 * GWEI), MaxPriorityFeePerGas: big.NewInt(GWEI)},
+	}
+
+	// -- only the transactions without a gas limit are estimated
+	mockRPC := &internalmocks.RPCClient{}
+	mockRPC.On("BatchCallContext", mock.Anything, mock.MatchedBy(func(b []rpc.BatchElem) bool {
+		return len(b) == 2
+	})).Run(func(args mock.Arguments) {
+		batch := args.Get(1).([]rpc.BatchElem)
+		*batch[0].Result.(*hexutil.Uint64) = 21000
+		*batch[1].Result.(*hexutil.Uint64) = 50000
+	}).Return(nil).Once()
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{BaseFee: big.NewInt(10 * GWEI)}, nil).Once()
+	gc := &ghostClient{
+		client:  mockClient,
+		rpc:     mockRPC,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	report, err := gc.EstimateBatchCost(context.Background(), txs)
+	assert.NoError(t, err)
+	assert.Len(t, report.Transactions, 3)
+
+	// -- 2x base fee plus the 2 gwei mainnet tip, with the simple and complex buffers
+	defaultFee := big.NewInt(22 * GWEI)
+	assert.Equal(t, uint64(23100), report.Transactions[0].GasLimit)
+	assert.Equal(t, defaultFee, report.Transactions[0].FeePerGas)
+	assert.Equal(t, new(big.Int).Mul(big.NewInt(23100), defaultFee), report.Transactions[0].Fee)
+	assert.Equal(t, uint64(60000), report.Transactions[1].GasLimit)
+	assert.Equal(t, new(big.Int).Mul(big.NewInt(60000), defaultFee), report.Transactions[1].Fee)
+	assert.Equal(t, "0.00132", report.Transactions[1].FeeFormatted)
+	assert.Equal(t, "0", report.Transactions[1].ValueFormatted)
+	assert.Equal(t, uint64(30000), report.Transactions[2].GasLimit)
+	assert.Equal(t, big.NewInt(40*GWEI), report.Transactions[2].FeePerGas)
+	assert.Equal(t, "0.5012", report.Transactions[2].TotalFormatted)
+
+	// -- fees 0.0005082 + 0.00132 + 0.0012 ETH on top of 1.5 ETH
+	assert.Equal(t, uint64(113100), report.TotalGas)
+	assert.Equal(t, big.NewInt(3028200*GWEI), report.TotalFee)
+	assert.Equal(t, big.NewInt(15e17), report.TotalValue)
+	assert.Equal(t, new(big.Int).Add(report.TotalFee, report.TotalValue), report.Total)
+	assert.Equal(t, "0.0030282", report.FeeFormatted)
+	assert.Equal(t, "1.5", report.ValueFormatted)
+	assert.Equal(t, "1.5030282", report.TotalFormatted)
+	mockRPC.AssertExpectations(t)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_EstimateBatchCost_EstimateFails(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockRPC := &internalmocks.RPCClient{}
+	mockRPC.On("BatchCallContext", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		batch := args.Get(1).([]rpc.BatchElem)
+		*batch[0].Result.(*hexutil.Uint64) = 21000
+		batch[1].Error = &testRPCError{code: 3, message: "execution reverted"}
+	}).Return(nil).Once()
+	gc := &ghostClient{
+		client:  &internalmocks.EthClient{},
+		rpc:     mockRPC,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	_, err := gc.EstimateBatchCost(context.Background(), []*Transaction{
+		{From: acc.Address, To: common.HexToAddress("0x02"), Value: big.NewInt(1)},
+		{From: acc.Address, To: common.HexToAddress("0x03"), Data: []byte{0x01}},
+	})
+	assert.ErrorContains(t, err, "transaction 1")
+	assert.ErrorContains(t, err, "execution reverted")
+
+	_, err = gc.EstimateBatchCost(context.Background(), []*Transaction{{From: acc.Address, Value: big.NewInt(-1)}})
+	assert.ErrorContains(t, err, "invalid transaction 0")
+}
+
+func TestGhostClient_EstimateGas(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	token := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("EstimateGas", mock.Anything, mock.MatchedBy(func(msg ethereum.CallMsg) bool {
+		return msg.From == acc.Address && *msg.To == token
+	})).Return(uint64(46000), nil).Once()
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	// -- the raw estimate, without the complex buffer, and tx is left as is
+	tx := &Transaction{From: acc.Address, To: token, Data: []byte{0xa9, 0x05, 0x9c, 0xbb}}
+	gas, err := gc.EstimateGas(tx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(46000), gas)
+	assert.Zero(t, tx.GasLimit)
+
+	_, err = gc.EstimateGas(&Transaction{From: acc.Address, To: token, Value: big.NewInt(-1)})
+	assert.ErrorContains(t, err, "invalid transaction")
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_EstimateGas_Reverts(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(0), errors.New("execution reverted")).Once()
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	_, err := gc.EstimateGas(&Transaction{From: acc.Address, To: common.HexToAddress("0x02"), Data: []byte{0x01}})
+	assert.ErrorContains(t, err, "failed to estimate gas")
+}
@@ -0,0 +1,427 @@
+// Package ethtest provides an in-memory EthClient for testing code built on eth.GhostClient
+// without a node, a mock or any RPC wiring:
+//
+//	backend := ethtest.NewClient(chainID)
+//	backend.SetBalance(account.Address, big.NewInt(1e18))
+//	client, err := eth.NewGhostClient(account, cfg, log, eth.WithEthClient(backend))
+//
+// Each accepted transaction is mined into its own block straight away, like anvil's automine.
+package ethtest
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/nando-os/ghost-eth/eth"
+)
+
+const (
+	// DefaultBaseFee is the base fee of every block until SetBaseFee changes it
+	DefaultBaseFee = 1_000_000_000 // 1 gwei
+	// DefaultTip is the priority fee the client suggests
+	DefaultTip = 1_000_000_000 // 1 gwei
+	// DefaultGasLimit is the gas limit of every block
+	DefaultGasLimit = 30_000_000
+	// blockTime is the spacing of block timestamps
+	blockTime = 12 * time.Second
+)
+
+// Ensure *Client implements eth.EthClient
+var _ eth.EthClient = (*Client)(nil)
+
+// Client is an in-memory eth.EthClient simulating a single-node chain. It tracks balances,
+// nonces and contract code, validates transactions the way a node's mempool does (chain ID,
+// signature, nonce, intrinsic gas, fee cap and funds) and mines each accepted transaction into
+// a new block with a successful receipt. Transactions move value and pay fees but run no EVM
+// code, so contract calls return empty data and emit no logs.
+//
+// State is current only: reads at historical block numbers see the latest state. Client is safe
+// for concurrent use.
+type Client struct {
+	chainID *big.Int
+	signer  types.Signer
+
+	mu       sync.RWMutex
+	baseFee  *big.Int
+	balances map[common.Address]*big.Int
+	nonces   map[common.Address]uint64
+	code     map[common.Address][]byte
+	blocks   []*types.Block
+	receipts map[common.Hash][]*types.Receipt // by block hash
+	txs      map[common.Hash]*types.Receipt   // by transaction hash
+
+	heads event.Feed
+}
+
+// NewClient returns a Client for chainID holding only a genesis block
+func NewClient(chainID int64) *Client {
+	c := &Client{
+		chainID:  big.NewInt(chainID),
+		signer:   types.LatestSignerForChainID(big.NewInt(chainID)),
+		baseFee:  big.NewInt(DefaultBaseFee),
+		balances: make(map[common.Address]*big.Int),
+		nonces:   make(map[common.Address]uint64),
+		code:     make(map[common.Address][]byte),
+		receipts: make(map[common.Hash][]*types.Receipt),
+		txs:      make(map[common.Hash]*types.Receipt),
+	}
+	c.blocks = []*types.Block{types.NewBlockWithHeader(&types.Header{
+		Number:     big.NewInt(0),
+		Time:       uint64(time.Now().Unix()),
+		GasLimit:   DefaultGasLimit,
+		BaseFee:    new(big.Int).Set(c.baseFee),
+		Difficulty: big.NewInt(0),
+	})}
+	return c
+}
+
+// SetBalance sets the balance of addr in wei
+func (c *Client) SetBalance(addr common.Address, wei *big.Int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.balances[addr] = new(big.Int).Set(wei)
+}
+
+// SetCode deploys code at addr, making it a contract as far as CodeAt is concerned
+func (c *Client) SetCode(addr common.Address, code []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.code[addr] = common.CopyBytes(code)
+}
+
+// SetBaseFee sets the base fee of the blocks mined from now on
+func (c *Client) SetBaseFee(wei *big.Int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.baseFee = new(big.Int).Set(wei)
+}
+
+// Mine adds an empty block, e.g. to add confirmations on top of a mined transaction
+func (c *Client) Mine() *types.Block {
+	c.mu.Lock()
+	block := c.mineLocked(nil, nil)
+	c.mu.Unlock()
+
+	c.heads.Send(block.Header())
+	return block
+}
+
+// ChainID returns the chain ID the client was created for
+func (c *Client) ChainID(ctx context.Context) (*big.Int, error) {
+	return new(big.Int).Set(c.chainID), nil
+}
+
+// BalanceAt returns the current balance of account, whatever blockNumber is
+func (c *Client) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.balanceLocked(account), nil
+}
+
+// BlockByHash returns the block with hash
+func (c *Client) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, block := range c.blocks {
+		if block.Hash() == hash {
+			return block, nil
+		}
+	}
+	return nil, ethereum.NotFound
+}
+
+// BlockByNumber returns the block at number, or the latest block for nil and the named tags
+func (c *Client) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.blockLocked(number)
+}
+
+// BlockReceipts returns the receipts of the block identified by blockNrOrHash
+func (c *Client) BlockReceipts(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]*types.Receipt, error) {
+	var block *types.Block
+	var err error
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		block, err = c.BlockByHash(ctx, hash)
+	} else if number, ok := blockNrOrHash.Number(); ok {
+		block, err = c.BlockByNumber(ctx, big.NewInt(number.Int64()))
+	} else {
+		return nil, fmt.Errorf("invalid block number or hash")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.receipts[block.Hash()], nil
+}
+
+// CodeAt returns the code set with SetCode, empty for externally owned accounts
+func (c *Client) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return common.CopyBytes(c.code[account]), nil
+}
+
+// CallContract returns empty data for calls to accounts without code. Contract code isn't
+// executed, so calls to contracts fail.
+func (c *Client) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if msg.To != nil && len(c.code[*msg.To]) > 0 {
+		return nil, fmt.Errorf("ethtest: contract calls are not supported (%s)", msg.To.Hex())
+	}
+	return nil, nil
+}
+
+// SendTransaction validates tx against the current state and mines it into a new block. Errors
+// carry the messages geth uses, so callers see the same typed errors as against a node.
+func (c *Client) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	c.mu.Lock()
+	receipt, err := c.applyLocked(tx)
+	if err != nil {
+		c.mu.Unlock()
+		return err
+	}
+	block := c.mineLocked(types.Transactions{tx}, []*types.Receipt{receipt})
+	c.mu.Unlock()
+
+	c.heads.Send(block.Header())
+	return nil
+}
+
+// TransactionByHash returns a mined transaction. Transactions are never pending.
+func (c *Client) TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	receipt, ok := c.txs[hash]
+	if !ok {
+		return nil, false, ethereum.NotFound
+	}
+	return c.blocks[receipt.BlockNumber.Uint64()].Transactions()[receipt.TransactionIndex], false, nil
+}
+
+// TransactionReceipt returns the receipt of a mined transaction
+func (c *Client) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	receipt, ok := c.txs[txHash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return receipt, nil
+}
+
+// PendingNonceAt returns the next nonce of account; with every transaction mined on arrival it is
+// the same as NonceAt
+func (c *Client) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return c.NonceAt(ctx, account, nil)
+}
+
+// NonceAt returns the current nonce of account, whatever blockNumber is
+func (c *Client) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.nonces[account], nil
+}
+
+// EstimateGas returns the intrinsic gas of msg, which is what a transaction costs when no code runs
+func (c *Client) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	return eth.IntrinsicGas(msg.Data, msg.To == nil, msg.AccessList)
+}
+
+// FilterLogs returns no logs: transactions run no code, so none are emitted
+func (c *Client) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	return nil, nil
+}
+
+// FeeHistory reports the base fees of the last blockCount blocks up to lastBlock, with the
+// default tip at every requested percentile
+func (c *Client) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	last, err := c.blockLocked(lastBlock)
+	if err != nil {
+		return nil, err
+	}
+	oldest := uint64(0)
+	if n := last.NumberU64() + 1; blockCount < n {
+		oldest = n - blockCount
+	}
+
+	history := &ethereum.FeeHistory{OldestBlock: new(big.Int).SetUint64(oldest)}
+	for _, block := range c.blocks[oldest : last.NumberU64()+1] {
+		history.BaseFee = append(history.BaseFee, block.BaseFee())
+		history.GasUsedRatio = append(history.GasUsedRatio, float64(block.GasUsed())/float64(block.GasLimit()))
+		if len(rewardPercentiles) > 0 {
+			reward := make([]*big.Int, len(rewardPercentiles))
+			for i := range reward {
+				reward[i] = big.NewInt(DefaultTip)
+			}
+			history.Reward = append(history.Reward, reward)
+		}
+	}
+	// -- the base fee of the block after lastBlock
+	history.BaseFee = append(history.BaseFee, new(big.Int).Set(c.baseFee))
+	return history, nil
+}
+
+// HeaderByHash returns the header of the block with hash
+func (c *Client) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	block, err := c.BlockByHash(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+	return block.Header(), nil
+}
+
+// HeaderByNumber returns the header of the block at number, or of the latest block for nil and
+// the named tags
+func (c *Client) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	block, err := c.BlockByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	return block.Header(), nil
+}
+
+// SubscribeNewHead delivers the header of every block mined from now on to ch
+func (c *Client) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	return c.heads.Subscribe(ch), nil
+}
+
+// SuggestGasPrice returns the current base fee plus the default tip
+func (c *Client) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return new(big.Int).Add(c.baseFee, big.NewInt(DefaultTip)), nil
+}
+
+// SuggestGasTipCap returns the default tip
+func (c *Client) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return big.NewInt(DefaultTip), nil
+}
+
+// Close does nothing; the state stays readable
+func (c *Client) Close() {}
+
+// applyLocked checks tx the way a node's mempool would and applies its value transfer and fee
+func (c *Client) applyLocked(tx *types.Transaction) (*types.Receipt, error) {
+	if tx.ChainId().Cmp(c.chainID) != 0 {
+		return nil, fmt.Errorf("invalid chain id: have %s want %s", tx.ChainId(), c.chainID)
+	}
+	from, err := types.Sender(c.signer, tx)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender: %w", err)
+	}
+	if _, ok := c.txs[tx.Hash()]; ok {
+		return nil, fmt.Errorf("already known")
+	}
+	if nonce := c.nonces[from]; tx.Nonce() < nonce {
+		return nil, fmt.Errorf("nonce too low: address %s, tx: %d state: %d", from.Hex(), tx.Nonce(), nonce)
+	} else if tx.Nonce() > nonce {
+		return nil, fmt.Errorf("nonce too high: address %s, tx: %d state: %d", from.Hex(), tx.Nonce(), nonce)
+	}
+	gasUsed, err := eth.IntrinsicGas(tx.Data(), tx.To() == nil, tx.AccessList())
+	if err != nil {
+		return nil, err
+	}
+	if tx.Gas() < gasUsed {
+		return nil, fmt.Errorf("intrinsic gas too low: gas %d, minimum needed %d", tx.Gas(), gasUsed)
+	}
+	if tx.Gas() > DefaultGasLimit {
+		return nil, fmt.Errorf("exceeds block gas limit")
+	}
+	if tx.GasFeeCap().Cmp(c.baseFee) < 0 {
+		return nil, fmt.Errorf("max fee per gas less than block base fee: maxFeePerGas: %s baseFee: %s", tx.GasFeeCap(), c.baseFee)
+	}
+	balance := c.balanceLocked(from)
+	if cost := tx.Cost(); balance.Cmp(cost) < 0 {
+		return nil, fmt.Errorf("insufficient funds for gas * price + value: address %s have %s want %s", from.Hex(), balance, cost)
+	}
+
+	price := new(big.Int).Add(c.baseFee, tx.EffectiveGasTipValue(c.baseFee))
+	fee := new(big.Int).Mul(price, new(big.Int).SetUint64(gasUsed))
+	to := crypto.CreateAddress(from, tx.Nonce())
+	if tx.To() != nil {
+		to = *tx.To()
+	}
+	balance.Sub(balance, fee)
+	c.balances[from] = balance.Sub(balance, tx.Value())
+	c.balances[to] = new(big.Int).Add(c.balanceLocked(to), tx.Value())
+	c.nonces[from]++
+
+	receipt := &types.Receipt{
+		Type:              tx.Type(),
+		Status:            types.ReceiptStatusSuccessful,
+		CumulativeGasUsed: gasUsed,
+		Logs:              []*types.Log{},
+		TxHash:            tx.Hash(),
+		GasUsed:           gasUsed,
+		EffectiveGasPrice: price,
+	}
+	if tx.To() == nil {
+		receipt.ContractAddress = to
+	}
+	receipt.Bloom = types.CreateBloom(receipt)
+	return receipt, nil
+}
+
+// mineLocked appends a block holding txs and fills in the block fields of their receipts
+func (c *Client) mineLocked(txs types.Transactions, receipts []*types.Receipt) *types.Block {
+	parent := c.blocks[len(c.blocks)-1]
+	var gasUsed uint64
+	for _, receipt := range receipts {
+		gasUsed += receipt.GasUsed
+	}
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     new(big.Int).Add(parent.Number(), common.Big1),
+		Time:       parent.Time() + uint64(blockTime/time.Second),
+		GasLimit:   DefaultGasLimit,
+		GasUsed:    gasUsed,
+		BaseFee:    new(big.Int).Set(c.baseFee),
+		Difficulty: big.NewInt(0),
+	}
+	block := types.NewBlockWithHeader(header).WithBody(types.Body{Transactions: txs})
+
+	for i, receipt := range receipts {
+		receipt.BlockHash = block.Hash()
+		receipt.BlockNumber = block.Number()
+		receipt.TransactionIndex = uint(i)
+		c.txs[receipt.TxHash] = receipt
+	}
+	c.receipts[block.Hash()] = receipts
+	c.blocks = append(c.blocks, block)
+	return block
+}
+
+// blockLocked returns the block at number, or the latest block for nil and negative block tags
+func (c *Client) blockLocked(number *big.Int) (*types.Block, error) {
+	if number == nil || number.Sign() < 0 {
+		return c.blocks[len(c.blocks)-1], nil
+	}
+	if !number.IsUint64() || number.Uint64() >= uint64(len(c.blocks)) {
+		return nil, ethereum.NotFound
+	}
+	return c.blocks[number.Uint64()], nil
+}
+
+// balanceLocked returns a copy of the balance of addr
+func (c *Client) balanceLocked(addr common.Address) *big.Int {
+	if balance, ok := c.balances[addr]; ok {
+		return new(big.Int).Set(balance)
+	}
+	return new(big.Int)
+}
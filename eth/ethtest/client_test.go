@@ -0,0 +1,124 @@
+package ethtest_test
+
+import (
+	"io"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nando-os/ghost-eth/eth"
+	"github.com/nando-os/ghost-eth/eth/ethtest"
+)
+
+const testChainID = 31337
+
+// newTestClient returns a GhostClient for the first anvil account backed by an ethtest.Client
+// holding 10 ETH for it
+func newTestClient(t *testing.T) (eth.GhostClient, *ethtest.Client, *eth.Account) {
+	t.Setenv("ETH_CHAIN_ID", "31337")
+	t.Setenv("ETH_ACCOUNTS", "main")
+	t.Setenv("ETH_ACCOUNT_MAIN_PRIVATE_KEY", "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	t.Setenv("ETH_TRANSACTION_TICKER_SECONDS", "1")
+	cfg, err := eth.NewConfiguration()
+	require.NoError(t, err)
+	account := cfg.Accounts()[0]
+
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	backend := ethtest.NewClient(testChainID)
+	backend.SetBalance(account.Address, new(big.Int).Mul(big.NewInt(10), big.NewInt(1e18)))
+
+	client, err := eth.NewGhostClient(account, cfg, log, eth.WithEthClient(backend))
+	require.NoError(t, err)
+	t.Cleanup(client.Close)
+	return client, backend, account
+}
+
+func TestClient_SendAndConfirm(t *testing.T) {
+	client, backend, account := newTestClient(t)
+	to := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	value := big.NewInt(1e18)
+
+	signedTx, err := client.SignTransaction(&eth.Transaction{From: account.Address, To: to, Value: value})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), signedTx.Nonce())
+
+	receipt, err := client.SendTransactionAndWait(signedTx)
+	require.NoError(t, err)
+	assert.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	assert.Equal(t, uint64(1), receipt.BlockNumber)
+	assert.Equal(t, uint64(21000), receipt.GasUsed)
+	assert.Equal(t, to, receipt.To)
+	assert.Equal(t, value, receipt.Value)
+
+	// -- the recipient got the value; the sender paid it and the fee
+	balance, err := client.GetBalance(to)
+	require.NoError(t, err)
+	assert.Equal(t, value, balance)
+	balance, err = client.GetBalance(account.Address)
+	require.NoError(t, err)
+	spent := new(big.Int).Add(value, receipt.Fee())
+	assert.Equal(t, new(big.Int).Sub(new(big.Int).Mul(big.NewInt(10), big.NewInt(1e18)), spent), balance)
+
+	// -- two more blocks give the transaction two confirmations
+	backend.Mine()
+	backend.Mine()
+	confirmed, err := client.WaitForConfirmations(receipt.TxHash, 2)
+	require.NoError(t, err)
+	assert.Equal(t, receipt.TxHash, confirmed.TxHash)
+
+	// -- the next transaction takes the next nonce
+	signedTx, err = client.SignTransaction(&eth.Transaction{From: account.Address, To: to, Value: value})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), signedTx.Nonce())
+}
+
+func TestClient_SendTransaction_Rejected(t *testing.T) {
+	client, _, account := newTestClient(t)
+	to := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+
+	// -- nonce 0 means "fill it in", so replay nonce 1
+	var signedTx *types.Transaction
+	for i := 0; i < 2; i++ {
+		var err error
+		signedTx, err = client.SignTransaction(&eth.Transaction{From: account.Address, To: to, Value: big.NewInt(1)})
+		require.NoError(t, err)
+		_, err = client.SendTransactionAndWait(signedTx)
+		require.NoError(t, err)
+	}
+
+	// -- the same nonce again
+	replay, err := client.SignTransaction(&eth.Transaction{From: account.Address, To: to, Value: big.NewInt(2), Nonce: signedTx.Nonce()})
+	require.NoError(t, err)
+	_, err = client.SendTransaction(replay)
+	assert.ErrorIs(t, err, eth.ErrNonceTooLow)
+
+	// -- the mined transaction itself
+	_, err = client.SendTransaction(signedTx)
+	assert.ErrorIs(t, err, eth.ErrAlreadyKnown)
+
+	// -- more than the account holds
+	tooMuch := new(big.Int).Mul(big.NewInt(11), big.NewInt(1e18))
+	signedTx, err = client.SignTransaction(&eth.Transaction{From: account.Address, To: to, Value: tooMuch})
+	require.NoError(t, err)
+	_, err = client.SendTransaction(signedTx)
+	assert.ErrorIs(t, err, eth.ErrInsufficientFunds)
+}
+
+func TestClient_ChainIDMismatch(t *testing.T) {
+	t.Setenv("ETH_CHAIN_ID", "31337")
+	t.Setenv("ETH_ACCOUNTS", "main")
+	t.Setenv("ETH_ACCOUNT_MAIN_PRIVATE_KEY", "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	cfg, err := eth.NewConfiguration()
+	require.NoError(t, err)
+
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	_, err = eth.NewGhostClient(cfg.Accounts()[0], cfg, log, eth.WithEthClient(ethtest.NewClient(1)))
+	assert.ErrorContains(t, err, "expected chain ID 31337, got 1")
+}
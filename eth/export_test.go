@@ -0,0 +1,79 @@
+package eth
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGhostClient_ExportTransactions(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	otherKey, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	other := &Account{Address: crypto.PubkeyToAddress(otherKey.PublicKey), ChainId: 1, PrivateKey: otherKey}
+
+	ours, ourReceipts := testSignedBlock(t, acc, 500, 2)
+	ourReceipts[0].EffectiveGasPrice = big.NewInt(GWEI)
+	ourReceipts[1].EffectiveGasPrice = big.NewInt(GWEI)
+	ourReceipts[1].Status = 0
+	theirs, theirReceipts := testSignedBlock(t, other, 501, 1)
+
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("BlockByNumber", mock.Anything, big.NewInt(500)).Return(ours, nil)
+	mockClient.On("BlockReceipts", mock.Anything, mock.Anything).Return(ourReceipts, nil).Once()
+	mockClient.On("BlockByNumber", mock.Anything, big.NewInt(501)).Return(theirs, nil)
+	mockClient.On("BlockReceipts", mock.Anything, mock.Anything).Return(theirReceipts, nil).Once()
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	var buf bytes.Buffer
+	err = gc.ExportTransactions(context.Background(), acc.Address, 500, 501, &buf)
+	assert.NoError(t, err)
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	assert.NoError(t, err)
+	if assert.Len(t, records, 3) {
+		assert.Equal(t, []string{"hash", "block", "timestamp", "from", "to", "value", "gas_used", "fee", "status"}, records[0])
+		assert.Equal(t, []string{
+			ours.Transactions()[0].Hash().Hex(),
+			"500",
+			"2023-11-14T22:21:40Z",
+			acc.Address.Hex(),
+			"0x0000000000000000000000000000000000000002",
+			"0.000000000000000001",
+			"21000",
+			"0.000021",
+			"success",
+		}, records[1])
+		assert.Equal(t, "failed", records[2][8])
+	}
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_ExportTransactions_InvalidRange(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	gc := &ghostClient{
+		client:  &internalmocks.EthClient{},
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	var buf bytes.Buffer
+	assert.Error(t, gc.ExportTransactions(context.Background(), acc.Address, 10, 9, &buf))
+	assert.Zero(t, buf.Len())
+}
@@ -0,0 +1,63 @@
+package eth
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// canFallbackToLegacy reports whether a rejected send of signedTx may be retried as legacy
+func (es *ghostClient) canFallbackToLegacy(signedTx *types.Transaction, err error) bool {
+	return es.feeFallback &&
+		!es.config.RequireEIP1559() &&
+		signedTx.Type() == types.DynamicFeeTxType &&
+		errors.Is(err, ErrTxTypeNotSupported)
+}
+
+// resignAsLegacy re-signs the EIP-1559 transaction signedTx with acc as a legacy transaction
+// with the same nonce, recipient, value, gas limit and data, priced at the suggested gas price.
+// A transaction with an access list is re-signed as an EIP-2930 transaction to keep it. The gas
+// price may not exceed signedTx's fee cap or ETH_MAX_FEE_PER_GAS, whichever is lower, so the
+// fallback never pays more than the caller agreed to. signedTx must have been signed by acc.
+func (es *ghostClient) resignAsLegacy(acc *Account, signedTx *types.Transaction) (*types.Transaction, error) {
+	from, err := types.Sender(es.Signer(), signedTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover sender: %w", err)
+	}
+	if from != acc.Address {
+		return nil, fmt.Errorf("transaction is from %s, not the signing account", from.Hex())
+	}
+
+	gasPrice, err := es.readClient().SuggestGasPrice(es.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas price: %w", err)
+	}
+	limit := signedTx.GasFeeCap()
+	if ceiling := es.config.MaxFeePerGas(); ceiling.Cmp(limit) < 0 {
+		limit = ceiling
+	}
+	if gasPrice.Cmp(limit) > 0 {
+		return nil, fmt.Errorf("suggested gas price %s exceeds the limit of %s", gasPrice, limit)
+	}
+
+	tx := &Transaction{
+		From:       from,
+		Value:      signedTx.Value(),
+		Data:       signedTx.Data(),
+		GasLimit:   signedTx.Gas(),
+		GasPrice:   gasPrice,
+		Nonce:      signedTx.Nonce(),
+		AccessList: signedTx.AccessList(),
+	}
+	if to := signedTx.To(); to != nil {
+		tx.To = *to
+	} else {
+		tx.IsContractCreation = true
+	}
+	ethereumTx, err := es.buildTx(tx)
+	if err != nil {
+		return nil, err
+	}
+	return types.SignTx(ethereumTx, es.Signer(), acc.PrivateKey)
+}
@@ -0,0 +1,141 @@
+package eth
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGhostClient_SendTransaction_FeeFallback(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	dynamicTx := testBroadcastTx(t, acc)
+	isDynamic := mock.MatchedBy(func(tx *types.Transaction) bool { return tx.Type() == types.DynamicFeeTxType })
+	isLegacy := mock.MatchedBy(func(tx *types.Transaction) bool { return tx.Type() == types.LegacyTxType })
+
+	var resent *types.Transaction
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("SendTransaction", mock.Anything, isDynamic).
+		Return(&testRPCError{code: -32000, message: "transaction type not supported"}).Once()
+	mockClient.On("SendTransaction", mock.Anything, isLegacy).
+		Run(func(args mock.Arguments) { resent = args.Get(1).(*types.Transaction) }).
+		Return(nil).Once()
+	mockClient.On("SuggestGasPrice", mock.Anything).Return(big.NewInt(90), nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	WithFeeFallback()(gc)
+
+	receipt, err := gc.SendTransaction(dynamicTx)
+	assert.NoError(t, err)
+	assert.NotNil(t, resent)
+	assert.Equal(t, resent.Hash(), receipt.TxHash)
+	assert.Equal(t, uint8(types.LegacyTxType), receipt.Type)
+
+	assert.Equal(t, dynamicTx.Nonce(), resent.Nonce())
+	assert.Equal(t, dynamicTx.To(), resent.To())
+	assert.Equal(t, dynamicTx.Gas(), resent.Gas())
+	assert.Equal(t, big.NewInt(90), resent.GasPrice())
+	from, err := types.Sender(types.LatestSignerForChainID(big.NewInt(1)), resent)
+	assert.NoError(t, err)
+	assert.Equal(t, acc.Address, from)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_SendTransaction_FeeFallbackDisabled(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	dynamicTx := testBroadcastTx(t, acc)
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("SendTransaction", mock.Anything, dynamicTx).
+		Return(&testRPCError{code: -32000, message: "transaction type not supported"}).Once()
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	_, err := gc.SendTransaction(dynamicTx)
+	assert.ErrorIs(t, err, ErrTxTypeNotSupported)
+	mockClient.AssertNumberOfCalls(t, "SendTransaction", 1)
+
+	// EIP-1559 required: no fallback even with the option
+	t.Setenv("ETH_REQUIRE_EIP1559", "true")
+	WithFeeFallback()(gc)
+	mockClient.On("SendTransaction", mock.Anything, dynamicTx).
+		Return(&testRPCError{code: -32000, message: "transaction type not supported"}).Once()
+	_, err = gc.SendTransaction(dynamicTx)
+	assert.ErrorIs(t, err, ErrTxTypeNotSupported)
+	mockClient.AssertNumberOfCalls(t, "SendTransaction", 2)
+	mockClient.AssertNotCalled(t, "SuggestGasPrice", mock.Anything)
+}
+
+func TestGhostClient_SendTransaction_FeeFallback_AboveFeeCap(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	dynamicTx := testBroadcastTx(t, acc)
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("SendTransaction", mock.Anything, dynamicTx).
+		Return(&testRPCError{code: -32000, message: "transaction type not supported"}).Once()
+	// -- the node wants more than the fee cap of 100 wei the transaction was signed with
+	mockClient.On("SuggestGasPrice", mock.Anything).Return(big.NewInt(101), nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	WithFeeFallback()(gc)
+
+	_, err := gc.SendTransaction(dynamicTx)
+	assert.ErrorContains(t, err, "suggested gas price 101 exceeds the limit of 100")
+	mockClient.AssertNumberOfCalls(t, "SendTransaction", 1)
+}
+
+func TestGhostClient_SendTransaction_FeeFallback_AccessList(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	to := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	accessList := types.AccessList{{Address: to, StorageKeys: []common.Hash{common.HexToHash("0x01")}}}
+	dynamicTx, err := types.SignNewTx(acc.PrivateKey, types.LatestSignerForChainID(big.NewInt(1)), &types.DynamicFeeTx{
+		ChainID: big.NewInt(1), To: &to, Gas: 30000, GasFeeCap: big.NewInt(100), GasTipCap: big.NewInt(1), AccessList: accessList,
+	})
+	assert.NoError(t, err)
+
+	var resent *types.Transaction
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("SendTransaction", mock.Anything, dynamicTx).
+		Return(&testRPCError{code: -32000, message: "transaction type not supported"}).Once()
+	mockClient.On("SendTransaction", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { resent = args.Get(1).(*types.Transaction) }).
+		Return(nil).Once()
+	mockClient.On("SuggestGasPrice", mock.Anything).Return(big.NewInt(90), nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	WithFeeFallback()(gc)
+
+	_, err = gc.SendTransaction(dynamicTx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(types.AccessListTxType), resent.Type())
+	assert.Equal(t, accessList, resent.AccessList())
+	assert.Equal(t, big.NewInt(90), resent.GasPrice())
+	mockClient.AssertExpectations(t)
+}
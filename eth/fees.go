@@ -0,0 +1,539 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/sirupsen/logrus"
+)
+
+// cachedFees holds the last fee reading returned by CurrentFees
+type cachedFees struct {
+	baseFee   *big.Int
+	tip       *big.Int
+	fetchedAt time.Time
+}
+
+// CurrentFees returns the latest block's base fee together with the node's suggested priority fee.
+// The base fee is nil on networks without EIP-1559. Readings are cached for the configured TTL so
+// rapid refreshes (e.g. a UI gas widget) don't hit the RPC on every call.
+func (es *ghostClient) CurrentFees(ctx context.Context) (baseFee, suggestedTip *big.Int, err error) {
+	// -- the lock only guards the cached value; concurrent misses each fetch rather than queue
+	// behind a slow RPC
+	ttl := time.Duration(es.config.FeeCacheTTLSeconds()) * time.Second
+	es.feeCacheMu.Lock()
+	cached := es.feeCache
+	es.feeCacheMu.Unlock()
+	if cached != nil && time.Since(cached.fetchedAt) < ttl {
+		return copyBig(cached.baseFee), copyBig(cached.tip), nil
+	}
+
+	header, err := es.readClient().HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get latest header: %w", err)
+	}
+
+	tip, err := es.readClient().SuggestGasTipCap(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get gas tip suggestion: %w", err)
+	}
+
+	es.feeCacheMu.Lock()
+	es.feeCache = &cachedFees{
+		baseFee:   copyBig(header.BaseFee),
+		tip:       copyBig(tip),
+		fetchedAt: time.Now(),
+	}
+	es.feeCacheMu.Unlock()
+	return copyBig(header.BaseFee), copyBig(tip), nil
+}
+
+// CompareFeeModes returns what tx's gas would cost, in wei and excluding its value, as an EIP-1559
+// transaction and as a legacy one under current conditions. The EIP-1559 cost uses the base fee
+// (from ETH_BASE_FEE_SOURCE) plus the priority fee used when signing, the legacy cost the node's suggested
+// gas price; both use tx's gas limit, or the buffered estimate if it has none. It fails on chains
+// without EIP-1559.
+func (es *ghostClient) CompareFeeModes(ctx context.Context, tx *Transaction) (eip1559Cost, legacyCost *big.Int, err error) {
+	header, err := es.feeHeader(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if header.BaseFee == nil {
+		return nil, nil, errors.New("chain does not support EIP-1559")
+	}
+
+	gasLimit := tx.GasLimit
+	if gasLimit == 0 {
+		estimate := *tx
+		if err := estimate.Validate(); err != nil {
+			return nil, nil, fmt.Errorf("invalid transaction: %w", err)
+		}
+		if err := es.estimateGasAndSetLimit(&estimate); err != nil {
+			return nil, nil, err
+		}
+		gasLimit = estimate.GasLimit
+	}
+	gas := new(big.Int).SetUint64(gasLimit)
+
+	gasPrice, err := es.readClient().SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get gas price: %w", err)
+	}
+
+	price := new(big.Int).Add(header.BaseFee, es.priorityFee(ctx))
+	eip1559Cost = price.Mul(price, gas)
+	legacyCost = new(big.Int).Mul(gasPrice, gas)
+	return eip1559Cost, legacyCost, nil
+}
+
+// MinFeesForNextBlock samples this many recent blocks at this reward percentile
+const (
+	minFeeHistoryBlocks = 20
+	minFeeTipPercentile = 10
+)
+
+// MinFeesForNextBlock returns the smallest EIP-1559 fees likely to be included in the next block,
+// for senders that care about cost rather than speed. The tip is the median of the 10th percentile
+// rewards over the last 20 non-empty blocks, or the node's suggestion if there are none, and
+// maxFee is the next block's base fee, as projected by eth_feeHistory, plus that tip. With no
+// headroom for base fee increases, the transaction may wait if blocks fill up.
+func (es *ghostClient) MinFeesForNextBlock(ctx context.Context) (maxFee, tip *big.Int, err error) {
+	history, err := es.readClient().FeeHistory(ctx, minFeeHistoryBlocks, nil, []float64{minFeeTipPercentile})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get fee history: %w", err)
+	}
+	if len(history.BaseFee) == 0 || history.BaseFee[len(history.BaseFee)-1] == nil || history.BaseFee[len(history.BaseFee)-1].Sign() == 0 {
+		return nil, nil, errors.New("chain does not support EIP-1559 fees")
+	}
+	nextBaseFee := history.BaseFee[len(history.BaseFee)-1]
+
+	if tip = medianReward(history); tip == nil {
+		if tip, err = es.readClient().SuggestGasTipCap(ctx); err != nil {
+			return nil, nil, fmt.Errorf("failed to get gas tip suggestion: %w", err)
+		}
+	}
+
+	maxFee = new(big.Int).Add(nextBaseFee, tip)
+	es.log.WithFields(logrus.Fields{
+		"next_base_fee": nextBaseFee.String(),
+		"tip":           tip.String(),
+		"max_fee":       maxFee.String(),
+	}).Info("Computed minimum fees for next block")
+	return maxFee, tip, nil
+}
+
+// medianReward returns the median of the first requested reward percentile over the non-empty
+// blocks of history, or nil if all of them are empty
+func medianReward(history *ethereum.FeeHistory) *big.Int {
+	var tips []*big.Int
+	for i, reward := range history.Reward {
+		if len(reward) == 0 || (i < len(history.GasUsedRatio) && history.GasUsedRatio[i] == 0) {
+			continue // empty blocks report zero rewards
+		}
+		tips = append(tips, reward[0])
+	}
+	if len(tips) == 0 {
+		return nil
+	}
+	sort.Slice(tips, func(i, j int) bool { return tips[i].Cmp(tips[j]) < 0 })
+	return new(big.Int).Set(tips[len(tips)/2])
+}
+
+// priorityFee returns the priority fee new EIP-1559 transactions get. With ETH_FEE_STRATEGY=history
+// it is the median over the last ETH_FEE_HISTORY_BLOCKS non-empty blocks of the
+// ETH_FEE_HISTORY_PERCENTILE reward; if eth_feeHistory fails or all blocks are empty, and
+// otherwise, it is the fixed per-chain priority fee.
+func (es *ghostClient) priorityFee(ctx context.Context) *big.Int {
+	if es.config.FeeStrategy() != FEE_STRATEGY_HISTORY {
+		return es.getFixedPriorityFee()
+	}
+
+	blocks, percentile := es.config.FeeHistoryBlocks(), es.config.FeeHistoryPercentile()
+	history, err := es.readClient().FeeHistory(ctx, blocks, nil, []float64{percentile})
+	if err != nil {
+		es.log.WithError(err).Warn("Failed to get fee history, using fixed priority fee")
+		return es.getFixedPriorityFee()
+	}
+	tip := medianReward(history)
+	if tip == nil {
+		es.log.Warn("Fee history has no non-empty blocks, using fixed priority fee")
+		return es.getFixedPriorityFee()
+	}
+
+	es.log.WithFields(logrus.Fields{
+		"blocks":     blocks,
+		"percentile": percentile,
+		"tip":        tip.String(),
+	}).Info("Computed priority fee from fee history")
+	return tip
+}
+
+// FeeSpeed is the inclusion speed SuggestFees prices for
+type FeeSpeed int
+
+const (
+	FeeSpeedSlow     FeeSpeed = iota // cheapest; may wait a few blocks
+	FeeSpeedStandard                 // likely in the next few blocks
+	FeeSpeedFast                     // likely in the next block, with room for base fee spikes
+)
+
+// String returns the lowercase name of the speed
+func (s FeeSpeed) String() string {
+	switch s {
+	case FeeSpeedSlow:
+		return "slow"
+	case FeeSpeedStandard:
+		return "standard"
+	case FeeSpeedFast:
+		return "fast"
+	}
+	return fmt.Sprintf("FeeSpeed(%d)", int(s))
+}
+
+// feeTier is how SuggestFees prices a FeeSpeed: the base fee multiplier and reward percentile on
+// EIP-1559 chains, and the share of the node's suggested gas price on legacy ones, in percent
+type feeTier struct {
+	baseFeePercent  int64
+	tipPercentile   float64
+	gasPricePercent int64
+}
+
+var feeTiers = map[FeeSpeed]feeTier{
+	FeeSpeedSlow:     {baseFeePercent: 110, tipPercentile: 10, gasPricePercent: 90},
+	FeeSpeedStandard: {baseFeePercent: 200, tipPercentile: 50, gasPricePercent: 100},
+	FeeSpeedFast:     {baseFeePercent: 300, tipPercentile: 90, gasPricePercent: 125},
+}
+
+// FeeEstimate is a SuggestFees suggestion: MaxFeePerGas and MaxPriorityFeePerGas on EIP-1559
+// chains, or GasPrice on chains without a base fee
+type FeeEstimate struct {
+	Speed                FeeSpeed `json:"speed"`
+	MaxFeePerGas         *big.Int `json:"max_fee_per_gas,omitempty"`
+	MaxPriorityFeePerGas *big.Int `json:"max_priority_fee_per_gas,omitempty"`
+	GasPrice             *big.Int `json:"gas_price,omitempty"`
+}
+
+// SuggestFees suggests fees for the given speed. On EIP-1559 chains the tip is the median over
+// the last ETH_FEE_HISTORY_BLOCKS non-empty blocks of the 10th, 50th or 90th percentile reward
+// (the node's suggestion if all are empty), and the max fee is 1.1, 2 or 3 times the base fee
+// (from ETH_BASE_FEE_SOURCE) plus the tip. On legacy chains the gas price is 90%, 100% or 125% of
+// the node's suggestion. Fees are capped at ETH_MAX_FEE_PER_GAS; ErrBaseFeeAboveCeiling is
+// returned when the capped max fee wouldn't cover the base fee.
+func (es *ghostClient) SuggestFees(speed FeeSpeed) (*FeeEstimate, error) {
+	tier, ok := feeTiers[speed]
+	if !ok {
+		return nil, fmt.Errorf("unknown fee speed %s", speed)
+	}
+	header, err := es.feeHeader(es.ctx)
+	if err != nil {
+		return nil, err
+	}
+	ceiling := es.config.MaxFeePerGas()
+
+	if header.BaseFee == nil {
+		gasPrice, err := es.readClient().SuggestGasPrice(es.ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get gas price: %w", err)
+		}
+		gasPrice = new(big.Int).Mul(gasPrice, big.NewInt(tier.gasPricePercent))
+		gasPrice.Div(gasPrice, big.NewInt(100))
+		if gasPrice.Cmp(ceiling) > 0 {
+			gasPrice.Set(ceiling)
+		}
+		es.log.WithFields(logrus.Fields{
+			"speed":     speed.String(),
+			"gas_price": gasPrice.String(),
+		}).Info("Suggested legacy fees")
+		return &FeeEstimate{Speed: speed, GasPrice: gasPrice}, nil
+	}
+
+	if header.BaseFee.Cmp(ceiling) > 0 {
+		return nil, fmt.Errorf("%w: base fee %s, max fee per gas %s", ErrBaseFeeAboveCeiling, header.BaseFee, ceiling)
+	}
+
+	history, err := es.readClient().FeeHistory(es.ctx, es.config.FeeHistoryBlocks(), nil, []float64{tier.tipPercentile})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fee history: %w", err)
+	}
+	tip := medianReward(history)
+	if tip == nil {
+		if tip, err = es.readClient().SuggestGasTipCap(es.ctx); err != nil {
+			return nil, fmt.Errorf("failed to get gas tip suggestion: %w", err)
+		}
+	}
+
+	maxFee := new(big.Int).Mul(header.BaseFee, big.NewInt(tier.baseFeePercent))
+	maxFee.Div(maxFee, big.NewInt(100))
+	maxFee.Add(maxFee, tip)
+	if maxFee.Cmp(ceiling) > 0 {
+		maxFee.Set(ceiling)
+	}
+	if tip.Cmp(maxFee) > 0 {
+		tip = new(big.Int).Set(maxFee)
+	}
+
+	es.log.WithFields(logrus.Fields{
+		"speed":    speed.String(),
+		"base_fee": header.BaseFee.String(),
+		"tip":      tip.String(),
+		"max_fee":  maxFee.String(),
+	}).Info("Suggested EIP-1559 fees")
+	return &FeeEstimate{Speed: speed, MaxFeePerGas: maxFee, MaxPriorityFeePerGas: tip}, nil
+}
+
+// FeeCompetitiveness returns the fraction, from 0 to 1, of recent blocks that would have included
+// the pending transaction hash at its current fees, to tell whether it needs a bump. It samples
+// the same 20 blocks at the 10th reward percentile as MinFeesForNextBlock: a block counts when
+// the transaction's fee cap covers its base fee and the resulting tip reaches the percentile, or
+// for empty blocks, when the fee cap covers the base fee alone.
+func (es *ghostClient) FeeCompetitiveness(ctx context.Context, hash common.Hash) (float64, error) {
+	tx, isPending, err := es.readClient().TransactionByHash(ctx, hash)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get transaction %s: %w", hash.Hex(), err)
+	}
+	if !isPending {
+		return 0, fmt.Errorf("transaction %s is already mined", hash.Hex())
+	}
+
+	history, err := es.readClient().FeeHistory(ctx, minFeeHistoryBlocks, nil, []float64{minFeeTipPercentile})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get fee history: %w", err)
+	}
+	blocks := len(history.GasUsedRatio)
+	if blocks == 0 {
+		return 0, errors.New("fee history returned no blocks")
+	}
+
+	included := 0
+	for i := 0; i < blocks; i++ {
+		baseFee := new(big.Int)
+		if i < len(history.BaseFee) && history.BaseFee[i] != nil {
+			baseFee = history.BaseFee[i]
+		}
+		if tx.GasFeeCap().Cmp(baseFee) < 0 {
+			continue
+		}
+		if history.GasUsedRatio[i] == 0 || i >= len(history.Reward) || len(history.Reward[i]) == 0 {
+			included++
+			continue
+		}
+		if tx.EffectiveGasTipValue(baseFee).Cmp(history.Reward[i][0]) >= 0 {
+			included++
+		}
+	}
+
+	score := float64(included) / float64(blocks)
+	es.log.WithFields(logrus.Fields{
+		"hash":     hash.Hex(),
+		"included": included,
+		"blocks":   blocks,
+	}).Info("Computed fee competitiveness")
+	return score, nil
+}
+
+// Minimum fee increases, in percent, the geth transaction pool requires to replace a pending
+// transaction; blob transactions need their fees doubled
+const (
+	replacementPriceBump     = 10
+	blobReplacementPriceBump = 100
+)
+
+// IsReplacementUnderpriced reports whether a node would reject newTx as a replacement for the
+// pending oldTx with "replacement transaction underpriced". Both the fee cap and the tip (for
+// legacy transactions, the gas price for both) must rise by at least 10% over oldTx, or 100% when
+// replacing a blob transaction, which also applies to the blob fee cap. It returns false when
+// newTx is not a replacement, i.e. from another sender or nonce.
+func (es *ghostClient) IsReplacementUnderpriced(ctx context.Context, oldTx, newTx *types.Transaction) bool {
+	if oldTx.Nonce() != newTx.Nonce() {
+		return false
+	}
+	signer := es.Signer()
+	oldFrom, oldErr := types.Sender(signer, oldTx)
+	newFrom, newErr := types.Sender(signer, newTx)
+	if oldErr == nil && newErr == nil && oldFrom != newFrom {
+		return false
+	}
+
+	bump := int64(replacementPriceBump)
+	if oldTx.Type() == types.BlobTxType {
+		bump = blobReplacementPriceBump
+		if newTx.Type() != types.BlobTxType || !bumpedEnough(oldTx.BlobGasFeeCap(), newTx.BlobGasFeeCap(), bump) {
+			return true
+		}
+	}
+	return !bumpedEnough(oldTx.GasFeeCap(), newTx.GasFeeCap(), bump) ||
+		!bumpedEnough(oldTx.GasTipCap(), newTx.GasTipCap(), bump)
+}
+
+// bumpedEnough reports whether next is above old and at least bump percent higher
+func bumpedEnough(old, next *big.Int, bump int64) bool {
+	if next.Cmp(old) <= 0 {
+		return false
+	}
+	threshold := new(big.Int).Mul(old, big.NewInt(100+bump))
+	threshold.Div(threshold, big.NewInt(100))
+	return next.Cmp(threshold) >= 0
+}
+
+// maxFeeHistoryBlocks is the largest window eth_feeHistory serves in one call on common nodes
+const maxFeeHistoryBlocks = 1024
+
+// cachedGasStats holds the last GasStats computed for a window size
+type cachedGasStats struct {
+	stats     *GasStats
+	fetchedAt time.Time
+}
+
+// GasStats returns min/max/average base fee and gas-used ratio over the last lastNBlocks blocks,
+// built from eth_feeHistory. Results are cached per window size for the fee cache TTL.
+func (es *ghostClient) GasStats(ctx context.Context, lastNBlocks int) (*GasStats, error) {
+	if lastNBlocks <= 0 || lastNBlocks > maxFeeHistoryBlocks {
+		return nil, fmt.Errorf("block count must be between 1 and %d, got %d", maxFeeHistoryBlocks, lastNBlocks)
+	}
+
+	ttl := time.Duration(es.config.FeeCacheTTLSeconds()) * time.Second
+	es.feeCacheMu.Lock()
+	cached, ok := es.gasStatsCache[lastNBlocks]
+	es.feeCacheMu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < ttl {
+		return copyGasStats(cached.stats), nil
+	}
+
+	history, err := es.readClient().FeeHistory(ctx, uint64(lastNBlocks), nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fee history: %w", err)
+	}
+
+	// BaseFee carries one extra entry for the block after the window; only GasUsedRatio's length counts
+	blocks := len(history.GasUsedRatio)
+	if blocks == 0 || len(history.BaseFee) < blocks {
+		return nil, fmt.Errorf("fee history returned no usable blocks")
+	}
+
+	stats := &GasStats{
+		OldestBlock:     history.OldestBlock.Uint64(),
+		Blocks:          blocks,
+		MinBaseFee:      new(big.Int).Set(history.BaseFee[0]),
+		MaxBaseFee:      new(big.Int).Set(history.BaseFee[0]),
+		MinGasUsedRatio: history.GasUsedRatio[0],
+		MaxGasUsedRatio: history.GasUsedRatio[0],
+	}
+	sumBaseFee := new(big.Int)
+	var sumRatio float64
+	for i := 0; i < blocks; i++ {
+		baseFee, ratio := history.BaseFee[i], history.GasUsedRatio[i]
+		if baseFee.Cmp(stats.MinBaseFee) < 0 {
+			stats.MinBaseFee.Set(baseFee)
+		}
+		if baseFee.Cmp(stats.MaxBaseFee) > 0 {
+			stats.MaxBaseFee.Set(baseFee)
+		}
+		stats.MinGasUsedRatio = min(stats.MinGasUsedRatio, ratio)
+		stats.MaxGasUsedRatio = max(stats.MaxGasUsedRatio, ratio)
+		sumBaseFee.Add(sumBaseFee, baseFee)
+		sumRatio += ratio
+	}
+	stats.AvgBaseFee = sumBaseFee.Div(sumBaseFee, big.NewInt(int64(blocks)))
+	stats.AvgGasUsedRatio = sumRatio / float64(blocks)
+
+	es.feeCacheMu.Lock()
+	if es.gasStatsCache == nil {
+		es.gasStatsCache = make(map[int]*cachedGasStats)
+	}
+	es.gasStatsCache[lastNBlocks] = &cachedGasStats{stats: stats, fetchedAt: time.Now()}
+	es.feeCacheMu.Unlock()
+	return copyGasStats(stats), nil
+}
+
+// copyGasStats returns a copy of s so cached values can't be mutated by callers
+func copyGasStats(s *GasStats) *GasStats {
+	c := *s
+	c.MinBaseFee = copyBig(s.MinBaseFee)
+	c.MaxBaseFee = copyBig(s.MaxBaseFee)
+	c.AvgBaseFee = copyBig(s.AvgBaseFee)
+	return &c
+}
+
+// copyBig returns a copy of v so cached values can't be mutated by callers
+func copyBig(v *big.Int) *big.Int {
+	if v == nil {
+		return nil
+	}
+	return new(big.Int).Set(v)
+}
+
+// feeHeader returns the header whose base fee the fee calculation should use, per the configured
+// base fee source. For the next-block source it is the latest header with its base fee replaced
+// by the projected one.
+func (es *ghostClient) feeHeader(ctx context.Context) (*types.Header, error) {
+	source := es.config.BaseFeeSource()
+	switch source {
+	case BASE_FEE_SOURCE_PENDING:
+		header, err := es.readClient().HeaderByNumber(ctx, big.NewInt(int64(rpc.PendingBlockNumber)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pending header: %w", err)
+		}
+		return header, nil
+	case BASE_FEE_SOURCE_NEXT:
+		header, err := es.readClient().HeaderByNumber(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get latest header: %w", err)
+		}
+		if header.BaseFee == nil {
+			return header, nil
+		}
+		projected := types.CopyHeader(header)
+		projected.BaseFee = projectNextBaseFee(es.chainId, header)
+		return projected, nil
+	default:
+		header, err := es.readClient().HeaderByNumber(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get latest header: %w", err)
+		}
+		return header, nil
+	}
+}
+
+// projectNextBaseFee applies the EIP-1559 base fee update rule to parent. Base uses the OP Stack
+// elasticity and change denominator; every other chain uses the mainnet parameters.
+func projectNextBaseFee(chainId int64, parent *types.Header) *big.Int {
+	elasticity, denominator := uint64(2), uint64(8)
+	if chainId == 8453 { // Base
+		elasticity, denominator = 6, 250
+	}
+
+	baseFee := new(big.Int).Set(parent.BaseFee)
+	target := parent.GasLimit / elasticity
+	if target == 0 || parent.GasUsed == target {
+		return baseFee
+	}
+
+	if parent.GasUsed > target {
+		delta := new(big.Int).SetUint64(parent.GasUsed - target)
+		delta.Mul(delta, parent.BaseFee)
+		delta.Div(delta, new(big.Int).SetUint64(target))
+		delta.Div(delta, new(big.Int).SetUint64(denominator))
+		if delta.Sign() == 0 {
+			delta.SetInt64(1)
+		}
+		return baseFee.Add(baseFee, delta)
+	}
+
+	delta := new(big.Int).SetUint64(target - parent.GasUsed)
+	delta.Mul(delta, parent.BaseFee)
+	delta.Div(delta, new(big.Int).SetUint64(target))
+	delta.Div(delta, new(big.Int).SetUint64(denominator))
+	baseFee.Sub(baseFee, delta)
+	if baseFee.Sign() < 0 {
+		baseFee.SetInt64(0)
+	}
+	return baseFee
+}
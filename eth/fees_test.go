@@ -0,0 +1,573 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/holiman/uint256"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGhostClient_CurrentFees_Cached(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	header := &types.Header{BaseFee: big.NewInt(30 * GWEI)}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(header, nil).Once()
+	mockClient.On("SuggestGasTipCap", mock.Anything).Return(big.NewInt(2*GWEI), nil).Once()
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	baseFee, tip, err := gc.CurrentFees(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(30*GWEI), baseFee)
+	assert.Equal(t, big.NewInt(2*GWEI), tip)
+
+	// second call within the TTL is served from the cache
+	baseFee, tip, err = gc.CurrentFees(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(30*GWEI), baseFee)
+	assert.Equal(t, big.NewInt(2*GWEI), tip)
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNumberOfCalls(t, "HeaderByNumber", 1)
+	mockClient.AssertNumberOfCalls(t, "SuggestGasTipCap", 1)
+}
+
+func TestGhostClient_CurrentFees_Error(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	header := &types.Header{BaseFee: big.NewInt(30 * GWEI)}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(header, nil)
+	mockClient.On("SuggestGasTipCap", mock.Anything).Return(nil, errors.New("fail tip"))
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	_, _, err := gc.CurrentFees(context.Background())
+	assert.Error(t, err)
+	// failed readings are not cached
+	assert.Nil(t, gc.feeCache)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_CalculateOptimalFees_PendingBaseFee(t *testing.T) {
+	t.Setenv("ETH_BASE_FEE_SOURCE", "pending")
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	pending := &types.Header{GasLimit: 30000000, BaseFee: big.NewInt(200)}
+	mockClient.On("HeaderByNumber", mock.Anything, big.NewInt(int64(rpc.PendingBlockNumber))).Return(pending, nil).Once()
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	tx := &Transaction{}
+	assert.NoError(t, gc.calculateOptimalFees(tx))
+	want := new(big.Int).Add(big.NewInt(400), cfg.PriorityFeeMainnet())
+	assert.Equal(t, want, tx.MaxFeePerGas)
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNotCalled(t, "HeaderByNumber", mock.Anything, (*big.Int)(nil))
+}
+
+func TestGhostClient_CalculateOptimalFees_NextBaseFee(t *testing.T) {
+	t.Setenv("ETH_BASE_FEE_SOURCE", "next")
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	// a full block raises the base fee by 12.5%
+	latest := &types.Header{GasLimit: 30000000, GasUsed: 30000000, BaseFee: big.NewInt(800)}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(latest, nil).Once()
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	tx := &Transaction{}
+	assert.NoError(t, gc.calculateOptimalFees(tx))
+	want := new(big.Int).Add(big.NewInt(1800), cfg.PriorityFeeMainnet())
+	assert.Equal(t, want, tx.MaxFeePerGas)
+	assert.Equal(t, big.NewInt(800), latest.BaseFee) // the fetched header is not modified
+	mockClient.AssertExpectations(t)
+}
+
+func TestProjectNextBaseFee(t *testing.T) {
+	header := func(used uint64) *types.Header {
+		return &types.Header{GasLimit: 30000000, GasUsed: used, BaseFee: big.NewInt(1000000000)}
+	}
+	assert.Equal(t, big.NewInt(1000000000), projectNextBaseFee(1, header(15000000)))
+	assert.Equal(t, big.NewInt(1125000000), projectNextBaseFee(1, header(30000000)))
+	assert.Equal(t, big.NewInt(875000000), projectNextBaseFee(1, header(0)))
+	// Base targets a sixth of the limit and moves at most 2% per block
+	assert.Equal(t, big.NewInt(1020000000), projectNextBaseFee(8453, header(30000000)))
+}
+
+func TestGhostClient_GasStats(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	history := &ethereum.FeeHistory{
+		OldestBlock:  big.NewInt(100),
+		BaseFee:      []*big.Int{big.NewInt(10 * GWEI), big.NewInt(12 * GWEI), big.NewInt(8 * GWEI), big.NewInt(50 * GWEI)}, // last entry is the next block
+		GasUsedRatio: []float64{0.5, 0.9, 0.1},
+	}
+	mockClient.On("FeeHistory", mock.Anything, uint64(3), (*big.Int)(nil), []float64(nil)).Return(history, nil).Once()
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	stats, err := gc.GasStats(context.Background(), 3)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(100), stats.OldestBlock)
+	assert.Equal(t, 3, stats.Blocks)
+	assert.Equal(t, big.NewInt(8*GWEI), stats.MinBaseFee)
+	assert.Equal(t, big.NewInt(12*GWEI), stats.MaxBaseFee)
+	assert.Equal(t, big.NewInt(10*GWEI), stats.AvgBaseFee)
+	assert.Equal(t, 0.1, stats.MinGasUsedRatio)
+	assert.Equal(t, 0.9, stats.MaxGasUsedRatio)
+	assert.InDelta(t, 0.5, stats.AvgGasUsedRatio, 1e-9)
+
+	// served from cache, and cached values can't be mutated through the result
+	stats.MinBaseFee.SetInt64(0)
+	again, err := gc.GasStats(context.Background(), 3)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(8*GWEI), again.MinBaseFee)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_GasStats_InvalidWindow(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	gc := &ghostClient{
+		client:  &internalmocks.EthClient{},
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	_, err := gc.GasStats(context.Background(), 0)
+	assert.Error(t, err)
+	_, err = gc.GasStats(context.Background(), 2048)
+	assert.Error(t, err)
+}
+
+func TestGhostClient_CompareFeeModes(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{GasLimit: 30000000, BaseFee: big.NewInt(20 * GWEI)}, nil)
+	mockClient.On("SuggestGasPrice", mock.Anything).Return(big.NewInt(25*GWEI), nil)
+	mockClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(50000), nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	// preset gas limit: 21000 * (20 + 2 gwei tip) vs 21000 * 25 gwei
+	tx := &Transaction{From: acc.Address, To: acc.Address, Value: big.NewInt(1), GasLimit: 21000}
+	eip1559Cost, legacyCost, err := gc.CompareFeeModes(context.Background(), tx)
+	assert.NoError(t, err)
+	assert.Equal(t, new(big.Int).Mul(big.NewInt(21000), big.NewInt(22*GWEI)), eip1559Cost)
+	assert.Equal(t, new(big.Int).Mul(big.NewInt(21000), big.NewInt(25*GWEI)), legacyCost)
+	mockClient.AssertNotCalled(t, "EstimateGas", mock.Anything, mock.Anything)
+
+	// estimated gas limit with the complex buffer, 50000 * 1.2; tx itself is left untouched
+	call := &Transaction{From: acc.Address, To: acc.Address, Data: []byte{0x01}}
+	eip1559Cost, legacyCost, err = gc.CompareFeeModes(context.Background(), call)
+	assert.NoError(t, err)
+	assert.Equal(t, new(big.Int).Mul(big.NewInt(60000), big.NewInt(22*GWEI)), eip1559Cost)
+	assert.Equal(t, new(big.Int).Mul(big.NewInt(60000), big.NewInt(25*GWEI)), legacyCost)
+	assert.Zero(t, call.GasLimit)
+}
+
+func TestGhostClient_CompareFeeModes_NoEIP1559(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{GasLimit: 30000000}, nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	_, _, err := gc.CompareFeeModes(context.Background(), &Transaction{From: acc.Address, To: acc.Address, GasLimit: 21000})
+	assert.ErrorContains(t, err, "does not support EIP-1559")
+}
+
+func TestGhostClient_IsReplacementUnderpriced(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	gc := &ghostClient{
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	to := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	sign := func(data types.TxData) *types.Transaction {
+		tx, err := types.SignNewTx(acc.PrivateKey, gc.Signer(), data)
+		assert.NoError(t, err)
+		return tx
+	}
+	legacy := func(nonce uint64, price int64) *types.Transaction {
+		return sign(&types.LegacyTx{Nonce: nonce, To: &to, Gas: 21000, GasPrice: big.NewInt(price)})
+	}
+	dynamic := func(feeCap, tip int64) *types.Transaction {
+		return sign(&types.DynamicFeeTx{ChainID: big.NewInt(1), Nonce: 5, To: &to, Gas: 21000, GasFeeCap: big.NewInt(feeCap), GasTipCap: big.NewInt(tip)})
+	}
+	blob := func(feeCap, tip, blobFeeCap uint64) *types.Transaction {
+		return sign(&types.BlobTx{
+			ChainID:    uint256.NewInt(1),
+			Nonce:      5,
+			To:         to,
+			Gas:        21000,
+			GasFeeCap:  uint256.NewInt(feeCap),
+			GasTipCap:  uint256.NewInt(tip),
+			BlobFeeCap: uint256.NewInt(blobFeeCap),
+			BlobHashes: []common.Hash{{0x01}},
+		})
+	}
+
+	tests := []struct {
+		name        string
+		old, new    *types.Transaction
+		underpriced bool
+	}{
+		{"legacy bumped 10%", legacy(5, 10*GWEI), legacy(5, 11*GWEI), false},
+		{"legacy bumped 5%", legacy(5, 10*GWEI), legacy(5, 105*GWEI/10), true},
+		{"legacy same price", legacy(5, 10*GWEI), legacy(5, 10*GWEI), true},
+		{"1559 both bumped", dynamic(100*GWEI, 2*GWEI), dynamic(110*GWEI, 22*GWEI/10), false},
+		{"1559 tip not bumped", dynamic(100*GWEI, 2*GWEI), dynamic(120*GWEI, 2*GWEI), true},
+		{"1559 fee cap not bumped", dynamic(100*GWEI, 2*GWEI), dynamic(105*GWEI, 3*GWEI), true},
+		{"legacy to 1559", legacy(5, 10*GWEI), dynamic(11*GWEI, 11*GWEI), false},
+		{"legacy to 1559 with low tip", legacy(5, 10*GWEI), dynamic(20*GWEI, 1*GWEI), true},
+		{"blob doubled", blob(100, 2, 10), blob(200, 4, 20), false},
+		{"blob fee cap not doubled", blob(100, 2, 10), blob(200, 4, 15), true},
+		{"blob fees bumped 10%", blob(100, 2, 10), blob(110, 3, 20), true},
+		{"different nonce", legacy(5, 10*GWEI), legacy(6, 1), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.underpriced, gc.IsReplacementUnderpriced(context.Background(), tt.old, tt.new))
+		})
+	}
+}
+
+func TestGhostClient_MinFeesForNextBlock(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("FeeHistory", mock.Anything, uint64(20), (*big.Int)(nil), []float64{10}).Return(&ethereum.FeeHistory{
+		OldestBlock: big.NewInt(100),
+		Reward: [][]*big.Int{
+			{big.NewInt(3 * GWEI)},
+			{big.NewInt(0)}, // empty block
+			{big.NewInt(1 * GWEI)},
+			{big.NewInt(2 * GWEI)},
+		},
+		BaseFee:      []*big.Int{big.NewInt(20 * GWEI), big.NewInt(22 * GWEI), big.NewInt(21 * GWEI), big.NewInt(24 * GWEI), big.NewInt(30 * GWEI)},
+		GasUsedRatio: []float64{0.9, 0, 0.7, 0.99},
+	}, nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	maxFee, tip, err := gc.MinFeesForNextBlock(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(2*GWEI), tip)     // median of 1, 2 and 3 gwei
+	assert.Equal(t, big.NewInt(32*GWEI), maxFee) // next base fee 30 gwei plus the tip
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNotCalled(t, "SuggestGasTipCap", mock.Anything)
+}
+
+func TestGhostClient_FeeCompetitiveness(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	history := &ethereum.FeeHistory{
+		OldestBlock: big.NewInt(100),
+		Reward: [][]*big.Int{
+			{big.NewInt(3 * GWEI)},
+			{big.NewInt(0)}, // empty block
+			{big.NewInt(1 * GWEI)},
+			{big.NewInt(2 * GWEI)},
+		},
+		BaseFee:      []*big.Int{big.NewInt(20 * GWEI), big.NewInt(22 * GWEI), big.NewInt(21 * GWEI), big.NewInt(24 * GWEI), big.NewInt(30 * GWEI)},
+		GasUsedRatio: []float64{0.9, 0, 0.7, 0.99},
+	}
+
+	for _, tc := range []struct {
+		name   string
+		feeCap int64
+		tip    int64
+		want   float64
+	}{
+		// -- tip 2 misses block 0's 3 gwei; block 3's base fee of 24 leaves only 1 gwei of tip
+		{"some blocks", 25, 2, 0.5},
+		{"every block", 40, 3, 1},
+		{"below every base fee", 15, 2, 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tx := types.NewTx(&types.DynamicFeeTx{
+				ChainID:   big.NewInt(1),
+				GasFeeCap: big.NewInt(tc.feeCap * GWEI),
+				GasTipCap: big.NewInt(tc.tip * GWEI),
+				Gas:       21000,
+			})
+			mockClient := &internalmocks.EthClient{}
+			mockClient.On("TransactionByHash", mock.Anything, tx.Hash()).Return(tx, true, nil)
+			mockClient.On("FeeHistory", mock.Anything, uint64(20), (*big.Int)(nil), []float64{10}).Return(history, nil)
+			gc := &ghostClient{
+				client:  mockClient,
+				ctx:     context.Background(),
+				chainId: 1,
+				account: acc,
+				config:  cfg,
+				log:     newTestLogger(),
+			}
+
+			score, err := gc.FeeCompetitiveness(context.Background(), tx.Hash())
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, score)
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGhostClient_FeeCompetitiveness_Mined(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	tx := testBroadcastTx(t, acc)
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("TransactionByHash", mock.Anything, tx.Hash()).Return(tx, false, nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	_, err := gc.FeeCompetitiveness(context.Background(), tx.Hash())
+	assert.ErrorContains(t, err, "already mined")
+	mockClient.AssertNotCalled(t, "FeeHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGhostClient_MinFeesForNextBlock_EmptyBlocks(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("FeeHistory", mock.Anything, uint64(20), (*big.Int)(nil), []float64{10}).Return(&ethereum.FeeHistory{
+		OldestBlock:  big.NewInt(100),
+		Reward:       [][]*big.Int{{big.NewInt(0)}, {big.NewInt(0)}},
+		BaseFee:      []*big.Int{big.NewInt(8 * GWEI), big.NewInt(7 * GWEI), big.NewInt(7 * GWEI)},
+		GasUsedRatio: []float64{0, 0},
+	}, nil)
+	mockClient.On("SuggestGasTipCap", mock.Anything).Return(big.NewInt(GWEI/10), nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	maxFee, tip, err := gc.MinFeesForNextBlock(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(GWEI/10), tip)
+	assert.Equal(t, big.NewInt(7*GWEI+GWEI/10), maxFee)
+
+	// Legacy chains have no base fee to project
+	mockClient = &internalmocks.EthClient{}
+	mockClient.On("FeeHistory", mock.Anything, uint64(20), (*big.Int)(nil), []float64{10}).Return(&ethereum.FeeHistory{
+		OldestBlock:  big.NewInt(100),
+		BaseFee:      []*big.Int{big.NewInt(0), big.NewInt(0)},
+		GasUsedRatio: []float64{0.5},
+	}, nil)
+	gc.client = mockClient
+	_, _, err = gc.MinFeesForNextBlock(context.Background())
+	assert.ErrorContains(t, err, "does not support EIP-1559")
+}
+
+func TestGhostClient_CalculateOptimalFees_HistoryStrategy(t *testing.T) {
+	t.Setenv("ETH_FEE_STRATEGY", "history")
+	t.Setenv("ETH_FEE_HISTORY_BLOCKS", "4")
+	t.Setenv("ETH_FEE_HISTORY_PERCENTILE", "60")
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{BaseFee: big.NewInt(10 * GWEI)}, nil)
+	mockClient.On("FeeHistory", mock.Anything, uint64(4), (*big.Int)(nil), []float64{60}).Return(&ethereum.FeeHistory{
+		OldestBlock: big.NewInt(100),
+		Reward: [][]*big.Int{
+			{big.NewInt(5 * GWEI)},
+			{big.NewInt(0)}, // empty block
+			{big.NewInt(1 * GWEI)},
+			{big.NewInt(3 * GWEI)},
+		},
+		BaseFee:      []*big.Int{big.NewInt(10 * GWEI), big.NewInt(10 * GWEI), big.NewInt(10 * GWEI), big.NewInt(10 * GWEI), big.NewInt(10 * GWEI)},
+		GasUsedRatio: []float64{0.9, 0, 0.5, 0.6},
+	}, nil).Once()
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	tx := &Transaction{}
+	assert.NoError(t, gc.calculateOptimalFees(tx))
+	assert.Equal(t, big.NewInt(3*GWEI), tx.MaxPriorityFeePerGas) // median of 1, 3 and 5 gwei
+	assert.Equal(t, big.NewInt(23*GWEI), tx.MaxFeePerGas)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_CalculateOptimalFees_HistoryStrategy_FallsBack(t *testing.T) {
+	t.Setenv("ETH_FEE_STRATEGY", "history")
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{BaseFee: big.NewInt(10 * GWEI)}, nil)
+	mockClient.On("FeeHistory", mock.Anything, uint64(20), (*big.Int)(nil), []float64{50}).Return(nil, errors.New("method not found")).Once()
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	tx := &Transaction{}
+	assert.NoError(t, gc.calculateOptimalFees(tx))
+	assert.Equal(t, big.NewInt(DEFAULT_PRIORITY_FEE_MAINNET), tx.MaxPriorityFeePerGas)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_SuggestFees(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	history := func(gwei ...int64) *ethereum.FeeHistory {
+		h := &ethereum.FeeHistory{OldestBlock: big.NewInt(100)}
+		for _, g := range gwei {
+			h.Reward = append(h.Reward, []*big.Int{big.NewInt(g * GWEI)})
+			h.BaseFee = append(h.BaseFee, big.NewInt(10*GWEI))
+			h.GasUsedRatio = append(h.GasUsedRatio, 0.5)
+		}
+		return h
+	}
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{BaseFee: big.NewInt(10 * GWEI)}, nil)
+	mockClient.On("FeeHistory", mock.Anything, uint64(20), (*big.Int)(nil), []float64{10}).Return(history(1, 1, 2), nil)
+	mockClient.On("FeeHistory", mock.Anything, uint64(20), (*big.Int)(nil), []float64{50}).Return(history(2, 3, 4), nil)
+	mockClient.On("FeeHistory", mock.Anything, uint64(20), (*big.Int)(nil), []float64{90}).Return(history(5, 6, 8), nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	tests := []struct {
+		speed  FeeSpeed
+		maxFee int64
+		tip    int64
+	}{
+		{speed: FeeSpeedSlow, maxFee: 12 * GWEI, tip: 1 * GWEI},     // 1.1x base fee
+		{speed: FeeSpeedStandard, maxFee: 23 * GWEI, tip: 3 * GWEI}, // 2x base fee
+		{speed: FeeSpeedFast, maxFee: 36 * GWEI, tip: 6 * GWEI},     // 3x base fee
+	}
+	for _, tt := range tests {
+		t.Run(tt.speed.String(), func(t *testing.T) {
+			estimate, err := gc.SuggestFees(tt.speed)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.speed, estimate.Speed)
+			assert.Equal(t, big.NewInt(tt.maxFee), estimate.MaxFeePerGas)
+			assert.Equal(t, big.NewInt(tt.tip), estimate.MaxPriorityFeePerGas)
+			assert.Nil(t, estimate.GasPrice)
+		})
+	}
+
+	_, err := gc.SuggestFees(FeeSpeed(7))
+	assert.ErrorContains(t, err, "unknown fee speed FeeSpeed(7)")
+}
+
+func TestGhostClient_SuggestFees_BaseFeeAboveCeiling(t *testing.T) {
+	t.Setenv("ETH_MAX_FEE_PER_GAS", "9000000000")
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{BaseFee: big.NewInt(10 * GWEI)}, nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	// -- a max fee capped at 9 gwei could never be included at a 10 gwei base fee
+	_, err := gc.SuggestFees(FeeSpeedStandard)
+	assert.ErrorIs(t, err, ErrBaseFeeAboveCeiling)
+	mockClient.AssertNotCalled(t, "FeeHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGhostClient_SuggestFees_Legacy(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{}, nil)
+	mockClient.On("SuggestGasPrice", mock.Anything).Return(big.NewInt(20*GWEI), nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 56,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	slow, err := gc.SuggestFees(FeeSpeedSlow)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(18*GWEI), slow.GasPrice)
+	assert.Nil(t, slow.MaxFeePerGas)
+	fast, err := gc.SuggestFees(FeeSpeedFast)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(25*GWEI), fast.GasPrice)
+	mockClient.AssertNotCalled(t, "FeeHistory", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,62 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/sirupsen/logrus"
+)
+
+// EstimateTimeToFinality estimates how long until the block of the mined transaction hash is
+// finalized, or zero if it already is. The estimate is the gap between its block and the finalized
+// block, rounded up to whole epochs on chains that finalize per epoch (e.g. 32 blocks on
+// Ethereum), at the chain's block time from the built-in registry. For chains outside the registry
+// the block time is measured between the finalized block and the transaction's block.
+func (es *ghostClient) EstimateTimeToFinality(ctx context.Context, hash common.Hash) (time.Duration, error) {
+	receipt, err := es.readClient().TransactionReceipt(ctx, hash)
+	if err != nil {
+		return 0, fmt.Errorf("transaction %s not found or pending: %w", hash.Hex(), err)
+	}
+	finalized, err := es.readClient().HeaderByNumber(ctx, big.NewInt(int64(rpc.FinalizedBlockNumber)))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get finalized block: %w", err)
+	}
+
+	block, finalizedBlock := receipt.BlockNumber.Uint64(), finalized.Number.Uint64()
+	if block <= finalizedBlock {
+		return 0, nil
+	}
+	gap := block - finalizedBlock
+
+	info, _ := LookupChain(es.chainId)
+	blockTime := info.BlockTime
+	if blockTime == 0 {
+		header, err := es.readClient().HeaderByNumber(ctx, receipt.BlockNumber)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get block %d: %w", block, err)
+		}
+		if header.Time <= finalized.Time {
+			return 0, errors.New("cannot measure block time: block timestamps don't advance")
+		}
+		blockTime = time.Duration(header.Time-finalized.Time) * time.Second / time.Duration(gap)
+	}
+
+	blocks := gap
+	if epoch := info.EpochBlocks; epoch > 1 {
+		blocks = (gap + epoch - 1) / epoch * epoch
+	}
+	eta := time.Duration(blocks) * blockTime
+
+	es.log.WithFields(logrus.Fields{
+		"hash":            hash.Hex(),
+		"block":           block,
+		"finalized_block": finalizedBlock,
+		"eta":             eta.String(),
+	}).Info("Estimated time to finality")
+	return eta, nil
+}
@@ -0,0 +1,93 @@
+package eth
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGhostClient_EstimateTimeToFinality(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	finalized := big.NewInt(int64(rpc.FinalizedBlockNumber))
+	tests := []struct {
+		name           string
+		block          int64
+		finalizedBlock int64
+		want           time.Duration
+	}{
+		{name: "already finalized", block: 950, finalizedBlock: 960, want: 0},
+		{name: "finalized block itself", block: 960, finalizedBlock: 960, want: 0},
+		{name: "within one epoch", block: 970, finalizedBlock: 960, want: 32 * 12 * time.Second},
+		{name: "two epochs", block: 1000, finalizedBlock: 960, want: 64 * 12 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hash := common.HexToHash("0xabc")
+			mockClient := &internalmocks.EthClient{}
+			mockClient.On("TransactionReceipt", mock.Anything, hash).Return(&types.Receipt{TxHash: hash, BlockNumber: big.NewInt(tt.block)}, nil)
+			mockClient.On("HeaderByNumber", mock.Anything, finalized).Return(&types.Header{Number: big.NewInt(tt.finalizedBlock)}, nil)
+			gc := &ghostClient{
+				client:  mockClient,
+				ctx:     context.Background(),
+				chainId: 1,
+				account: acc,
+				config:  cfg,
+				log:     newTestLogger(),
+			}
+
+			eta, err := gc.EstimateTimeToFinality(context.Background(), hash)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, eta)
+		})
+	}
+}
+
+func TestGhostClient_EstimateTimeToFinality_MeasuredBlockTime(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	hash := common.HexToHash("0xabc")
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("TransactionReceipt", mock.Anything, hash).Return(&types.Receipt{TxHash: hash, BlockNumber: big.NewInt(1100)}, nil)
+	mockClient.On("HeaderByNumber", mock.Anything, big.NewInt(int64(rpc.FinalizedBlockNumber))).Return(&types.Header{Number: big.NewInt(1000), Time: 10000}, nil)
+	mockClient.On("HeaderByNumber", mock.Anything, big.NewInt(1100)).Return(&types.Header{Number: big.NewInt(1100), Time: 10400}, nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 999999, // not in the registry
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	// -- 100 blocks in 400 seconds, finalized block by block
+	eta, err := gc.EstimateTimeToFinality(context.Background(), hash)
+	assert.NoError(t, err)
+	assert.Equal(t, 400*time.Second, eta)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_EstimateTimeToFinality_Pending(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	hash := common.HexToHash("0xabc")
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("TransactionReceipt", mock.Anything, hash).Return(nil, ethereum.NotFound)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	_, err := gc.EstimateTimeToFinality(context.Background(), hash)
+	assert.ErrorIs(t, err, ethereum.NotFound)
+}
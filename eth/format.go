@@ -0,0 +1,86 @@
+package eth
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// FormatUnits renders an integer amount with the given number of decimals, e.g. wei with 18
+// decimals as ETH. Trailing zeros in the fractional part are dropped.
+func FormatUnits(amount *big.Int, decimals uint8) string {
+	if amount == nil {
+		return "0"
+	}
+	sign := ""
+	abs := new(big.Int).Set(amount)
+	if abs.Sign() < 0 {
+		sign = "-"
+		abs.Neg(abs)
+	}
+
+	digits := abs.String()
+	if decimals == 0 {
+		return sign + digits
+	}
+	if len(digits) <= int(decimals) {
+		digits = strings.Repeat("0", int(decimals)-len(digits)+1) + digits
+	}
+	whole := digits[:len(digits)-int(decimals)]
+	frac := strings.TrimRight(digits[len(digits)-int(decimals):], "0")
+	if frac == "" {
+		return sign + whole
+	}
+	return sign + whole + "." + frac
+}
+
+// DescribeTransaction returns a concise, human-readable summary of a signed transaction for
+// logging: type, recovered sender, recipient, value in ETH, nonce, gas limit and fees in gwei.
+func DescribeTransaction(tx *types.Transaction, chainID *big.Int) string {
+	from := "unknown"
+	if sender, err := types.Sender(types.LatestSignerForChainID(chainID), tx); err == nil {
+		from = sender.Hex()
+	}
+	to := "contract-creation"
+	if tx.To() != nil {
+		to = tx.To().Hex()
+	}
+
+	parts := []string{
+		"type=" + transactionTypeName(tx.Type()),
+		"from=" + from,
+		"to=" + to,
+		"value=" + FormatUnits(tx.Value(), 18) + " ETH",
+		fmt.Sprintf("nonce=%d", tx.Nonce()),
+		fmt.Sprintf("gas_limit=%d", tx.Gas()),
+	}
+	if tx.Type() == types.LegacyTxType || tx.Type() == types.AccessListTxType {
+		parts = append(parts, "gas_price="+FormatUnits(tx.GasPrice(), 9)+" gwei")
+	} else {
+		parts = append(parts,
+			"max_fee="+FormatUnits(tx.GasFeeCap(), 9)+" gwei",
+			"max_priority_fee="+FormatUnits(tx.GasTipCap(), 9)+" gwei",
+		)
+	}
+	return strings.Join(parts, " ")
+}
+
+// transactionTypeName maps an EIP-2718 transaction type to a short name
+func transactionTypeName(txType uint8) string {
+	switch txType {
+	case types.LegacyTxType:
+		return "legacy"
+	case types.AccessListTxType:
+		return "access_list"
+	case types.DynamicFeeTxType:
+		return "eip1559"
+	case types.BlobTxType:
+		return "blob"
+	case types.SetCodeTxType:
+		return "set_code"
+	default:
+		return fmt.Sprintf("unknown(%d)", txType)
+	}
+}
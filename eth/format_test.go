@@ -0,0 +1,57 @@
+package eth
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatUnits(t *testing.T) {
+	assert.Equal(t, "1", FormatUnits(big.NewInt(1e18), 18))
+	assert.Equal(t, "0.001", FormatUnits(big.NewInt(1e15), 18))
+	assert.Equal(t, "1.5", FormatUnits(big.NewInt(1500000000), 9))
+	assert.Equal(t, "0.000000001", FormatUnits(big.NewInt(1), 9))
+	assert.Equal(t, "-2.25", FormatUnits(big.NewInt(-2250000), 6))
+	assert.Equal(t, "42", FormatUnits(big.NewInt(42), 0))
+	assert.Equal(t, "0", FormatUnits(big.NewInt(0), 18))
+	assert.Equal(t, "0", FormatUnits(nil, 18))
+}
+
+func TestDescribeTransaction_EIP1559(t *testing.T) {
+	acc, _ := testAccountAndConfig()
+	to := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	chainID := big.NewInt(1)
+	value, _ := new(big.Int).SetString("1500000000000000000", 10) // 1.5 ETH
+	signedTx, err := types.SignNewTx(acc.PrivateKey, types.LatestSignerForChainID(chainID), &types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     7,
+		To:        &to,
+		Value:     value,
+		Gas:       21000,
+		GasFeeCap: big.NewInt(30 * GWEI),
+		GasTipCap: big.NewInt(2 * GWEI),
+	})
+	assert.NoError(t, err)
+
+	described := DescribeTransaction(signedTx, chainID)
+	assert.Contains(t, described, "type=eip1559")
+	assert.Contains(t, described, "from="+acc.Address.Hex())
+	assert.Contains(t, described, "to="+to.Hex())
+	assert.Contains(t, described, "value=1.5 ETH")
+	assert.Contains(t, described, "nonce=7")
+	assert.Contains(t, described, "gas_limit=21000")
+	assert.Contains(t, described, "max_fee=30 gwei")
+	assert.Contains(t, described, "max_priority_fee=2 gwei")
+}
+
+func TestDescribeTransaction_LegacyUnsigned(t *testing.T) {
+	tx := types.NewContractCreation(1, big.NewInt(0), 100000, big.NewInt(GWEI), []byte{0x60})
+	described := DescribeTransaction(tx, big.NewInt(1))
+	assert.Contains(t, described, "type=legacy")
+	assert.Contains(t, described, "from=unknown")
+	assert.Contains(t, described, "to=contract-creation")
+	assert.Contains(t, described, "gas_price=1 gwei")
+}
@@ -0,0 +1,46 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/sirupsen/logrus"
+)
+
+// CheckFunds reports whether the sender of signedTx can pay for it before it is broadcast,
+// returning ErrInsufficientFunds when its balance is below the most the transaction can cost: the
+// gas limit at the fee cap (plus blob gas) and the value. The balance is the pending one by
+// default, which already has the sender's pending transactions taken out, or the latest
+// confirmed one with ETH_BALANCE_BLOCK_TAG=latest. In strict mode SendTransaction runs it on
+// every transaction before broadcasting.
+func (es *ghostClient) CheckFunds(ctx context.Context, signedTx *types.Transaction) error {
+	from, err := types.Sender(es.Signer(), signedTx)
+	if err != nil {
+		return fmt.Errorf("failed to recover sender: %w", err)
+	}
+
+	tag := es.config.BalanceBlockTag()
+	blockNumber := big.NewInt(int64(rpc.PendingBlockNumber))
+	if tag == BALANCE_BLOCK_TAG_LATEST {
+		blockNumber = nil
+	}
+	balance, err := es.readClient().BalanceAt(ctx, from, blockNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get %s balance: %w", tag, err)
+	}
+
+	cost := signedTx.Cost()
+	es.log.WithFields(logrus.Fields{
+		"from":    from.Hex(),
+		"tag":     tag,
+		"balance": balance.String(),
+		"cost":    cost.String(),
+	}).Debug("Checking funds")
+	if balance.Cmp(cost) < 0 {
+		return fmt.Errorf("%w: %s balance of %s is %s, transaction costs up to %s", ErrInsufficientFunds, tag, from.Hex(), balance, cost)
+	}
+	return nil
+}
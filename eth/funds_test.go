@@ -0,0 +1,93 @@
+package eth
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGhostClient_CheckFunds_BlockTag(t *testing.T) {
+	pending := big.NewInt(int64(rpc.PendingBlockNumber))
+	tests := []struct {
+		name        string
+		tag         string
+		blockNumber *big.Int
+	}{
+		{name: "default", tag: "", blockNumber: pending},
+		{name: "pending", tag: "pending", blockNumber: pending},
+		{name: "latest", tag: "latest", blockNumber: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("ETH_BALANCE_BLOCK_TAG", tt.tag)
+			acc, cfg := testAccountAndConfig()
+			signedTx := testBroadcastTx(t, acc) // costs up to 21000 * 100 wei
+
+			mockClient := &internalmocks.EthClient{}
+			mockClient.On("BalanceAt", mock.Anything, acc.Address, tt.blockNumber).Return(big.NewInt(2100000), nil).Once()
+			gc := &ghostClient{
+				client:  mockClient,
+				ctx:     context.Background(),
+				chainId: 1,
+				account: acc,
+				config:  cfg,
+				log:     newTestLogger(),
+			}
+
+			assert.NoError(t, gc.CheckFunds(context.Background(), signedTx))
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGhostClient_CheckFunds_Insufficient(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	signedTx := testBroadcastTx(t, acc)
+
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("BalanceAt", mock.Anything, acc.Address, mock.Anything).Return(big.NewInt(2099999), nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	err := gc.CheckFunds(context.Background(), signedTx)
+	assert.ErrorIs(t, err, ErrInsufficientFunds)
+	assert.ErrorContains(t, err, "pending balance")
+}
+
+func TestGhostClient_SendTransaction_StrictModeChecksFunds(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	signedTx := testBroadcastTx(t, acc)
+
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("BalanceAt", mock.Anything, acc.Address, mock.Anything).Return(big.NewInt(2099999), nil)
+	mockClient.On("SendTransaction", mock.Anything, signedTx).Return(nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	// -- outside strict mode the node is left to reject it
+	_, err := gc.SendTransaction(signedTx)
+	assert.NoError(t, err)
+	mockClient.AssertNotCalled(t, "BalanceAt", mock.Anything, mock.Anything, mock.Anything)
+
+	t.Setenv("ETH_STRICT_MODE", "true")
+	_, err = gc.SendTransaction(signedTx)
+	assert.ErrorIs(t, err, ErrInsufficientFunds)
+	mockClient.AssertNumberOfCalls(t, "SendTransaction", 1)
+}
@@ -0,0 +1,69 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// WaitForGasBelow polls the network gas price every pollInterval until it is below maxGasPrice,
+// returning that price. On EIP-1559 chains the base fee (from ETH_BASE_FEE_SOURCE) is compared,
+// elsewhere the node's suggested gas price. The first check is immediate; ctx bounds the wait.
+func (es *ghostClient) WaitForGasBelow(ctx context.Context, maxGasPrice *big.Int, pollInterval time.Duration) (*big.Int, error) {
+	if maxGasPrice == nil || maxGasPrice.Sign() <= 0 {
+		return nil, errors.New("max gas price must be positive")
+	}
+	if pollInterval <= 0 {
+		return nil, errors.New("poll interval must be positive")
+	}
+
+	after := es.after
+	if after == nil {
+		after = time.After
+	}
+
+	for polls := 1; ; polls++ {
+		price, err := es.currentGasPrice(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if price.Cmp(maxGasPrice) < 0 {
+			es.log.WithFields(logrus.Fields{
+				"gas_price": price.String(),
+				"polls":     polls,
+			}).Info("Gas price below threshold")
+			return price, nil
+		}
+		es.log.WithFields(logrus.Fields{
+			"gas_price": price.String(),
+			"threshold": maxGasPrice.String(),
+		}).Debug("Gas price above threshold, waiting")
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-after(pollInterval):
+		}
+	}
+}
+
+// currentGasPrice returns the base fee of the configured fee header, or the suggested gas price
+// on chains without one
+func (es *ghostClient) currentGasPrice(ctx context.Context) (*big.Int, error) {
+	header, err := es.feeHeader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if header.BaseFee != nil {
+		return new(big.Int).Set(header.BaseFee), nil
+	}
+	price, err := es.readClient().SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get gas price: %w", err)
+	}
+	return price, nil
+}
@@ -0,0 +1,89 @@
+package eth
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// testClock fires every wait immediately and records the requested durations
+type testClock struct {
+	waits []time.Duration
+}
+
+func (c *testClock) after(d time.Duration) <-chan time.Time {
+	c.waits = append(c.waits, d)
+	ch := make(chan time.Time, 1)
+	ch <- time.Time{}
+	return ch
+}
+
+func TestGhostClient_WaitForGasBelow(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{BaseFee: big.NewInt(50 * GWEI)}, nil).Once()
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{BaseFee: big.NewInt(40 * GWEI)}, nil).Once()
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{BaseFee: big.NewInt(12 * GWEI)}, nil).Once()
+	clock := &testClock{}
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+		after:   clock.after,
+	}
+
+	price, err := gc.WaitForGasBelow(context.Background(), big.NewInt(20*GWEI), 30*time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(12*GWEI), price)
+	assert.Equal(t, []time.Duration{30 * time.Second, 30 * time.Second}, clock.waits)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_WaitForGasBelow_Legacy(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{}, nil)
+	mockClient.On("SuggestGasPrice", mock.Anything).Return(big.NewInt(5*GWEI), nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+		after:   (&testClock{}).after,
+	}
+
+	price, err := gc.WaitForGasBelow(context.Background(), big.NewInt(20*GWEI), time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(5*GWEI), price)
+}
+
+func TestGhostClient_WaitForGasBelow_ContextDone(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{BaseFee: big.NewInt(50 * GWEI)}, nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+		after:   func(time.Duration) <-chan time.Time { return nil }, // never fires
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := gc.WaitForGasBelow(ctx, big.NewInt(20*GWEI), time.Second)
+	assert.ErrorIs(t, err, context.Canceled)
+}
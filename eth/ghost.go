@@ -3,8 +3,14 @@ package eth
 import (
 	"context"
 	"fmt"
+	"io"
+	"math"
 	"math/big"
+	"net/http"
 	"os"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -12,25 +18,185 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 )
 
 type GhostClient interface {
 	// SendTransaction sends a signed transaction to the network
 	SendTransaction(signedTx *types.Transaction) (*TransactionReceipt, error)
 
+	// SendTransactionAndWait sends a signed transaction and waits for it to be mined
+	SendTransactionAndWait(signedTx *types.Transaction) (*TransactionReceipt, error)
+
 	// SignTransaction signs a transaction with the client's private key
 	SignTransaction(tx *Transaction) (*types.Transaction, error)
 
+	// ResetNonce re-reads tracked nonces from the network on next use when ETH_NONCE_MANAGER is set
+	ResetNonce()
+
+	// SignBatchOffline signs fully populated transactions without network calls, with a per-transaction error
+	SignBatchOffline(txs []*Transaction) ([]*types.Transaction, []error)
+
 	// GetBalance returns the ETH balance of an address
 	GetBalance(address common.Address) (*big.Int, error)
 
+	// GetBalances returns the ETH balance of each address using batched JSON-RPC calls
+	GetBalances(ctx context.Context, addrs []common.Address) ([]*big.Int, []error)
+
+	// CheckFunds returns ErrInsufficientFunds if a signed transaction's sender can't pay its maximum cost
+	CheckFunds(ctx context.Context, signedTx *types.Transaction) error
+
+	// GetReceipts returns the receipt of each transaction using batched JSON-RPC calls
+	GetReceipts(ctx context.Context, hashes []common.Hash) ([]*TransactionReceipt, []error)
+
+	// CallContract executes a read-only call of ABI-encoded data against the contract at to and returns the raw output
+	CallContract(to common.Address, data []byte, blockNumber *big.Int) ([]byte, error)
+
+	// GetTokenBalance returns holder's balance of the ERC-20 token at tokenAddr
+	GetTokenBalance(tokenAddr common.Address, holder common.Address) (*big.Int, error)
+
 	// WaitForTransaction waits for a transaction to be mined and returns the receipt
 	WaitForTransaction(hash common.Hash) (*TransactionReceipt, error)
 
+	// WaitForTransactionContext waits for a transaction to be mined, returning early when ctx is cancelled
+	WaitForTransactionContext(ctx context.Context, hash common.Hash) (*TransactionReceipt, error)
+
+	// EstimateTimeToFinality estimates how long until a mined transaction's block is finalized, zero if it is
+	EstimateTimeToFinality(ctx context.Context, hash common.Hash) (time.Duration, error)
+
+	// WaitForReceiptMatching waits until a transaction's receipt exists and satisfies pred
+	WaitForReceiptMatching(ctx context.Context, hash common.Hash, pred func(*TransactionReceipt) bool) (*TransactionReceipt, error)
+
+	// WaitForConfirmations waits until a transaction has the given number of confirmations and returns its receipt
+	WaitForConfirmations(hash common.Hash, confirmations uint64) (*TransactionReceipt, error)
+
+	// WatchConfirmations calls cb on each new confirmation of a transaction and on reorgs until it reaches target
+	WatchConfirmations(ctx context.Context, hash common.Hash, target uint64, cb func(ConfirmationUpdate)) error
+
+	// CodeSize returns the length of the code deployed at an address, zero for externally owned accounts
+	CodeSize(ctx context.Context, address common.Address) (int, error)
+
+	// HasTransacted reports whether an address has sent at least one confirmed transaction
+	HasTransacted(ctx context.Context, address common.Address) (bool, error)
+
+	// ActivityRange returns the blocks of the first and last transaction an address sent
+	ActivityRange(ctx context.Context, addr common.Address) (firstBlock, lastBlock uint64, err error)
+
+	// VerifyContractCode reports whether the code deployed at an address hashes to expectedHash
+	VerifyContractCode(ctx context.Context, address common.Address, expectedHash common.Hash) (bool, error)
+
 	// GetTransactionReceipt returns the receipt for a transaction if it exists
 	GetTransactionReceipt(hash common.Hash) (*TransactionReceipt, error)
 
+	// GetTransaction returns a mined or pending transaction by hash, including its type
+	GetTransaction(ctx context.Context, hash common.Hash) (*Transaction, error)
+
+	// CurrentFees returns the latest base fee and a suggested priority fee, cached briefly
+	CurrentFees(ctx context.Context) (baseFee, suggestedTip *big.Int, err error)
+
+	// WaitForGasBelow polls until the base fee (or gas price on legacy chains) is below maxGasPrice
+	WaitForGasBelow(ctx context.Context, maxGasPrice *big.Int, pollInterval time.Duration) (*big.Int, error)
+
+	// CompareFeeModes returns tx's gas cost as an EIP-1559 and as a legacy transaction at current prices
+	CompareFeeModes(ctx context.Context, tx *Transaction) (eip1559Cost, legacyCost *big.Int, err error)
+
+	// MinFeesForNextBlock returns the cheapest EIP-1559 fees likely to be included in the next block
+	MinFeesForNextBlock(ctx context.Context) (maxFee, tip *big.Int, err error)
+
+	// SuggestFees suggests EIP-1559 fees, or a legacy gas price, for a slow, standard or fast transaction
+	SuggestFees(speed FeeSpeed) (*FeeEstimate, error)
+
+	// FeeCompetitiveness returns the fraction of recent blocks that would have included a pending transaction at its fees
+	FeeCompetitiveness(ctx context.Context, hash common.Hash) (float64, error)
+
+	// ProjectNonce returns the nonce a transaction will get after pendingTxCount more from an address
+	ProjectNonce(ctx context.Context, addr common.Address, pendingTxCount int) (uint64, error)
+
+	// FindReplacements returns the hashes of mempool transactions from an address with the given nonce
+	FindReplacements(ctx context.Context, addr common.Address, nonce uint64) ([]common.Hash, error)
+
+	// IsReplacementUnderpriced reports whether newTx's fees are too low for a node to accept it in place of the pending oldTx
+	IsReplacementUnderpriced(ctx context.Context, oldTx, newTx *types.Transaction) bool
+
+	// SpeedUpTransaction re-sends a pending transaction at the same nonce with its fees raised by a percentage
+	SpeedUpTransaction(hash common.Hash, bumpPercent int) (*types.Transaction, error)
+
+	// GasStats returns base fee and gas-used ratio statistics over the last N blocks, cached briefly
+	GasStats(ctx context.Context, lastNBlocks int) (*GasStats, error)
+
+	// GetBlockReceipts returns the receipts of every transaction in a block (nil for latest)
+	GetBlockReceipts(ctx context.Context, blockNumber *big.Int) ([]*TransactionReceipt, error)
+
+	// ExportTransactions writes the transactions sent from or to an address in a block range to w as CSV
+	ExportTransactions(ctx context.Context, addr common.Address, fromBlock, toBlock uint64, w io.Writer) error
+
+	// InclusionProof returns the verified block location of a mined transaction
+	InclusionProof(ctx context.Context, hash common.Hash) (*InclusionProof, error)
+
+	// EstimateGas returns the node's gas estimate for a transaction, without the configured buffer
+	EstimateGas(tx *Transaction) (uint64, error)
+
+	// EstimateGasBatch estimates gas for several transactions in one batch, with a per-transaction error
+	EstimateGasBatch(ctx context.Context, txs []*Transaction) ([]uint64, []error)
+
+	// BinarySearchGasLimit finds the lowest gas limit in [lo, hi] at which tx succeeds, via eth_call
+	BinarySearchGasLimit(ctx context.Context, tx *Transaction, lo, hi uint64) (uint64, error)
+
+	// EstimateBatchCost returns the estimated gas, fee and value of each planned transaction and their totals, without sending
+	EstimateBatchCost(ctx context.Context, txs []*Transaction) (*BatchCostReport, error)
+
+	// EstimateGasWithOverrides estimates gas for tx as if the given account state overrides applied
+	EstimateGasWithOverrides(ctx context.Context, tx *Transaction, overrides map[common.Address]StateOverride) (uint64, error)
+
+	// SimulateBalanceChanges traces tx and returns the net ETH balance change of each address it touches
+	SimulateBalanceChanges(ctx context.Context, tx *Transaction) (map[common.Address]*big.Int, error)
+
+	// Allowance returns how much of token spender may transfer on behalf of owner
+	Allowance(ctx context.Context, token, owner, spender common.Address) (*big.Int, error)
+
+	// EnsureAllowance approves spender for amount of token if the current allowance is lower, waiting for it to be mined
+	EnsureAllowance(ctx context.Context, token, spender common.Address, amount *big.Int) (bool, error)
+
+	// ScanLogs fetches logs over a block range in chunks, shrinking them to the provider's limit
+	ScanLogs(ctx context.Context, query ethereum.FilterQuery, fn func([]types.Log) error) error
+
+	// Portfolio returns the account's ETH balance and its balances of the given ERC-20 tokens
+	Portfolio(ctx context.Context, tokens []common.Address) (*Portfolio, error)
+
+	// Heartbeat sends a zero-value self-transaction to check the signing pipeline end to end
+	Heartbeat(ctx context.Context) (*TransactionReceipt, error)
+
+	// SendAsync signs and sends a transaction, returning a future that resolves once it is mined
+	SendAsync(ctx context.Context, tx *Transaction) (*TxFuture, error)
+
+	// SweepTokens transfers the account's full balance of each token to a destination, skipping empty ones
+	SweepTokens(ctx context.Context, tokens []common.Address, to common.Address) ([]common.Hash, error)
+
+	// SignTransactionWithAccount signs a transaction with the configured account named label instead of the client's
+	SignTransactionWithAccount(label string, tx *Transaction) (*types.Transaction, error)
+
+	// SendTransactionWithAccount signs a transaction with the configured account named label and sends it
+	SendTransactionWithAccount(label string, tx *Transaction) (*TransactionReceipt, error)
+
+	// ProveOwnership signs a challenge with the account key as an EIP-191 message, see VerifyOwnership
+	ProveOwnership(challenge []byte) ([]byte, error)
+
+	// SignMessage signs data with the account key as an EIP-191 personal message
+	SignMessage(data []byte) ([]byte, error)
+
+	// SignTypedData signs EIP-712 typed data with the account key
+	SignTypedData(typedData apitypes.TypedData) ([]byte, error)
+
+	// SignPermit signs an ERC-2612 permit for spender to transfer value of token until deadline
+	SignPermit(ctx context.Context, token common.Address, spender common.Address, value *big.Int, deadline *big.Int) (*PermitSignature, error)
+
+	// Signer returns the transaction signer for the connected chain
+	Signer() types.Signer
+
 	// Close closes the Ethereum client connection
 	Close()
 }
@@ -39,31 +205,130 @@ type GhostClient interface {
 type EthClient interface {
 	ChainID(ctx context.Context) (*big.Int, error)
 	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+	BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error)
+	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
+	BlockReceipts(ctx context.Context, blockNrOrHash rpc.BlockNumberOrHash) ([]*types.Receipt, error)
+	CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error)
+	CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
 	SendTransaction(ctx context.Context, tx *types.Transaction) error
 	TransactionByHash(ctx context.Context, hash common.Hash) (*types.Transaction, bool, error)
 	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
 	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
 	EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error)
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+	FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error)
+	HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error)
 	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error)
 	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
 	Close()
 }
 
 // Ensure *ethclient.Client implements EthClient
 var _ EthClient = (*ethclient.Client)(nil)
 
+// RPCClient issues raw JSON-RPC calls for methods ethclient doesn't wrap
+type RPCClient interface {
+	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
+	BatchCallContext(ctx context.Context, b []rpc.BatchElem) error
+}
+
+// Ensure *rpc.Client implements RPCClient
+var _ RPCClient = (*rpc.Client)(nil)
+
 type ghostClient struct {
 	client  EthClient
+	rpc     RPCClient // raw JSON-RPC access to the read endpoint
+	writer  EthClient // optional broadcast client, nil when reads and writes share an endpoint
 	ctx     context.Context
+	cancel  context.CancelFunc // cancels ctx on Close, stopping background waiters
 	chainId int64
 	account *Account
 	config  Config
 	log     *logrus.Logger
+
+	// readURL and writeURL are the endpoints dialed, empty when the client was supplied
+	readURL  string
+	writeURL string
+
+	// signer is computed once for chainId; see Signer
+	signer types.Signer
+
+	// async tracks SendAsync waiters so Close can wait for them to stop
+	async sync.WaitGroup
+
+	feeCacheMu    sync.Mutex
+	feeCache      *cachedFees
+	gasStatsCache map[int]*cachedGasStats
+
+	// subscribeHeads waits for transactions on new-head notifications instead of polling
+	subscribeHeads bool
+
+	// clientMu guards client, which is replaced when a dropped websocket connection is re-dialed
+	clientMu         sync.RWMutex
+	reconnectMu      sync.Mutex
+	redial           func(ctx context.Context) (EthClient, error)
+	reconnectBackoff time.Duration
+
+	// -- behaviour toggled through Options
+	lightReceipts  bool
+	receiptTimes   bool          // fetch the block header to set receipt timestamps
+	rpcDebug       io.Writer     // receives JSON-RPC traffic when set
+	rpcHeaders     http.Header   // sent with every RPC request, on top of ETH_RPC_HEADERS
+	broadcastCheck time.Duration // window for confirming a sent transaction, zero to skip
+
+	// after replaces time.After in polling loops, so tests can drive the clock
+	after func(time.Duration) <-chan time.Time
+
+	// errorClassifier maps provider-specific errors to typed errors ahead of the built-in patterns
+	errorClassifier func(error) error
+
+	// logRange is the learned eth_getLogs block range limit of the read endpoint, zero until a
+	// range has been rejected
+	logRangeMu sync.Mutex
+	logRange   uint64
+
+	// feeFallback re-sends EIP-1559 transactions as legacy when the provider rejects the type
+	feeFallback bool
+
+	// metadata remembers Transaction.Metadata for recently signed hashes
+	metadata metadataRegistry
+	// deadlines remembers Transaction.ValidUntil for recently signed hashes
+	deadlines deadlineRegistry
+
+	// autoAccessList attaches an eth_createAccessList result to contract calls when it saves gas
+	autoAccessList bool
+
+	// payableCheck simulates value transfers to contracts outside strict mode too
+	payableCheck bool
+
+	// txStore, when set, keeps an audit record of every signed, sent and mined transaction
+	txStore TxStore
+
+	// baseFeeGuard re-signs EIP-1559 transactions whose fee cap the base fee has outrun before sending
+	baseFeeGuard bool
+
+	// nonces hands out nonces locally when ETH_NONCE_MANAGER is set, nil otherwise
+	nonces *NonceManager
+
+	// preSendHook inspects every signed transaction right before broadcast and can veto it
+	preSendHook func(*types.Transaction) error
+
+	// transferGas skips gas estimation for plain transfers to externally owned accounts, which
+	// codeCache remembers for codeCacheTTL
+	transferGas bool
+	codeCacheMu sync.Mutex
+	codeCache   map[common.Address]cachedCode
+
+	// autoApprove raises allowances for a Transaction's TokenSpend before signing it
+	autoApprove      bool
+	approveUnlimited bool
 }
 
-func NewGhostClient(account *Account, cfg Config, l *logrus.Logger) (GhostClient, error) {
+func NewGhostClient(account *Account, cfg Config, l *logrus.Logger, opts ...Option) (GhostClient, error) {
 
-	ctx := context.Background()
 	chainId := account.ChainId
 
 	// -- validate account
@@ -83,6 +348,24 @@ func NewGhostClient(account *Account, cfg Config, l *logrus.Logger) (GhostClient
 		return nil, fmt.Errorf("account public key is not set")
 	}
 
+	// -- an account with its own endpoint targets its own chain, checked when dialing
+	readURL, writeURL := cfg.RPCURLRead(), cfg.RPCURLWrite()
+	if account.RPCURL != "" {
+		readURL, writeURL = account.RPCURL, account.RPCURL
+		l.WithFields(logrus.Fields{
+			"account":  account.Label,
+			"chain_id": account.ChainId,
+		}).Info("Using the account's RPC URL and chain ID")
+	} else if account.ChainId != cfg.ChainID() {
+		if cfg.StrictMode() {
+			return nil, fmt.Errorf("account chain ID %d does not match configured chain ID %d", account.ChainId, cfg.ChainID())
+		}
+		l.WithFields(logrus.Fields{
+			"account_chain_id": account.ChainId,
+			"config_chain_id":  cfg.ChainID(),
+		}).Warn("Account chain ID does not match configuration, using the account's")
+	}
+
 	// Log proxy usage if configured
 	if os.Getenv("HTTP_PROXY") != "" || os.Getenv("HTTPS_PROXY") != "" {
 		l.WithFields(logrus.Fields{
@@ -93,166 +376,581 @@ func NewGhostClient(account *Account, cfg Config, l *logrus.Logger) (GhostClient
 		l.Info("Connected to Ethereum network directly")
 	}
 
+	ctx, cancel := context.WithCancel(context.Background())
+	es := &ghostClient{
+		ctx:     ctx,
+		cancel:  cancel,
+		chainId: chainId,
+		account: account,
+		config:  cfg,
+		log:     l,
+		signer:  types.LatestSignerForChainID(big.NewInt(chainId)),
+	}
+	for _, opt := range opts {
+		opt(es)
+	}
+	if es.client == nil {
+		es.readURL, es.writeURL = readURL, writeURL
+	}
+	if cfg.NonceManager() {
+		es.nonces = es.newNonceManager()
+	}
+
+	// -- Use the client supplied with WithEthClient instead of dialing
+	if es.client != nil {
+		if err := useEthClient(ctx, es, chainId); err != nil {
+			cancel()
+			return nil, err
+		}
+		l.WithField("chain_id", chainId).Info("Using supplied Ethereum client")
+		return es, nil
+	}
+
 	// -- Connect to Ethereum client
-	l.WithField("url", cfg.RPCURL()).Info("Connecting to Ethereum RPC")
-	client, err := ethclient.DialContext(ctx, cfg.RPCURL())
+	client, err := dialClient(ctx, l, readURL, chainId, es.dialOptions(readURL)...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	es.client = client
+	es.rpc = client.Client()
+	es.subscribeHeads = isWebsocketURL(readURL)
+	if es.subscribeHeads {
+		es.redial = func(ctx context.Context) (EthClient, error) {
+			return dialClient(ctx, l, readURL, chainId, es.dialOptions(readURL)...)
+		}
+	}
+
+	// -- Connect a separate broadcast client when the write endpoint differs
+	if writeURL != readURL {
+		writeClient, err := dialClient(ctx, l, writeURL, chainId, es.dialOptions(writeURL)...)
+		if err != nil {
+			client.Close()
+			cancel()
+			return nil, fmt.Errorf("write endpoint: %w", err)
+		}
+		es.writer = writeClient
+	}
+
+	l.WithFields(logrus.Fields{
+		"chain_id": chainId,
+		"account":  account.Address.Hex(),
+	}).Info("Successfully connected to Ethereum network")
+
+	return es, nil
+}
+
+// dialOptions returns the RPC client options for url: configured headers and, for HTTP
+// endpoints, the debug transport
+func (es *ghostClient) dialOptions(url string) []rpc.ClientOption {
+	var opts []rpc.ClientOption
+
+	headers := http.Header{}
+	for name, value := range es.config.RPCHeaders() {
+		headers.Set(name, value)
+	}
+	for name, values := range es.rpcHeaders {
+		headers[name] = values
+	}
+	if len(headers) > 0 {
+		names := make([]string, 0, len(headers))
+		for name := range headers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		es.log.WithField("headers", strings.Join(names, ",")).Info("Sending custom RPC headers") // names only, values may be secrets
+		opts = append(opts, rpc.WithHeaders(headers))
+	}
+
+	if es.rpcDebug != nil {
+		if isWebsocketURL(url) {
+			es.log.WithField("url", url).Warn("RPC debug logging is not supported for websocket endpoints")
+		} else {
+			opts = append(opts, rpc.WithHTTPClient(&http.Client{Transport: newDebugTransport(es.rpcDebug, nil)}))
+		}
+	}
+	return opts
+}
+
+// dialClient connects to an RPC endpoint and verifies it serves the expected chain
+func dialClient(ctx context.Context, l *logrus.Logger, url string, chainId int64, opts ...rpc.ClientOption) (*ethclient.Client, error) {
+	l.WithField("url", url).Info("Connecting to Ethereum RPC")
+	rpcClient, err := rpc.DialOptions(ctx, url, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to Ethereum network: %w", err)
 	}
+	client := ethclient.NewClient(rpcClient)
 
 	// -- Verify connection and get chain ID
 	l.Info("Verifying connection and getting chain ID")
 	clientChainId, err := client.ChainID(ctx)
 	if err != nil {
+		client.Close()
 		return nil, fmt.Errorf("failed to get chain ID: %w", err)
 	}
 
 	// -- Check if chain ID matches config
 	if clientChainId.Int64() != chainId {
+		client.Close()
 		return nil, fmt.Errorf("expected chain ID %d, got %d", chainId, clientChainId.Int64())
 	}
+	return client, nil
+}
 
-	l.WithFields(logrus.Fields{
-		"chain_id": clientChainId.Int64(),
-		"account":  account.Address.Hex(),
-	}).Info("Successfully connected to Ethereum network")
+// runPreSendHook passes signedTx to the hook set with WithPreSendHook, if any
+func (es *ghostClient) runPreSendHook(signedTx *types.Transaction) error {
+	if es.preSendHook == nil {
+		return nil
+	}
+	if err := es.preSendHook(signedTx); err != nil {
+		return fmt.Errorf("pre-send hook rejected transaction %s: %w", signedTx.Hash().Hex(), err)
+	}
+	return nil
+}
 
-	return &ghostClient{
-		client:  client, // now EthClient
-		ctx:     ctx,
-		chainId: clientChainId.Int64(),
-		account: account,
-		config:  cfg,
-		log:     l,
-	}, nil
+// accountLog returns a log entry tagged with the account label, so operators running many
+// wallets can tell their transactions apart. Unlabelled accounts log without the field.
+func (es *ghostClient) accountLog(acc *Account) *logrus.Entry {
+	if acc == nil || acc.Label == "" {
+		return logrus.NewEntry(es.log)
+	}
+	return es.log.WithField("account", acc.Label)
 }
 
 // SendTransaction sends a signed transaction to the network
 func (es *ghostClient) SendTransaction(signedTx *types.Transaction) (*TransactionReceipt, error) {
-	es.log.WithField("hash", signedTx.Hash().Hex()).Info("Sending transaction to network")
+	return es.sendTransaction(es.account, signedTx)
+}
+
+// SendTransactionAndWait sends a signed transaction like SendTransaction, then waits for it to be
+// mined like WaitForTransaction and returns the mined receipt. When the transaction was re-signed
+// before broadcasting, it waits for the transaction actually sent.
+func (es *ghostClient) SendTransactionAndWait(signedTx *types.Transaction) (*TransactionReceipt, error) {
+	receipt, err := es.SendTransaction(signedTx)
+	if err != nil {
+		return nil, err
+	}
+	return es.waitForTransaction(es.ctx, receipt.TxHash)
+}
+
+// sendTransaction sends signedTx, which was signed by acc
+func (es *ghostClient) sendTransaction(acc *Account, signedTx *types.Transaction) (*TransactionReceipt, error) {
+	metadata := es.metadata.get(signedTx.Hash())
+	l := es.accountLog(acc)
+	if metadata != nil {
+		l = l.WithField("metadata", metadata)
+	}
+	l.WithField("hash", signedTx.Hash().Hex()).Info("Sending transaction to network")
+
+	if es.config.StrictMode() && signedTx.ChainId().Cmp(big.NewInt(es.chainId)) != 0 {
+		err := fmt.Errorf("strict mode: transaction chain ID %s does not match connected chain %d", signedTx.ChainId(), es.chainId)
+		l.WithError(err).Error("Transaction not sent")
+		es.resyncNonce(acc.Address, signedTx.Nonce())
+		return nil, err
+	}
+
+	if err := es.deadlines.check(signedTx.Hash()); err != nil {
+		l.WithError(err).Warn("Transaction not sent")
+		es.resyncNonce(acc.Address, signedTx.Nonce())
+		return nil, err
+	}
+
+	if size, limit := signedTx.Size(), es.config.MaxTxSizeBytes(); size > limit {
+		err := fmt.Errorf("%w: %d bytes exceeds limit of %d bytes", ErrTxTooLarge, size, limit)
+		l.WithError(err).Error("Transaction not sent")
+		es.resyncNonce(acc.Address, signedTx.Nonce())
+		return nil, err
+	}
+
+	if es.config.StrictMode() {
+		if err := es.CheckFunds(es.ctx, signedTx); err != nil {
+			l.WithError(err).Error("Transaction not sent")
+			es.resyncNonce(acc.Address, signedTx.Nonce())
+			return nil, fmt.Errorf("strict mode: %w", err)
+		}
+	}
+
+	if es.baseFeeGuard && signedTx.Type() == types.DynamicFeeTxType {
+		guardedTx, err := es.guardBaseFee(acc, signedTx)
+		if err != nil {
+			l.WithError(err).Error("Base fee guard failed")
+			es.resyncNonce(acc.Address, signedTx.Nonce())
+			return nil, fmt.Errorf("failed to check base fee: %w", err)
+		}
+		if guardedTx != signedTx {
+			es.metadata.put(guardedTx.Hash(), metadata)
+			signedTx = guardedTx
+		}
+	}
+
+	if err := es.runPreSendHook(signedTx); err != nil {
+		l.WithError(err).Warn("Transaction rejected before sending")
+		es.resyncNonce(acc.Address, signedTx.Nonce())
+		return nil, err
+	}
 
 	// Send the transaction
-	err := es.client.SendTransaction(es.ctx, signedTx)
+	err := es.writeClient().SendTransaction(es.ctx, signedTx)
 	if err != nil {
-		es.log.WithError(err).Error("Failed to send transaction")
-		return nil, fmt.Errorf("failed to send transaction: %w", err)
+		err = es.classifyError(err)
+		if !es.canFallbackToLegacy(signedTx, err) {
+			l.WithError(err).Error("Failed to send transaction")
+			es.resyncNonce(acc.Address, signedTx.Nonce())
+			return nil, fmt.Errorf("failed to send transaction: %w", err)
+		}
+
+		l.WithError(err).Warn("EIP-1559 transaction rejected, re-sending as legacy")
+		legacyTx, legacyErr := es.resignAsLegacy(acc, signedTx)
+		if legacyErr != nil {
+			l.WithError(legacyErr).Error("Failed to re-sign transaction as legacy")
+			es.resyncNonce(acc.Address, signedTx.Nonce())
+			return nil, fmt.Errorf("failed to re-sign transaction as legacy: %w", legacyErr)
+		}
+		if err := es.runPreSendHook(legacyTx); err != nil {
+			l.WithError(err).Warn("Legacy transaction rejected before sending")
+			es.resyncNonce(acc.Address, signedTx.Nonce())
+			return nil, err
+		}
+		if err := es.writeClient().SendTransaction(es.ctx, legacyTx); err != nil {
+			l.WithError(err).Error("Failed to send legacy transaction")
+			es.resyncNonce(acc.Address, signedTx.Nonce())
+			return nil, fmt.Errorf("failed to send transaction: %w", es.classifyError(err))
+		}
+		es.metadata.put(legacyTx.Hash(), metadata)
+		signedTx = legacyTx
 	}
 
-	es.log.WithField("hash", signedTx.Hash().Hex()).Info("Transaction sent successfully")
+	if es.broadcastCheck > 0 {
+		if err := es.confirmBroadcast(signedTx.Hash()); err != nil {
+			l.WithError(err).Error("Sent transaction not accepted by provider")
+			return nil, err
+		}
+	}
+
+	if err := es.recordTransaction(acc, signedTx, TxRecordSent); err != nil {
+		l.WithError(err).Error("Failed to record sent transaction")
+	}
+	l.WithField("hash", signedTx.Hash().Hex()).Info("Transaction sent successfully")
 
-	// Return immediately with transaction hash
-	return &TransactionReceipt{
-		TxHash: signedTx.Hash(),
-		Status: 0,                  // Pending
-		From:   es.account.Address, // Use known address
-		To:     *signedTx.To(),
-	}, nil
+	// Return immediately with transaction hash; To stays zero for contract creations
+	receipt := &TransactionReceipt{
+		TxHash:   signedTx.Hash(),
+		Status:   0, // Pending
+		From:     acc.Address,
+		Type:     signedTx.Type(),
+		Metadata: metadata,
+	}
+	if signedTx.To() != nil {
+		receipt.To = *signedTx.To()
+	}
+	return receipt, nil
 }
 
 // WaitForTransaction waits for a transaction to be mined and returns the receipt
 func (es *ghostClient) WaitForTransaction(hash common.Hash) (*TransactionReceipt, error) {
-	receipt, err := es.waitForTransaction(hash)
+	return es.waitForTransaction(es.ctx, hash)
+}
+
+// WaitForTransactionContext is WaitForTransaction, returning early with ctx's error when ctx is
+// cancelled
+func (es *ghostClient) WaitForTransactionContext(ctx context.Context, hash common.Hash) (*TransactionReceipt, error) {
+	return es.waitForTransaction(ctx, hash)
+}
+
+// EstimateGas returns the node's gas estimate for tx without the configured buffer, e.g. to show
+// the expected gas cost before the transaction is signed. tx is validated but not modified.
+func (es *ghostClient) EstimateGas(tx *Transaction) (uint64, error) {
+	if err := tx.Validate(); err != nil {
+		return 0, fmt.Errorf("invalid transaction: %w", err)
+	}
+	return es.estimateGas(tx)
+}
+
+// estimateGasAndSetLimit estimates gas for the transaction and sets tx.GasLimit accordingly.
+func (es *ghostClient) estimateGasAndSetLimit(tx *Transaction) error {
+	gasLimit, err := es.estimateGas(tx)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	// Get the transaction to find the To address
-	tx, _, err := es.client.TransactionByHash(es.ctx, hash)
+	tx.GasLimit, err = applyGasBuffer(gasLimit, es.gasLimitBuffer(tx), es.config.GasLimitBufferAbsolute())
 	if err != nil {
-		return nil, fmt.Errorf("failed to get transaction: %w", err)
+		es.log.WithError(err).Error("Invalid gas limit")
+		return err
 	}
+	es.log.WithFields(logrus.Fields{
+		"estimated":   gasLimit,
+		"with_buffer": tx.GasLimit,
+	}).Info("Gas limit calculated")
 
-	return &TransactionReceipt{
-		TxHash:      receipt.TxHash,
-		Status:      receipt.Status,
-		BlockNumber: receipt.BlockNumber,
-		GasUsed:     receipt.GasUsed,
-		From:        es.account.Address, // Use known address
-		To:          *tx.To(),           // Get To address from transaction
-		Logs:        receipt.Logs,
-	}, nil
+	return es.checkBlockGasCap(tx.GasLimit)
 }
 
-// estimateGasAndSetLimit estimates gas for the transaction and sets tx.GasLimit accordingly.
-func (es *ghostClient) estimateGasAndSetLimit(tx *Transaction) error {
+// estimateGas returns the unbuffered gas estimate for tx, from EstimateFrom when set
+func (es *ghostClient) estimateGas(tx *Transaction) (uint64, error) {
+	from := tx.From
+	if tx.EstimateFrom != (common.Address{}) {
+		from = tx.EstimateFrom
+	}
 	msg := ethereum.CallMsg{
-		From:  tx.From,
-		To:    &tx.To,
-		Value: tx.Value,
-		Data:  tx.Data,
+		From:       from,
+		To:         tx.recipient(),
+		Value:      tx.Value,
+		Data:       tx.Data,
+		AccessList: tx.AccessList,
 	}
 
-	gasLimit, err := es.client.EstimateGas(es.ctx, msg)
+	if es.transferGas && es.isPlainTransfer(tx) {
+		es.log.WithField("to", tx.To.Hex()).Info("Plain transfer to an externally owned account, skipping gas estimation")
+		return params.TxGas, nil
+	}
+	gasLimit, err := es.readClient().EstimateGas(es.ctx, msg)
 	if err != nil {
 		es.log.WithError(err).Error("Failed to estimate gas")
-		return fmt.Errorf("failed to estimate gas: %w", err)
+		return 0, fmt.Errorf("failed to estimate gas: %w", es.classifyError(err))
+	}
+	return gasLimit, nil
+}
+
+// checkBlockGasCap validates a gas limit against the network gas limit, transaction will get blocked
+// if goes above it. The check is skipped if the header can't be fetched, unless in strict mode.
+func (es *ghostClient) checkBlockGasCap(gasLimit uint64) error {
+	header, err := es.readClient().HeaderByNumber(es.ctx, nil)
+	if err != nil {
+		if es.config.StrictMode() {
+			return fmt.Errorf("strict mode: failed to check gas limit against block gas cap: %w", err)
+		}
+		return nil
+	}
+	if header.GasLimit > 0 {
+		maxGas := header.GasLimit * 2 / 3 // Use 2/3 of block gas limit
+		if gasLimit > maxGas {
+			es.log.WithFields(logrus.Fields{
+				"gas_limit":   gasLimit,
+				"max_allowed": maxGas,
+			}).Error("Gas limit too high")
+			return fmt.Errorf("gas limit %d exceeds maximum allowed %d", gasLimit, maxGas)
+		}
+	}
+	return nil
+}
+
+// resolveRecipient sets tx.To from the configured account named by tx.ToLabel, if any
+func (es *ghostClient) resolveRecipient(tx *Transaction) error {
+	if tx.ToLabel == "" {
+		return nil
+	}
+	account, ok := es.config.Account(tx.ToLabel)
+	if !ok {
+		return fmt.Errorf("unknown recipient label %q", tx.ToLabel)
+	}
+	if tx.To != (common.Address{}) && tx.To != account.Address {
+		return fmt.Errorf("recipient label %q resolves to %s, conflicting with to %s", tx.ToLabel, account.Address.Hex(), tx.To.Hex())
 	}
+	tx.To = account.Address
+	es.log.WithFields(logrus.Fields{
+		"label": tx.ToLabel,
+		"to":    tx.To.Hex(),
+	}).Info("Resolved recipient label")
+	return nil
+}
 
-	// Add dynamic buffer based on transaction complexity
+// checkCanReceiveETH simulates sending tx's value to a contract recipient and fails with
+// ErrNonPayableRecipient if the contract's code rejects it. A plain transfer that reverts has no
+// payable receive or fallback; a call that reverts is only blamed on the value if the same call
+// without value succeeds. Transfers to accounts without code always pass.
+func (es *ghostClient) checkCanReceiveETH(tx *Transaction) error {
+	if tx.Value.Sign() == 0 || tx.IsContractCreation {
+		return nil
+	}
+	size, err := es.CodeSize(es.ctx, tx.To)
+	if err != nil {
+		return err
+	}
+	if size == 0 {
+		return nil
+	}
+	msg := ethereum.CallMsg{From: tx.From, To: &tx.To, Value: tx.Value, Data: tx.Data}
+	_, err = es.readClient().CallContract(es.ctx, msg, nil)
+	if err == nil {
+		return nil
+	}
+	if !isExecutionReverted(err) {
+		return fmt.Errorf("failed to simulate transfer to %s: %w", tx.To.Hex(), err)
+	}
+	if len(tx.Data) > 0 {
+		msg.Value = nil
+		if _, zeroErr := es.readClient().CallContract(es.ctx, msg, nil); zeroErr != nil {
+			return fmt.Errorf("simulated call to %s reverts: %w", tx.To.Hex(), err)
+		}
+	}
+	return fmt.Errorf("%w: contract %s cannot receive ETH: %v", ErrNonPayableRecipient, tx.To.Hex(), err)
+}
+
+// gasLimitBuffer returns the multiplier applied to tx's gas estimate, based on its complexity
+func (es *ghostClient) gasLimitBuffer(tx *Transaction) float64 {
 	var buffer float64
-	if len(tx.Data) == 0 {
+	if tx.GasLimitBuffer != 0 {
+		buffer = tx.GasLimitBuffer // Per-transaction override
+		es.log.WithField("buffer", buffer).Info("Using transaction gas limit buffer")
+	} else if len(tx.Data) == 0 {
 		buffer = es.config.GasLimitBufferSimple() // Configurable buffer for simple ETH transfers
 		es.log.WithField("buffer", buffer).Info("Using simple transaction buffer")
 	} else {
 		buffer = es.config.GasLimitBufferComplex() // Configurable buffer for complex transactions
 		es.log.WithField("buffer", buffer).Info("Using complex transaction buffer")
 	}
-	tx.GasLimit = uint64(float64(gasLimit) * buffer)
-	es.log.WithFields(logrus.Fields{
-		"estimated":   gasLimit,
-		"with_buffer": tx.GasLimit,
-	}).Info("Gas limit calculated")
+	return buffer
+}
 
-	// Validate against network gas limit, transaction will get blocked if goes above it
-	header, err := es.client.HeaderByNumber(es.ctx, nil)
-	if err == nil && header.GasLimit > 0 {
-		maxGas := header.GasLimit * 2 / 3 // Use 2/3 of block gas limit
-		if tx.GasLimit > maxGas {
-			es.log.WithFields(logrus.Fields{
-				"gas_limit":   tx.GasLimit,
-				"max_allowed": maxGas,
-			}).Error("Gas limit too high")
-			return fmt.Errorf("gas limit %d exceeds maximum allowed %d", tx.GasLimit, maxGas)
-		}
+// applyGasBuffer scales an estimate by buffer and adds absolute gas on top. A zero scaled estimate
+// is rejected since it can only come from a broken estimate or misconfigured buffer; anything below
+// the intrinsic cost of a transfer is raised to it, as no transaction can be included with less.
+func applyGasBuffer(estimated uint64, buffer float64, absolute uint64) (uint64, error) {
+	gasLimit := uint64(float64(estimated) * buffer)
+	if gasLimit == 0 {
+		return 0, fmt.Errorf("computed gas limit is zero (estimate %d, buffer %.2f)", estimated, buffer)
 	}
-	return nil
+	if gasLimit > math.MaxUint64-absolute {
+		return 0, fmt.Errorf("gas limit overflows (estimate %d, buffer %.2f, absolute %d)", estimated, buffer, absolute)
+	}
+	gasLimit += absolute
+	if gasLimit < params.TxGas {
+		gasLimit = params.TxGas
+	}
+	return gasLimit, nil
 }
 
 // SignTransaction signs a transaction with the client's private key
 func (es *ghostClient) SignTransaction(tx *Transaction) (*types.Transaction, error) {
-	es.log.WithFields(logrus.Fields{
+	return es.signTransaction(es.account, tx)
+}
+
+// signTransaction fills in and signs tx with acc's private key
+func (es *ghostClient) signTransaction(acc *Account, tx *Transaction) (_ *types.Transaction, err error) {
+	l := es.accountLog(acc)
+	if len(tx.Metadata) > 0 {
+		l = l.WithField("metadata", tx.Metadata)
+	}
+	l.WithFields(logrus.Fields{
 		"from": tx.From.Hex(),
 		"to":   tx.To.Hex(),
 	}).Info("Starting transaction signing process")
 
+	// Validate fields before any network round trip
+	if err := tx.checkDeadline(); err != nil {
+		return nil, err
+	}
+	if es.config.StrictMode() && tx.Value == nil {
+		return nil, fmt.Errorf("invalid transaction: strict mode: value is nil")
+	}
+	if err := tx.Validate(); err != nil {
+		l.WithError(err).Error("Invalid transaction")
+		return nil, fmt.Errorf("invalid transaction: %w", err)
+	}
+	if es.config.RequireEIP1559() && tx.GasPrice != nil {
+		return nil, fmt.Errorf("invalid transaction: legacy gas price set but EIP-1559 is required")
+	}
+	if err := es.resolveRecipient(tx); err != nil {
+		l.WithError(err).Error("Invalid transaction")
+		return nil, fmt.Errorf("invalid transaction: %w", err)
+	}
+
+	// -- the approve must be mined before the transaction spending it, so it goes first
+	if es.autoApprove && tx.TokenSpend != nil {
+		if _, err := es.ensureAllowance(es.ctx, acc, tx.TokenSpend.Token, tx.To, tx.TokenSpend.Amount); err != nil {
+			return nil, err
+		}
+	}
+
 	// Get nonce if not provided
-	if tx.Nonce == 0 {
-		es.log.WithField("address", tx.From.Hex()).Info("Getting nonce for address")
-		nonce, err := es.client.PendingNonceAt(es.ctx, tx.From)
+	if tx.Nonce == 0 && es.nonces != nil {
+		var nonce uint64
+		nonce, err = es.nonces.Next(es.ctx, tx.From)
+		if err != nil {
+			l.WithError(err).Error("Failed to get nonce")
+			return nil, fmt.Errorf("failed to get nonce: %w", err)
+		}
+		// -- a transaction that is never signed would leave a gap, so re-read the nonce next time
+		defer func() {
+			if err != nil {
+				es.resyncNonce(tx.From, nonce)
+			}
+		}()
+		tx.Nonce = nonce
+		l.WithField("nonce", nonce).Info("Assigned tracked nonce")
+	} else if tx.Nonce == 0 {
+		l.WithField("address", tx.From.Hex()).Info("Getting nonce for address")
+		nonce, err := es.readClient().PendingNonceAt(es.ctx, tx.From)
 		if err != nil {
-			es.log.WithError(err).Error("Failed to get nonce")
+			l.WithError(err).Error("Failed to get nonce")
 			return nil, fmt.Errorf("failed to get nonce: %w", err)
 		}
 		tx.Nonce = nonce
-		es.log.WithField("nonce", nonce).Info("Got nonce")
+		l.WithField("nonce", nonce).Info("Got nonce")
+	}
+
+	if es.autoAccessList && tx.GasPrice == nil && len(tx.Data) > 0 && tx.AccessList == nil {
+		es.attachAccessList(tx)
 	}
 
 	// Estimate gas if not provided
 	if tx.GasLimit == 0 {
 		if err := es.estimateGasAndSetLimit(tx); err != nil {
-			es.log.WithError(err).Error("Failed to estimate gas")
+			l.WithError(err).Error("Failed to estimate gas")
+			return nil, err
+		}
+	} else if es.config.StrictMode() {
+		if err := es.checkBlockGasCap(tx.GasLimit); err != nil {
+			return nil, err
+		}
+	}
+
+	if es.config.StrictMode() || es.payableCheck {
+		if err := es.checkCanReceiveETH(tx); err != nil {
 			return nil, err
 		}
 	}
 
 	// Calulate fees based on network conditions
-	es.log.Info("Calculating optimal fees")
-	err := es.calculateOptimalFees(tx)
+	l.Info("Calculating optimal fees")
+	err = es.calculateOptimalFees(tx)
 	if err != nil {
-		es.log.WithError(err).Error("Failed to calculate fees")
+		l.WithError(err).Error("Failed to calculate fees")
 		return nil, fmt.Errorf("failed to calculate fees: %w", err)
 	}
 
+	ethereumTx, err := es.buildTx(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The round trips above can be slow; don't sign once the deadline has passed
+	if err := tx.checkDeadline(); err != nil {
+		return nil, err
+	}
+
+	// Sign the transaction
+	l.Info("Signing transaction")
+	signedTx, err := types.SignTx(ethereumTx, es.Signer(), acc.PrivateKey)
+	if err != nil {
+		l.WithError(err).Error("Failed to sign transaction")
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	es.metadata.put(signedTx.Hash(), tx.Metadata)
+	es.deadlines.put(signedTx.Hash(), tx.ValidUntil)
+	if err := es.recordTransaction(acc, signedTx, TxRecordSigned); err != nil {
+		l.WithError(err).Error("Failed to record signed transaction")
+		return nil, err
+	}
+	l.WithField("hash", signedTx.Hash().Hex()).Info("Transaction signed successfully")
+	return signedTx, nil
+}
+
+// buildTx creates the unsigned go-ethereum transaction for tx, EIP-1559 when both fee caps are
+// set and legacy when GasPrice is. A GasPrice transaction with an access list is built as EIP-2930.
+func (es *ghostClient) buildTx(tx *Transaction) (*types.Transaction, error) {
 	var ethereumTx *types.Transaction
 
 	if tx.MaxFeePerGas != nil && tx.MaxPriorityFeePerGas != nil {
@@ -262,67 +960,74 @@ func (es *ghostClient) SignTransaction(tx *Transaction) (*types.Transaction, err
 			"max_priority_fee_per_gas": tx.MaxPriorityFeePerGas.String(),
 		}).Info("Creating EIP-1559 transaction")
 		ethereumTx = types.NewTx(&types.DynamicFeeTx{
-			ChainID:   big.NewInt(es.chainId),
-			Nonce:     tx.Nonce,
-			GasTipCap: tx.MaxPriorityFeePerGas,
-			GasFeeCap: tx.MaxFeePerGas,
-			Gas:       tx.GasLimit,
-			To:        &tx.To,
-			Value:     tx.Value,
-			Data:      tx.Data,
+			ChainID:    big.NewInt(es.chainId),
+			Nonce:      tx.Nonce,
+			GasTipCap:  tx.MaxPriorityFeePerGas,
+			GasFeeCap:  tx.MaxFeePerGas,
+			Gas:        tx.GasLimit,
+			To:         tx.recipient(),
+			Value:      tx.Value,
+			Data:       tx.Data,
+			AccessList: tx.AccessList,
+		})
+	} else if tx.GasPrice != nil && len(tx.AccessList) > 0 {
+		// EIP-2930 transaction, so the access list survives legacy pricing
+		es.log.WithField("gas_price", tx.GasPrice.String()).Info("Creating access list transaction")
+		ethereumTx = types.NewTx(&types.AccessListTx{
+			ChainID:    big.NewInt(es.chainId),
+			Nonce:      tx.Nonce,
+			GasPrice:   tx.GasPrice,
+			Gas:        tx.GasLimit,
+			To:         tx.recipient(),
+			Value:      tx.Value,
+			Data:       tx.Data,
+			AccessList: tx.AccessList,
 		})
 	} else if tx.GasPrice != nil {
 		// Legacy transaction
 		es.log.WithField("gas_price", tx.GasPrice.String()).Info("Creating legacy transaction")
-		ethereumTx = types.NewTransaction(
-			tx.Nonce,
-			tx.To,
-			tx.Value,
-			tx.GasLimit,
-			tx.GasPrice,
-			tx.Data,
-		)
+		ethereumTx = types.NewTx(&types.LegacyTx{
+			Nonce:    tx.Nonce,
+			GasPrice: tx.GasPrice,
+			Gas:      tx.GasLimit,
+			To:       tx.recipient(),
+			Value:    tx.Value,
+			Data:     tx.Data,
+		})
 	} else {
 		es.log.Error("Transaction must specify either EIP-1559 fields or legacy GasPrice")
 		return nil, fmt.Errorf("transaction must specify either EIP-1559 fields (MaxFeePerGas, MaxPriorityFeePerGas) or legacy GasPrice")
 	}
-
-	// Sign the transaction
-	es.log.Info("Signing transaction")
-	signedTx, err := types.SignTx(ethereumTx, types.LatestSignerForChainID(big.NewInt(es.chainId)), es.account.PrivateKey)
-	if err != nil {
-		es.log.WithError(err).Error("Failed to sign transaction")
-		return nil, fmt.Errorf("failed to sign transaction: %w", err)
-	}
-
-	es.log.WithField("hash", signedTx.Hash().Hex()).Info("Transaction signed successfully")
-	return signedTx, nil
+	return ethereumTx, nil
 }
 
 // calculateOptimalFees calculates optimal gas fees based on network conditions
 func (es *ghostClient) calculateOptimalFees(tx *Transaction) error {
-	// Get latest header for base fee
-	header, err := es.client.HeaderByNumber(es.ctx, nil)
+	// Get the header carrying the base fee from the configured source
+	header, err := es.feeHeader(es.ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get latest header: %w", err)
+		return err
 	}
 
 	// Fix: group EIP-1559 condition to avoid nil pointer dereference
 	if header.BaseFee != nil && (tx.MaxFeePerGas == nil || tx.MaxPriorityFeePerGas == nil) {
 		es.log.Info("Using EIP-1559 fee calculation")
 		// EIP-1559 network - calculate optimal fees
-		// Use fixed priority fee based on network
-		tx.MaxPriorityFeePerGas = es.getFixedPriorityFee()
+		// Use the priority fee of the configured strategy (ETH_FEE_STRATEGY)
+		tx.MaxPriorityFeePerGas = es.priorityFee(es.ctx)
 
 		// Calculate max fee with room for base fee increases
 		maxFee := new(big.Int).Mul(header.BaseFee, big.NewInt(2)) // 2x base fee
 		maxFee.Add(maxFee, tx.MaxPriorityFeePerGas)
 		tx.MaxFeePerGas = maxFee
 	} else {
+		if header.BaseFee == nil && es.config.RequireEIP1559() {
+			return fmt.Errorf("chain has no base fee but EIP-1559 is required")
+		}
 		es.log.Info("Using legacy fee calculation")
 		// Legacy network - use gas price
 		if tx.GasPrice == nil {
-			gasPrice, err := es.client.SuggestGasPrice(es.ctx)
+			gasPrice, err := es.readClient().SuggestGasPrice(es.ctx)
 			if err != nil {
 				return fmt.Errorf("failed to get gas price: %w", err)
 			}
@@ -342,7 +1047,7 @@ func (es *ghostClient) getFixedPriorityFee() *big.Int {
 	case 8453: // Base
 		return es.config.PriorityFeeBase()
 	default:
-		return es.config.PriorityFeeDefault()
+		return es.config.PriorityFeeForChain(es.chainId)
 	}
 }
 
@@ -363,7 +1068,7 @@ func (es *ghostClient) validateFees(tx *Transaction) error {
 
 // GetBalance returns the ETH balance of an address
 func (es *ghostClient) GetBalance(address common.Address) (*big.Int, error) {
-	balance, err := es.client.BalanceAt(es.ctx, address, nil)
+	balance, err := es.readClient().BalanceAt(es.ctx, address, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get balance: %w", err)
 	}
@@ -371,59 +1076,230 @@ func (es *ghostClient) GetBalance(address common.Address) (*big.Int, error) {
 	return balance, nil
 }
 
-// waitForTransaction waits for a transaction to be mined
-func (es *ghostClient) waitForTransaction(hash common.Hash) (*TransactionReceipt, error) {
-	timeout := time.Duration(es.config.TransactionTimeoutSeconds()) * time.Second
-	tickerInterval := time.Duration(es.config.TransactionTickerSeconds()) * time.Second
+// CallContract runs an eth_call of data against the contract at to, at blockNumber or the latest
+// block when nil, with the account as sender. Nothing is signed, so public-key-only accounts can
+// call too. Callers ABI-encode the input (e.g. with EncodeCall) and decode the returned bytes
+// themselves; a revert is returned as an error.
+func (es *ghostClient) CallContract(to common.Address, data []byte, blockNumber *big.Int) ([]byte, error) {
+	out, err := es.readClient().CallContract(es.ctx, ethereum.CallMsg{From: es.account.Address, To: &to, Data: data}, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("call to %s failed: %w", to.Hex(), err)
+	}
+	return out, nil
+}
+
+// GetTokenBalance returns holder's balance of the ERC-20 token at tokenAddr by calling its
+// balanceOf. It only reads state, so holder needn't be an account the client can sign for.
+func (es *ghostClient) GetTokenBalance(tokenAddr common.Address, holder common.Address) (*big.Int, error) {
+	data, err := erc20ABI.Pack("balanceOf", holder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode balanceOf: %w", err)
+	}
+	out, err := es.readClient().CallContract(es.ctx, ethereum.CallMsg{To: &tokenAddr, Data: data}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balance of token %s: %w", tokenAddr.Hex(), err)
+	}
+	balance, err := unpackUint256(erc20ABI, "balanceOf", out)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode balance of token %s: %w", tokenAddr.Hex(), err)
+	}
+	return balance, nil
+}
+
+// CodeSize returns the length of the code deployed at an address, zero for externally owned accounts
+func (es *ghostClient) CodeSize(ctx context.Context, address common.Address) (int, error) {
+	code, err := es.readClient().CodeAt(ctx, address, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get code at %s: %w", address.Hex(), err)
+	}
+	return len(code), nil
+}
 
-	timeoutChan := time.After(timeout)
-	ticker := time.NewTicker(tickerInterval)
-	defer ticker.Stop()
+// HasTransacted reports whether address has sent any transaction, i.e. whether its nonce at the
+// latest block is non-zero. Pending transactions and incoming transfers don't count.
+func (es *ghostClient) HasTransacted(ctx context.Context, address common.Address) (bool, error) {
+	nonce, err := es.readClient().NonceAt(ctx, address, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to get nonce of %s: %w", address.Hex(), err)
+	}
+	return nonce > 0, nil
+}
 
-	for {
-		select {
-		case <-timeoutChan:
-			return nil, fmt.Errorf("transaction timeout: %s", hash.Hex())
-		case <-ticker.C:
-			receipt, err := es.GetTransactionReceipt(hash)
-			if err == nil {
-				return receipt, nil
-			}
-		}
+// VerifyContractCode reports whether the keccak256 hash of the code deployed at address matches
+// expectedHash. An address without code never matches.
+func (es *ghostClient) VerifyContractCode(ctx context.Context, address common.Address, expectedHash common.Hash) (bool, error) {
+	code, err := es.readClient().CodeAt(ctx, address, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to get code at %s: %w", address.Hex(), err)
+	}
+	if len(code) == 0 {
+		es.log.WithField("address", address.Hex()).Warn("No contract code deployed at address")
+		return false, nil
 	}
+
+	codeHash := crypto.Keccak256Hash(code)
+	if codeHash != expectedHash {
+		es.log.WithFields(logrus.Fields{
+			"address":  address.Hex(),
+			"expected": expectedHash.Hex(),
+			"actual":   codeHash.Hex(),
+		}).Warn("Contract code hash mismatch")
+		return false, nil
+	}
+	return true, nil
 }
 
 // GetTransactionReceipt returns the receipt for a transaction if it exists
 func (es *ghostClient) GetTransactionReceipt(hash common.Hash) (*TransactionReceipt, error) {
-	receipt, err := es.client.TransactionReceipt(es.ctx, hash)
+	return es.getTransactionReceipt(es.ctx, hash)
+}
+
+// getTransactionReceipt is GetTransactionReceipt bounded by ctx
+func (es *ghostClient) getTransactionReceipt(ctx context.Context, hash common.Hash) (*TransactionReceipt, error) {
+	receipt, err := es.readClient().TransactionReceipt(ctx, hash)
 	if err != nil {
 		return nil, fmt.Errorf("transaction not found or pending: %w", err)
 	}
 
-	// Get the transaction to find the To address
-	tx, _, err := es.client.TransactionByHash(es.ctx, hash)
+	// Light receipts skip the extra lookup and carry only status, block and gas data
+	var result *TransactionReceipt
+	if es.lightReceipts {
+		result = newTransactionReceipt(receipt, nil, common.Address{})
+	} else {
+		// Get the transaction to find the sender and the To address
+		tx, _, err := es.readClient().TransactionByHash(ctx, hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transaction: %w", err)
+		}
+		// -- the sender is left empty rather than guessed when it can't be recovered
+		from, err := types.Sender(es.Signer(), tx)
+		if err != nil {
+			es.log.WithError(err).WithField("hash", hash.Hex()).Warn("Failed to recover transaction sender")
+		}
+		result = newTransactionReceipt(receipt, tx, from)
+	}
+
+	if es.receiptTimes {
+		header, err := es.readClient().HeaderByHash(ctx, receipt.BlockHash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get block header for timestamp: %w", err)
+		}
+		result.Timestamp = header.Time
+	}
+	result.Metadata = es.metadata.get(hash)
+	return result, nil
+}
+
+// GetTransaction returns a mined or pending transaction by hash, with its sender recovered
+// and its EIP-2718 type set
+func (es *ghostClient) GetTransaction(ctx context.Context, hash common.Hash) (*Transaction, error) {
+	tx, _, err := es.readClient().TransactionByHash(ctx, hash)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get transaction: %w", err)
+		return nil, fmt.Errorf("transaction not found: %w", err)
 	}
+	from, err := types.Sender(es.Signer(), tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover sender of %s: %w", hash.Hex(), err)
+	}
+	result := newTransaction(tx, from)
+	result.Metadata = es.metadata.get(hash)
+	return result, nil
+}
 
-	return &TransactionReceipt{
-		TxHash:      receipt.TxHash,
-		Status:      receipt.Status,
-		BlockNumber: receipt.BlockNumber.Uint64(),
-		GasUsed:     receipt.GasUsed,
-		From:        es.account.Address, // Use known address
-		To:          *tx.To(),           // Get To address from transaction
-		Logs:        receipt.Logs,
-	}, nil
+// Signer returns the transaction signer for the connected chain. It is computed once at
+// construction, falling back to deriving it from the chain ID for clients built without one.
+func (es *ghostClient) Signer() types.Signer {
+	if es.signer == nil {
+		return types.LatestSignerForChainID(big.NewInt(es.chainId))
+	}
+	return es.signer
 }
 
 // Close closes the Ethereum client connection
 func (es *ghostClient) Close() {
+	if es.cancel != nil {
+		es.cancel()
+	}
+	es.async.Wait()
+
 	if es.ctx != nil {
 		es.ctx.Done() // Signal context cancellation
 		es.ctx = nil  // Prevent further use
 	}
-	if es.client != nil {
-		es.client.Close()
+	if client := es.readClient(); client != nil {
+		client.Close()
 	}
+	if es.writer != nil {
+		es.writer.Close()
+	}
+}
+
+// writeClient returns the client transactions are broadcast with
+func (es *ghostClient) writeClient() EthClient {
+	if es.writer != nil {
+		return es.writer
+	}
+	return es.readClient()
+}
+
+// readClient returns the client used for reads and subscriptions. It may be swapped for a
+// fresh connection after a websocket reconnect.
+func (es *ghostClient) readClient() EthClient {
+	es.clientMu.RLock()
+	defer es.clientMu.RUnlock()
+	return es.client
+}
+
+// rpcClient returns the raw JSON-RPC client for the read endpoint
+func (es *ghostClient) rpcClient() RPCClient {
+	es.clientMu.RLock()
+	defer es.clientMu.RUnlock()
+	return es.rpc
+}
+
+// useEthClient wires up a client supplied with WithEthClient after checking its chain ID. Head
+// subscriptions are used only if the client supports them.
+func useEthClient(ctx context.Context, es *ghostClient, chainId int64) error {
+	clientChainId, err := es.client.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get chain ID: %w", err)
+	}
+	if clientChainId.Int64() != chainId {
+		return fmt.Errorf("expected chain ID %d, got %d", chainId, clientChainId.Int64())
+	}
+
+	switch c := es.client.(type) {
+	case *ethclient.Client:
+		es.rpc = c.Client()
+		es.subscribeHeads = c.Client().SupportsSubscriptions()
+		return nil
+	case RPCClient:
+		es.rpc = c
+	default:
+		es.rpc = unsupportedRPC{}
+	}
+	es.subscribeHeads = supportsHeadSubscription(ctx, es.client)
+	return nil
+}
+
+// supportsHeadSubscription reports whether client can subscribe to new heads by subscribing once
+func supportsHeadSubscription(ctx context.Context, client EthClient) bool {
+	sub, err := client.SubscribeNewHead(ctx, make(chan *types.Header, 1))
+	if err != nil {
+		return false
+	}
+	sub.Unsubscribe()
+	return true
+}
+
+// unsupportedRPC stands in for the raw JSON-RPC client when the supplied EthClient has none,
+// answering every call as an unknown method
+type unsupportedRPC struct{}
+
+func (unsupportedRPC) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	return fmt.Errorf("method not found: %s is not available without a raw JSON-RPC client", method)
+}
+
+func (unsupportedRPC) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	return fmt.Errorf("method not found: batch calls are not available without a raw JSON-RPC client")
 }
@@ -6,24 +6,31 @@ import (
 	"errors"
 	"math/big"
 	"testing"
+	"time"
 
 	"io"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
 	"github.com/sirupsen/logrus"
+	logtest "github.com/sirupsen/logrus/hooks/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
 func testAccountAndConfig() (*Account, *config) {
+	privKey, _ := crypto.HexToECDSA("4f3edf983ac636a65a842ce7c78d9aa706d3b113b37e5a4d5e1e4e6a1f7a1e08") // test key
+	pubKey := privKey.Public().(*ecdsa.PublicKey)
 	accs := []*Account{
 		{
-			Address:    common.HexToAddress("0x0000000000000000000000000000000000000001"),
+			Address:    crypto.PubkeyToAddress(*pubKey),
+			PublicKey:  pubKey,
 			ChainId:    1,
 			Label:      "main",
-			PrivateKey: &ecdsa.PrivateKey{}, // dummy, not used for real signing
+			PrivateKey: privKey,
 		},
 	}
 	cfg := &config{chainId: 1, acounts: accs, rpcURL: "http://localhost:8545"}
@@ -72,6 +79,174 @@ func TestGhostClient_GetBalance_Error(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestGhostClient_GetTokenBalance(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	// -- a read-only account: public key only
+	readOnly := &Account{Address: acc.Address, PublicKey: acc.PublicKey, ChainId: 1}
+	token := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	wantData, err := erc20ABI.Pack("balanceOf", readOnly.Address)
+	assert.NoError(t, err)
+	isBalanceOf := mock.MatchedBy(func(msg ethereum.CallMsg) bool {
+		return msg.To != nil && *msg.To == token && string(msg.Data) == string(wantData)
+	})
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("CallContract", mock.Anything, isBalanceOf, (*big.Int)(nil)).
+		Return(common.LeftPadBytes(big.NewInt(1234567).Bytes(), 32), nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: readOnly,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	bal, err := gc.GetTokenBalance(token, readOnly.Address)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(1234567), bal)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_CallContract(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	// -- a read-only account: public key only
+	readOnly := &Account{Address: acc.Address, PublicKey: acc.PublicKey, ChainId: 1}
+	token := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	data, err := EncodeCall(erc20ABI, "totalSupply")
+	assert.NoError(t, err)
+	isTotalSupply := mock.MatchedBy(func(msg ethereum.CallMsg) bool {
+		return msg.From == readOnly.Address && msg.To != nil && *msg.To == token && string(msg.Data) == string(data)
+	})
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("CallContract", mock.Anything, isTotalSupply, big.NewInt(100)).
+		Return(common.LeftPadBytes(big.NewInt(1e6).Bytes(), 32), nil)
+	mockClient.On("CallContract", mock.Anything, isTotalSupply, (*big.Int)(nil)).
+		Return(nil, &testRPCError{code: 3, message: "execution reverted"})
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: readOnly,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	out, err := gc.CallContract(token, data, big.NewInt(100))
+	assert.NoError(t, err)
+	supply, err := unpackUint256(erc20ABI, "totalSupply", out)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(1e6), supply)
+
+	_, err = gc.CallContract(token, data, nil)
+	assert.ErrorContains(t, err, "execution reverted")
+	code, ok := RPCErrorCode(err)
+	assert.True(t, ok)
+	assert.Equal(t, 3, code)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_GetTokenBalance_Error(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	token := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("CallContract", mock.Anything, mock.Anything, (*big.Int)(nil)).Return([]byte{}, nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	// -- no return data, e.g. no contract at the address
+	_, err := gc.GetTokenBalance(token, acc.Address)
+	assert.Error(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_CodeSize(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	eoa := common.HexToAddress("0x01")
+	contract := common.HexToAddress("0x02")
+	mockClient.On("CodeAt", mock.Anything, eoa, (*big.Int)(nil)).Return([]byte{}, nil)
+	mockClient.On("CodeAt", mock.Anything, contract, (*big.Int)(nil)).Return([]byte{0x60, 0x80, 0x60, 0x40}, nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	size, err := gc.CodeSize(context.Background(), eoa)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, size)
+
+	size, err = gc.CodeSize(context.Background(), contract)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, size)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_HasTransacted(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	fresh := common.HexToAddress("0x01")
+	active := common.HexToAddress("0x02")
+	mockClient.On("NonceAt", mock.Anything, fresh, (*big.Int)(nil)).Return(uint64(0), nil)
+	mockClient.On("NonceAt", mock.Anything, active, (*big.Int)(nil)).Return(uint64(3), nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	transacted, err := gc.HasTransacted(context.Background(), fresh)
+	assert.NoError(t, err)
+	assert.False(t, transacted)
+
+	transacted, err = gc.HasTransacted(context.Background(), active)
+	assert.NoError(t, err)
+	assert.True(t, transacted)
+	mockClient.AssertNotCalled(t, "PendingNonceAt", mock.Anything, mock.Anything)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_VerifyContractCode(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	contract := common.HexToAddress("0x02")
+	eoa := common.HexToAddress("0x03")
+	code := []byte{0x60, 0x80, 0x60, 0x40, 0x52}
+	mockClient.On("CodeAt", mock.Anything, contract, (*big.Int)(nil)).Return(code, nil)
+	mockClient.On("CodeAt", mock.Anything, eoa, (*big.Int)(nil)).Return([]byte{}, nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	ok, err := gc.VerifyContractCode(context.Background(), contract, crypto.Keccak256Hash(code))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = gc.VerifyContractCode(context.Background(), contract, crypto.Keccak256Hash([]byte{0x60, 0x80}))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = gc.VerifyContractCode(context.Background(), eoa, crypto.Keccak256Hash(nil))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	mockClient.AssertExpectations(t)
+}
+
 func TestGhostClient_Close(t *testing.T) {
 	acc, cfg := testAccountAndConfig()
 	mockClient := &internalmocks.EthClient{}
@@ -142,6 +317,38 @@ func TestGhostClient_EstimateGasAndSetLimit_Complex(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestGhostClient_EstimateGasAndSetLimit_TransactionBuffer(t *testing.T) {
+	t.Setenv(envGasLimitBufferSimple, "1.5")
+	t.Setenv(envGasLimitBufferComplex, "1.5")
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(50000), nil)
+	header := &types.Header{GasLimit: 30000000}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(header, nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	// the per-transaction buffer wins over the configured one for both kinds of transaction
+	complexTx := &Transaction{From: acc.Address, To: acc.Address, Data: []byte{1, 2, 3}, GasLimitBuffer: 2.5}
+	assert.NoError(t, gc.estimateGasAndSetLimit(complexTx))
+	assert.Equal(t, uint64(125000), complexTx.GasLimit)
+
+	simpleTx := &Transaction{From: acc.Address, To: acc.Address, GasLimitBuffer: 2.0}
+	assert.NoError(t, gc.estimateGasAndSetLimit(simpleTx))
+	assert.Equal(t, uint64(100000), simpleTx.GasLimit)
+
+	// without one the configured buffer applies
+	configTx := &Transaction{From: acc.Address, To: acc.Address, Data: []byte{1, 2, 3}}
+	assert.NoError(t, gc.estimateGasAndSetLimit(configTx))
+	assert.Equal(t, uint64(75000), configTx.GasLimit)
+}
+
 func TestGhostClient_EstimateGasAndSetLimit_Errors(t *testing.T) {
 	acc, cfg := testAccountAndConfig()
 	mockClient := &internalmocks.EthClient{}
@@ -178,6 +385,102 @@ func TestGhostClient_EstimateGasAndSetLimit_Errors(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestGhostClient_EstimateGasAndSetLimit_Floor(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	// A tiny estimate is raised to the intrinsic transfer cost
+	mockClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(100), nil)
+	header := &types.Header{GasLimit: 30000000}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(header, nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	tx := &Transaction{
+		From: acc.Address,
+		To:   acc.Address,
+	}
+	err := gc.estimateGasAndSetLimit(tx)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(21000), tx.GasLimit)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_EstimateGasAndSetLimit_Zero(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(0), nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	tx := &Transaction{
+		From: acc.Address,
+		To:   acc.Address,
+	}
+	err := gc.estimateGasAndSetLimit(tx)
+	assert.ErrorContains(t, err, "computed gas limit is zero")
+	mockClient.AssertNotCalled(t, "HeaderByNumber", mock.Anything, mock.Anything)
+}
+
+func TestGhostClient_EstimateGasAndSetLimit_AbsoluteBuffer(t *testing.T) {
+	t.Setenv("ETH_GAS_LIMIT_BUFFER_ABSOLUTE", "5000")
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(100000), nil)
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{GasLimit: 300000}, nil).Once()
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	// estimate x 1.5 + 5000
+	tx := &Transaction{From: acc.Address, To: acc.Address, Data: []byte{0x01}, GasLimitBuffer: 1.5}
+	assert.NoError(t, gc.estimateGasAndSetLimit(tx))
+	assert.Equal(t, uint64(155000), tx.GasLimit)
+
+	// The combined limit is still capped at 2/3 of the block gas limit
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{GasLimit: 225000}, nil).Once()
+	tx = &Transaction{From: acc.Address, To: acc.Address, Data: []byte{0x01}, GasLimitBuffer: 1.5}
+	assert.ErrorContains(t, gc.estimateGasAndSetLimit(tx), "gas limit 155000 exceeds maximum allowed 150000")
+	mockClient.AssertExpectations(t)
+}
+
+func TestApplyGasBuffer(t *testing.T) {
+	tests := []struct {
+		estimated uint64
+		buffer    float64
+		absolute  uint64
+		want      uint64
+	}{
+		{21000, 1.0, 0, 21000},
+		{21000, 1.1, 5000, 28100},
+		{1000000, 1.2, 5000, 1205000},
+		{10000, 1.0, 5000, 21000}, // raised to the intrinsic cost
+		{10000, 1.0, 20000, 30000},
+	}
+	for _, tt := range tests {
+		got, err := applyGasBuffer(tt.estimated, tt.buffer, tt.absolute)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.want, got, "estimate %d x %.2f + %d", tt.estimated, tt.buffer, tt.absolute)
+	}
+
+	_, err := applyGasBuffer(0, 1.2, 5000)
+	assert.ErrorContains(t, err, "computed gas limit is zero")
+}
+
 func TestGhostClient_CalculateOptimalFees_EIP1559(t *testing.T) {
 	acc, cfg := testAccountAndConfig()
 	mockClient := &internalmocks.EthClient{}
@@ -231,6 +534,34 @@ func TestGhostClient_CalculateOptimalFees_Legacy(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+// Regression: with `BaseFee != nil && MaxFeePerGas == nil || MaxPriorityFeePerGas == nil` a nil
+// tip took the EIP-1559 branch on a legacy chain and dereferenced the nil base fee
+func TestGhostClient_CalculateOptimalFees_LegacyWithoutTip(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{BaseFee: nil}, nil)
+	mockClient.On("SuggestGasPrice", mock.Anything).Return(big.NewInt(12345), nil)
+	tx := &Transaction{
+		From:         acc.Address,
+		To:           acc.Address,
+		MaxFeePerGas: big.NewInt(50 * GWEI),
+	}
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	assert.NotPanics(t, func() {
+		assert.NoError(t, gc.calculateOptimalFees(tx))
+	})
+	assert.Equal(t, big.NewInt(12345), tx.GasPrice)
+	assert.Nil(t, tx.MaxPriorityFeePerGas)
+	mockClient.AssertExpectations(t)
+}
+
 func TestGhostClient_CalculateOptimalFees_HeaderError(t *testing.T) {
 	acc, cfg := testAccountAndConfig()
 	mockClient := &internalmocks.EthClient{}
@@ -302,11 +633,13 @@ func TestGhostClient_GetTransactionReceipt_Success(t *testing.T) {
 	mockClient := &internalmocks.EthClient{}
 	hash := common.HexToHash("0xabc")
 	receipt := &types.Receipt{
-		TxHash:      hash,
-		Status:      1,
-		BlockNumber: big.NewInt(123),
-		GasUsed:     21000,
-		Logs:        []*types.Log{},
+		TxHash:            hash,
+		Status:            1,
+		BlockNumber:       big.NewInt(123),
+		GasUsed:           21000,
+		CumulativeGasUsed: 1500000,
+		EffectiveGasPrice: big.NewInt(12 * GWEI),
+		Logs:              []*types.Log{},
 	}
 	to := common.HexToAddress("0x0000000000000000000000000000000000000002")
 	tx := types.NewTx(&types.DynamicFeeTx{
@@ -326,6 +659,34 @@ func TestGhostClient_GetTransactionReceipt_Success(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, to, result.To)
+	assert.Equal(t, big.NewInt(12*GWEI), result.EffectiveGasPrice)
+	assert.Equal(t, uint64(1500000), result.CumulativeGasUsed)
+	assert.Equal(t, big.NewInt(21000*12*GWEI), result.Fee())
+	mockClient.AssertExpectations(t)
+}
+
+// A transaction sent from another account reports that account as the sender, not the client's
+func TestGhostClient_GetTransactionReceipt_RecoversSender(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	treasury := testTreasuryAccount(t)
+	gc := &ghostClient{ctx: context.Background(), chainId: 1, account: acc, config: cfg, log: newTestLogger()}
+	signedTx, err := types.SignNewTx(treasury.PrivateKey, gc.Signer(), &types.DynamicFeeTx{
+		ChainID:   big.NewInt(1),
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(100),
+		Gas:       21000,
+		To:        &acc.Address,
+	})
+	assert.NoError(t, err)
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("TransactionReceipt", mock.Anything, signedTx.Hash()).
+		Return(&types.Receipt{TxHash: signedTx.Hash(), Status: 1, BlockNumber: big.NewInt(123)}, nil)
+	mockClient.On("TransactionByHash", mock.Anything, signedTx.Hash()).Return(signedTx, false, nil)
+	gc.client = mockClient
+
+	result, err := gc.GetTransactionReceipt(signedTx.Hash())
+	assert.NoError(t, err)
+	assert.Equal(t, treasury.Address, result.From)
 	mockClient.AssertExpectations(t)
 }
 
@@ -384,6 +745,78 @@ func TestGhostClient_SignTransaction_EIP1559_Success(t *testing.T) {
 	mockClient.AssertExpectations(t)
 }
 
+func TestGhostClient_SignTransaction_ContractCreation(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	initCode := []byte{0x60, 0x80, 0x60, 0x40}
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("PendingNonceAt", mock.Anything, acc.Address).Return(uint64(7), nil)
+	mockClient.On("EstimateGas", mock.Anything, mock.MatchedBy(func(msg ethereum.CallMsg) bool {
+		return msg.To == nil && string(msg.Data) == string(initCode)
+	})).Return(uint64(60000), nil)
+	header := &types.Header{GasLimit: 30000000, BaseFee: big.NewInt(100)}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(header, nil)
+	mockClient.On("SendTransaction", mock.Anything, mock.Anything).Return(nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	signedTx, err := gc.SignTransaction(&Transaction{From: acc.Address, IsContractCreation: true, Data: initCode})
+	assert.NoError(t, err)
+	assert.Nil(t, signedTx.To())
+	assert.Equal(t, uint64(72000), signedTx.Gas()) // complex buffer of 1.2
+
+	receipt, err := gc.SendTransaction(signedTx)
+	assert.NoError(t, err)
+	assert.Equal(t, common.Address{}, receipt.To)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_SignTransaction_LogsAccountLabel(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("PendingNonceAt", mock.Anything, acc.Address).Return(uint64(7), nil)
+	mockClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(21000), nil)
+	header := &types.Header{GasLimit: 30000000, BaseFee: big.NewInt(100)}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(header, nil)
+	logger, hook := logtest.NewNullLogger()
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     logger,
+	}
+
+	signedTx, err := gc.SignTransaction(&Transaction{From: acc.Address, To: acc.Address, Value: big.NewInt(1)})
+	assert.NoError(t, err)
+
+	var signed *logrus.Entry
+	for _, entry := range hook.AllEntries() {
+		if entry.Message == "Transaction signed successfully" {
+			signed = entry
+		}
+	}
+	if assert.NotNil(t, signed) {
+		assert.Equal(t, "main", signed.Data["account"])
+		assert.Equal(t, signedTx.Hash().Hex(), signed.Data["hash"])
+	}
+
+	// Unlabelled accounts don't get an empty field
+	hook.Reset()
+	gc.account = &Account{Address: acc.Address, ChainId: 1, PrivateKey: acc.PrivateKey}
+	_, err = gc.SignTransaction(&Transaction{From: acc.Address, To: acc.Address, Value: big.NewInt(1)})
+	assert.NoError(t, err)
+	for _, entry := range hook.AllEntries() {
+		assert.NotContains(t, entry.Data, "account")
+	}
+}
+
 func TestGhostClient_SignTransaction_Errors(t *testing.T) {
 	acc, cfg := testAccountAndConfig()
 	mockClient := &internalmocks.EthClient{}
@@ -419,10 +852,320 @@ func TestGhostClient_SignTransaction_Errors(t *testing.T) {
 	mockClient = &internalmocks.EthClient{}
 	mockClient.On("PendingNonceAt", mock.Anything, acc.Address).Return(uint64(2), nil)
 	mockClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(21000), nil)
-	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(nil, errors.New("fail header")).Once()
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(nil, errors.New("fail header"))
 	gc.client = mockClient
 	tx = &Transaction{From: acc.Address, To: acc.Address}
 	_, err = gc.SignTransaction(tx)
 	assert.Error(t, err)
 	mockClient.AssertExpectations(t)
 }
+
+func TestGhostClient_SignTransaction_NilValue(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("PendingNonceAt", mock.Anything, acc.Address).Return(uint64(3), nil)
+	mockClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(50000), nil)
+	header := &types.Header{GasLimit: 30000000, BaseFee: big.NewInt(100)}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(header, nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	tx := &Transaction{
+		From: acc.Address,
+		To:   acc.Address,
+		Data: []byte{0xa9, 0x05, 0x9c, 0xbb}, // contract call without value
+	}
+	signedTx, err := gc.SignTransaction(tx)
+	assert.NoError(t, err)
+	assert.NotNil(t, signedTx.Value())
+	assert.Equal(t, 0, signedTx.Value().Sign())
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_SignTransaction_NilData(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("PendingNonceAt", mock.Anything, acc.Address).Return(uint64(1), nil)
+	mockClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(21000), nil)
+	header := &types.Header{GasLimit: 30000000, BaseFee: big.NewInt(100)}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(header, nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	tx := &Transaction{
+		From:  acc.Address,
+		To:    acc.Address,
+		Value: big.NewInt(1),
+		Data:  nil, // plain transfer
+	}
+	signedTx, err := gc.SignTransaction(tx)
+	assert.NoError(t, err)
+	assert.NotNil(t, tx.Data)
+	assert.Empty(t, signedTx.Data())
+	// Default buffer for simple is 1.1, so expect 21000*1.1 = 23100
+	assert.Equal(t, uint64(23100), tx.GasLimit)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_ReadWriteSplit(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	readClient := &internalmocks.EthClient{}
+	writeClient := &internalmocks.EthClient{}
+	readClient.On("BalanceAt", mock.Anything, acc.Address, (*big.Int)(nil)).Return(big.NewInt(42), nil)
+	writeClient.On("SendTransaction", mock.Anything, mock.Anything).Return(nil)
+	gc := &ghostClient{
+		client:  readClient,
+		writer:  writeClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	_, err := gc.GetBalance(acc.Address)
+	assert.NoError(t, err)
+
+	to := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	_, err = gc.SendTransaction(types.NewTx(&types.DynamicFeeTx{To: &to}))
+	assert.NoError(t, err)
+
+	readClient.AssertExpectations(t)
+	writeClient.AssertExpectations(t)
+	readClient.AssertNotCalled(t, "SendTransaction", mock.Anything, mock.Anything)
+	writeClient.AssertNotCalled(t, "BalanceAt", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGhostClient_EstimateGasAndSetLimit_EstimateFrom(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	sponsor := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	mockClient.On("EstimateGas", mock.Anything, mock.MatchedBy(func(msg ethereum.CallMsg) bool {
+		return msg.From == sponsor
+	})).Return(uint64(21000), nil)
+	header := &types.Header{GasLimit: 30000000}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(header, nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	tx := &Transaction{
+		From:         acc.Address,
+		To:           acc.Address,
+		EstimateFrom: sponsor,
+	}
+	err := gc.estimateGasAndSetLimit(tx)
+	assert.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_SignTransaction_ToLabel(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	treasury := &Account{Address: common.HexToAddress("0x00000000000000000000000000000000000000aa"), ChainId: 1, Label: "treasury"}
+	cfg.acounts = append(cfg.acounts, treasury)
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("PendingNonceAt", mock.Anything, acc.Address).Return(uint64(1), nil)
+	mockClient.On("EstimateGas", mock.Anything, mock.MatchedBy(func(msg ethereum.CallMsg) bool {
+		return *msg.To == treasury.Address
+	})).Return(uint64(21000), nil)
+	header := &types.Header{GasLimit: 30000000, BaseFee: big.NewInt(100)}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(header, nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	signedTx, err := gc.SignTransaction(&Transaction{From: acc.Address, ToLabel: "Treasury", Value: big.NewInt(1)})
+	assert.NoError(t, err)
+	assert.Equal(t, treasury.Address, *signedTx.To())
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_SignTransaction_UnknownToLabel(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	_, err := gc.SignTransaction(&Transaction{From: acc.Address, ToLabel: "treasury", Value: big.NewInt(1)})
+	assert.ErrorContains(t, err, `unknown recipient label "treasury"`)
+
+	_, err = gc.SignTransaction(&Transaction{From: acc.Address, To: common.HexToAddress("0x01"), ToLabel: "main", Value: big.NewInt(1)})
+	assert.ErrorContains(t, err, "conflicting")
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_SignTransaction_RequireEIP1559(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{GasLimit: 30000000, BaseFee: big.NewInt(100)}, nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	legacyTx := func() *Transaction {
+		return &Transaction{From: acc.Address, To: acc.Address, Value: big.NewInt(1), Nonce: 1, GasLimit: 21000, GasPrice: big.NewInt(10)}
+	}
+
+	// without the flag a legacy-only transaction is accepted
+	_, err := gc.SignTransaction(legacyTx())
+	assert.NoError(t, err)
+
+	t.Setenv("ETH_REQUIRE_EIP1559", "true")
+	_, err = gc.SignTransaction(legacyTx())
+	assert.ErrorContains(t, err, "EIP-1559 is required")
+}
+
+func TestGhostClient_SignTransaction_RequireEIP1559_NoBaseFee(t *testing.T) {
+	t.Setenv("ETH_REQUIRE_EIP1559", "true")
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{GasLimit: 30000000}, nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	_, err := gc.SignTransaction(&Transaction{From: acc.Address, To: acc.Address, Value: big.NewInt(1), Nonce: 1, GasLimit: 21000})
+	assert.ErrorContains(t, err, "no base fee")
+	mockClient.AssertNotCalled(t, "SuggestGasPrice", mock.Anything)
+}
+
+// countingSigner records how often a signer is used to hash or recover transactions
+type countingSigner struct {
+	types.Signer
+	hashes  int
+	senders int
+}
+
+func (s *countingSigner) Hash(tx *types.Transaction) common.Hash {
+	s.hashes++
+	return s.Signer.Hash(tx)
+}
+
+func (s *countingSigner) Sender(tx *types.Transaction) (common.Address, error) {
+	s.senders++
+	return s.Signer.Sender(tx)
+}
+
+func TestGhostClient_Signer(t *testing.T) {
+	gc := &ghostClient{chainId: 137}
+	assert.Equal(t, big.NewInt(137), gc.Signer().ChainID())
+
+	cached := types.LatestSignerForChainID(big.NewInt(137))
+	gc.signer = cached
+	assert.Same(t, cached, gc.Signer())
+}
+
+func TestGhostClient_SignTransaction_UsesCachedSigner(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("PendingNonceAt", mock.Anything, acc.Address).Return(uint64(0), nil)
+	mockClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(21000), nil)
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{GasLimit: 30000000, BaseFee: big.NewInt(100)}, nil)
+	signer := &countingSigner{Signer: types.LatestSignerForChainID(big.NewInt(1))}
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+		signer:  signer,
+	}
+
+	signedTx, err := gc.SignTransaction(&Transaction{From: acc.Address, To: acc.Address, Value: big.NewInt(1)})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, signer.hashes)
+
+	from, err := types.Sender(gc.Signer(), signedTx)
+	assert.NoError(t, err)
+	assert.Equal(t, acc.Address, from)
+	assert.Equal(t, 1, signer.senders)
+}
+
+func TestGhostClient_SignTransaction_ValidUntil(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	// expired: rejected before any RPC call
+	expired := &Transaction{From: acc.Address, To: acc.Address, Value: big.NewInt(1), ValidUntil: time.Now().Add(-time.Minute)}
+	_, err := gc.SignTransaction(expired)
+	assert.ErrorIs(t, err, ErrExpired)
+	mockClient.AssertNotCalled(t, "PendingNonceAt", mock.Anything, mock.Anything)
+
+	// still valid: signed as usual
+	mockClient.On("PendingNonceAt", mock.Anything, acc.Address).Return(uint64(1), nil)
+	mockClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(21000), nil)
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{GasLimit: 30000000, BaseFee: big.NewInt(100)}, nil)
+	valid := &Transaction{From: acc.Address, To: acc.Address, Value: big.NewInt(1), ValidUntil: time.Now().Add(time.Hour)}
+	signedTx, err := gc.SignTransaction(valid)
+	assert.NoError(t, err)
+	assert.NotNil(t, signedTx)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_SendTransaction_ValidUntil(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("PendingNonceAt", mock.Anything, acc.Address).Return(uint64(1), nil)
+	mockClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(21000), nil)
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{GasLimit: 30000000, BaseFee: big.NewInt(100)}, nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	signedTx, err := gc.SignTransaction(&Transaction{From: acc.Address, To: acc.Address, Value: big.NewInt(1), ValidUntil: time.Now().Add(50 * time.Millisecond)})
+	assert.NoError(t, err)
+	time.Sleep(100 * time.Millisecond)
+
+	// -- the deadline passed between signing and sending
+	_, err = gc.SendTransaction(signedTx)
+	assert.ErrorIs(t, err, ErrExpired)
+	mockClient.AssertNotCalled(t, "SendTransaction", mock.Anything, mock.Anything)
+}
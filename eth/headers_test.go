@@ -0,0 +1,87 @@
+package eth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithRPCHeaders(t *testing.T) {
+	var mu sync.Mutex
+	var seen []http.Header
+	handler := testRPCHandler(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		seen = append(seen, r.Header.Clone())
+		mu.Unlock()
+		handler(w, r)
+	}))
+	defer srv.Close()
+
+	t.Setenv("ETH_RPC_HEADERS", "X-Api-Key: from-env, Authorization: Bearer env-token")
+	acc, cfg := testAccountAndConfig()
+	cfg.rpcURL = srv.URL
+	client, err := NewGhostClient(acc, cfg, newTestLogger(), WithRPCHeaders(map[string]string{
+		"authorization": "Bearer option-token",
+		"X-Tenant":      "ghost",
+	}))
+	assert.NoError(t, err)
+	defer client.Close()
+
+	_, err = client.GetBalance(common.HexToAddress("0x01"))
+	assert.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Len(t, seen, 2) // eth_chainId on dial, then eth_getBalance
+	for _, h := range seen {
+		assert.Equal(t, "from-env", h.Get("X-Api-Key"))
+		assert.Equal(t, "Bearer option-token", h.Get("Authorization"))
+		assert.Equal(t, "ghost", h.Get("X-Tenant"))
+	}
+}
+
+func TestNewGhostClient_AccountRPCURL(t *testing.T) {
+	t.Setenv("ETH_STRICT_MODE", "true")
+	var hits int
+	var mu sync.Mutex
+	handler := testRPCHandler(t)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		hits++
+		mu.Unlock()
+		handler(w, r)
+	}))
+	defer srv.Close()
+
+	// -- the configured chain and endpoint differ from the account's; strict mode doesn't object
+	acc, cfg := testAccountAndConfig()
+	cfg.chainId = 8453
+	cfg.rpcURL = "http://127.0.0.1:1"
+	acc.RPCURL = srv.URL
+	client, err := NewGhostClient(acc, cfg, newTestLogger())
+	assert.NoError(t, err)
+	defer client.Close()
+
+	_, err = client.GetBalance(common.HexToAddress("0x01"))
+	assert.NoError(t, err)
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, 2, hits) // eth_chainId on dial, then eth_getBalance
+}
+
+func TestNewGhostClient_AccountRPCURL_ChainMismatch(t *testing.T) {
+	srv := testRPCServer(t)
+	defer srv.Close()
+
+	// -- the account's endpoint must serve the account's chain
+	acc, cfg := testAccountAndConfig()
+	acc.ChainId = 8453
+	acc.RPCURL = srv.URL
+	_, err := NewGhostClient(acc, cfg, newTestLogger())
+	assert.ErrorContains(t, err, "expected chain ID 8453, got 1")
+}
@@ -0,0 +1,36 @@
+package eth
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Heartbeat sends a zero-value, no-data transaction from the account to itself with the intrinsic
+// 21000 gas and the configured fees. It exercises the whole signing and broadcast pipeline and
+// advances the nonce, e.g. for liveness monitoring. The returned receipt is pending.
+func (es *ghostClient) Heartbeat(ctx context.Context) (*TransactionReceipt, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	tx := &Transaction{
+		From:     es.account.Address,
+		To:       es.account.Address,
+		Value:    big.NewInt(0),
+		Data:     []byte{},
+		GasLimit: params.TxGas,
+	}
+	signedTx, err := es.SignTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt, err := es.SendTransaction(signedTx)
+	if err != nil {
+		return nil, err
+	}
+	es.log.WithField("hash", receipt.TxHash.Hex()).Info("Heartbeat sent")
+	return receipt, nil
+}
@@ -0,0 +1,48 @@
+package eth
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGhostClient_Heartbeat(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("PendingNonceAt", mock.Anything, acc.Address).Return(uint64(12), nil)
+	header := &types.Header{GasLimit: 30000000, BaseFee: big.NewInt(100)}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(header, nil)
+	var sent *types.Transaction
+	mockClient.On("SendTransaction", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { sent = args.Get(1).(*types.Transaction) }).
+		Return(nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	receipt, err := gc.Heartbeat(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, sent.Hash(), receipt.TxHash)
+
+	assert.Equal(t, acc.Address, *sent.To())
+	from, err := types.Sender(types.LatestSignerForChainID(big.NewInt(1)), sent)
+	assert.NoError(t, err)
+	assert.Equal(t, acc.Address, from)
+	assert.Equal(t, 0, sent.Value().Sign())
+	assert.Empty(t, sent.Data())
+	assert.Equal(t, uint64(21000), sent.Gas())
+	assert.Equal(t, uint64(12), sent.Nonce())
+	assert.Equal(t, cfg.PriorityFeeMainnet(), sent.GasTipCap())
+	mockClient.AssertNotCalled(t, "EstimateGas", mock.Anything, mock.Anything)
+	mockClient.AssertExpectations(t)
+}
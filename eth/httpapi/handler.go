@@ -0,0 +1,178 @@
+// Package httpapi exposes a GhostClient as a small JSON HTTP API, so services outside Go can use
+// it as a signing and broadcasting sidecar.
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/nando-os/ghost-eth/eth"
+)
+
+// maxBodyBytes bounds request bodies; transactions with very large calldata should go through the library
+const maxBodyBytes = 1 << 20
+
+// TransactionRequest is the body of POST /sign and POST /send. /sign takes a Transaction;
+// /send takes either a Transaction to sign and send, or Raw, a transaction already signed by /sign.
+type TransactionRequest struct {
+	Transaction *eth.Transaction `json:"transaction,omitempty"`
+	Raw         hexutil.Bytes    `json:"raw,omitempty"`
+}
+
+// SignResponse is the body returned by POST /sign
+type SignResponse struct {
+	Hash common.Hash   `json:"hash"`
+	Raw  hexutil.Bytes `json:"raw"` // RLP-encoded signed transaction, accepted by POST /send
+}
+
+// BalanceResponse is the body returned by GET /balance/{addr}
+type BalanceResponse struct {
+	Address common.Address `json:"address"`
+	Balance string         `json:"balance"` // wei, as a decimal string
+}
+
+// ErrorResponse is the body returned with any non-2xx status
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+type handler struct {
+	client eth.GhostClient
+}
+
+// NewHTTPHandler returns a handler serving:
+//
+//	POST /sign            sign a transaction without sending it
+//	POST /send            sign and send a transaction, or send a raw signed one
+//	GET  /balance/{addr}  ETH balance of an address
+//	GET  /receipt/{hash}  receipt of a mined transaction
+func NewHTTPHandler(client eth.GhostClient) http.Handler {
+	h := &handler{client: client}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /sign", h.sign)
+	mux.HandleFunc("POST /send", h.send)
+	mux.HandleFunc("GET /balance/{addr}", h.balance)
+	mux.HandleFunc("GET /receipt/{hash}", h.receipt)
+	return mux
+}
+
+func (h *handler) sign(w http.ResponseWriter, r *http.Request) {
+	req, err := decodeRequest(w, r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if req.Transaction == nil {
+		writeError(w, http.StatusBadRequest, errors.New("transaction is required"))
+		return
+	}
+
+	signedTx, err := h.client.SignTransaction(req.Transaction)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+	raw, err := signedTx.MarshalBinary()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to encode signed transaction: %w", err))
+		return
+	}
+	writeJSON(w, http.StatusOK, SignResponse{Hash: signedTx.Hash(), Raw: raw})
+}
+
+func (h *handler) send(w http.ResponseWriter, r *http.Request) {
+	req, err := decodeRequest(w, r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var signedTx *types.Transaction
+	switch {
+	case req.Transaction != nil && len(req.Raw) > 0:
+		writeError(w, http.StatusBadRequest, errors.New("provide either transaction or raw, not both"))
+		return
+	case len(req.Raw) > 0:
+		signedTx = new(types.Transaction)
+		if err := signedTx.UnmarshalBinary(req.Raw); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid raw transaction: %w", err))
+			return
+		}
+	case req.Transaction != nil:
+		if signedTx, err = h.client.SignTransaction(req.Transaction); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+	default:
+		writeError(w, http.StatusBadRequest, errors.New("transaction or raw is required"))
+		return
+	}
+
+	receipt, err := h.client.SendTransaction(signedTx)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, receipt)
+}
+
+func (h *handler) balance(w http.ResponseWriter, r *http.Request) {
+	addr := r.PathValue("addr")
+	if !common.IsHexAddress(addr) {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid address %q", addr))
+		return
+	}
+
+	address := common.HexToAddress(addr)
+	balance, err := h.client.GetBalance(address)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, BalanceResponse{Address: address, Balance: balance.String()})
+}
+
+func (h *handler) receipt(w http.ResponseWriter, r *http.Request) {
+	var hash common.Hash
+	if err := hash.UnmarshalText([]byte(r.PathValue("hash"))); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid transaction hash: %w", err))
+		return
+	}
+
+	receipt, err := h.client.GetTransactionReceipt(hash)
+	if errors.Is(err, ethereum.NotFound) {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, receipt)
+}
+
+func decodeRequest(w http.ResponseWriter, r *http.Request) (*TransactionRequest, error) {
+	var req TransactionRequest
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		return nil, fmt.Errorf("invalid request body: %w", err)
+	}
+	return &req, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, ErrorResponse{Error: err.Error()})
+}
@@ -0,0 +1,169 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/nando-os/ghost-eth/eth"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubClient implements the GhostClient methods the handler uses; any other call panics
+type stubClient struct {
+	eth.GhostClient
+	signed   []*eth.Transaction
+	sent     []*types.Transaction
+	balances map[common.Address]*big.Int
+	receipts map[common.Hash]*eth.TransactionReceipt
+}
+
+func (s *stubClient) SignTransaction(tx *eth.Transaction) (*types.Transaction, error) {
+	if tx.Value != nil && tx.Value.Sign() < 0 {
+		return nil, errors.New("invalid transaction: value is negative")
+	}
+	s.signed = append(s.signed, tx)
+	key, _ := crypto.HexToECDSA("4f3edf983ac636a65a842ce7c78d9aa706d3b113b37e5a4d5e1e4e6a1f7a1e08")
+	chainID := big.NewInt(1)
+	return types.SignNewTx(key, types.LatestSignerForChainID(chainID), &types.DynamicFeeTx{
+		ChainID: chainID, Nonce: tx.Nonce, To: &tx.To, Value: tx.Value, Gas: 21000,
+		GasFeeCap: big.NewInt(2), GasTipCap: big.NewInt(1),
+	})
+}
+
+func (s *stubClient) SendTransaction(signedTx *types.Transaction) (*eth.TransactionReceipt, error) {
+	s.sent = append(s.sent, signedTx)
+	return &eth.TransactionReceipt{TxHash: signedTx.Hash(), To: *signedTx.To()}, nil
+}
+
+func (s *stubClient) GetBalance(address common.Address) (*big.Int, error) {
+	if balance, ok := s.balances[address]; ok {
+		return balance, nil
+	}
+	return nil, errors.New("connection refused")
+}
+
+func (s *stubClient) GetTransactionReceipt(hash common.Hash) (*eth.TransactionReceipt, error) {
+	if receipt, ok := s.receipts[hash]; ok {
+		return receipt, nil
+	}
+	return nil, fmt.Errorf("transaction not found or pending: %w", ethereum.NotFound)
+}
+
+func do(t *testing.T, h http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+const testTransactionBody = `{"transaction":{"from":"0x0000000000000000000000000000000000000001","to":"0x0000000000000000000000000000000000000002","value":1000,"nonce":4}}`
+
+func TestHandler_Sign(t *testing.T) {
+	client := &stubClient{}
+	rec := do(t, NewHTTPHandler(client), http.MethodPost, "/sign", testTransactionBody)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var resp SignResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	signedTx := new(types.Transaction)
+	assert.NoError(t, signedTx.UnmarshalBinary(resp.Raw))
+	assert.Equal(t, resp.Hash, signedTx.Hash())
+	assert.Equal(t, big.NewInt(1000), signedTx.Value())
+
+	assert.Len(t, client.signed, 1)
+	assert.Equal(t, common.HexToAddress("0x02"), client.signed[0].To)
+	assert.Empty(t, client.sent)
+}
+
+func TestHandler_Sign_Errors(t *testing.T) {
+	h := NewHTTPHandler(&stubClient{})
+
+	rec := do(t, h, http.MethodPost, "/sign", `{"transaction":`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = do(t, h, http.MethodPost, "/sign", `{}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = do(t, h, http.MethodPost, "/sign", `{"transaction":{"value":-1}}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	var resp ErrorResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Contains(t, resp.Error, "value is negative")
+
+	rec = do(t, h, http.MethodGet, "/sign", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestHandler_Send(t *testing.T) {
+	client := &stubClient{}
+	h := NewHTTPHandler(client)
+
+	// sign and send in one call
+	rec := do(t, h, http.MethodPost, "/send", testTransactionBody)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	var receipt eth.TransactionReceipt
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &receipt))
+	assert.Equal(t, client.sent[0].Hash(), receipt.TxHash)
+
+	// send what /sign returned
+	raw, err := client.sent[0].MarshalBinary()
+	assert.NoError(t, err)
+	rec = do(t, h, http.MethodPost, "/send", fmt.Sprintf(`{"raw":%q}`, hexutil.Encode(raw)))
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Len(t, client.sent, 2)
+	assert.Len(t, client.signed, 1)
+
+	rec = do(t, h, http.MethodPost, "/send", `{"raw":"0x1234"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestHandler_Balance(t *testing.T) {
+	addr := common.HexToAddress("0x742d35Cc6634C0532925a3b8D4C9db96C4b4d8b6")
+	balance, _ := new(big.Int).SetString("123456789000000000000", 10)
+	h := NewHTTPHandler(&stubClient{balances: map[common.Address]*big.Int{addr: balance}})
+
+	rec := do(t, h, http.MethodGet, "/balance/"+addr.Hex(), "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var resp BalanceResponse
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, addr, resp.Address)
+	assert.Equal(t, "123456789000000000000", resp.Balance)
+
+	rec = do(t, h, http.MethodGet, "/balance/not-an-address", "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = do(t, h, http.MethodGet, "/balance/0x0000000000000000000000000000000000000009", "")
+	assert.Equal(t, http.StatusBadGateway, rec.Code)
+}
+
+func TestHandler_Receipt(t *testing.T) {
+	hash := common.HexToHash("0xabc")
+	h := NewHTTPHandler(&stubClient{receipts: map[common.Hash]*eth.TransactionReceipt{
+		hash: {TxHash: hash, Status: 1, BlockNumber: 101},
+	}})
+
+	rec := do(t, h, http.MethodGet, "/receipt/"+hash.Hex(), "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var receipt eth.TransactionReceipt
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &receipt))
+	assert.Equal(t, uint64(101), receipt.BlockNumber)
+
+	rec = do(t, h, http.MethodGet, "/receipt/"+common.HexToHash("0xdef").Hex(), "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = do(t, h, http.MethodGet, "/receipt/0x1234", "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
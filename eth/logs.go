@@ -0,0 +1,94 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/sirupsen/logrus"
+)
+
+// defaultLogBlockRange is the eth_getLogs block range tried before a provider's limit is known
+const defaultLogBlockRange = 10000
+
+// ScanLogs fetches the logs matching query between its FromBlock and ToBlock (both required) in
+// block range chunks, calling fn for each chunk in order. When the provider rejects a chunk as too
+// large, the range is halved and retried; the smaller range is remembered for later scans.
+// Returning an error from fn stops the scan.
+func (es *ghostClient) ScanLogs(ctx context.Context, query ethereum.FilterQuery, fn func([]types.Log) error) error {
+	if query.BlockHash != nil {
+		return errors.New("log scans need a block range, not a block hash")
+	}
+	if query.FromBlock == nil || query.ToBlock == nil {
+		return errors.New("log scans need both FromBlock and ToBlock")
+	}
+	if query.FromBlock.Sign() < 0 || query.ToBlock.Sign() < 0 {
+		return errors.New("log scans need concrete block numbers, not tags")
+	}
+	from, to := query.FromBlock.Uint64(), query.ToBlock.Uint64()
+	if from > to {
+		return fmt.Errorf("invalid block range: from %d is after to %d", from, to)
+	}
+
+	for from <= to {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		size := es.logBlockRange()
+		end := from + size - 1
+		if end > to || end < from { // clamp, including on overflow
+			end = to
+		}
+
+		chunk := query
+		chunk.FromBlock = new(big.Int).SetUint64(from)
+		chunk.ToBlock = new(big.Int).SetUint64(end)
+		logs, err := es.readClient().FilterLogs(ctx, chunk)
+		if err != nil {
+			if !isLogRangeError(err) || end == from {
+				return fmt.Errorf("failed to get logs for blocks %d-%d: %w", from, end, err)
+			}
+			es.shrinkLogBlockRange(end - from + 1)
+			continue
+		}
+
+		if err := fn(logs); err != nil {
+			return err
+		}
+		if end == to {
+			break
+		}
+		from = end + 1
+	}
+	return nil
+}
+
+// logBlockRange returns the block range to request, the learned provider limit if any
+func (es *ghostClient) logBlockRange() uint64 {
+	es.logRangeMu.Lock()
+	defer es.logRangeMu.Unlock()
+	if es.logRange == 0 {
+		return defaultLogBlockRange
+	}
+	return es.logRange
+}
+
+// shrinkLogBlockRange halves the range after a request of rejected blocks failed
+func (es *ghostClient) shrinkLogBlockRange(rejected uint64) {
+	es.logRangeMu.Lock()
+	defer es.logRangeMu.Unlock()
+	next := rejected / 2
+	if next == 0 {
+		next = 1
+	}
+	if es.logRange == 0 || next < es.logRange {
+		es.logRange = next
+	}
+	es.log.WithFields(logrus.Fields{
+		"rejected": rejected,
+		"range":    es.logRange,
+	}).Warn("Provider rejected log range, halving")
+}
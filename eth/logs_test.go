@@ -0,0 +1,99 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// testLogClient serves one log per eth_getLogs request, rejecting ranges above limit blocks;
+// requested ranges are appended to *ranges
+func testLogClient(limit uint64, ranges *[][2]uint64) *internalmocks.EthClient {
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("FilterLogs", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+			from, to := q.FromBlock.Uint64(), q.ToBlock.Uint64()
+			*ranges = append(*ranges, [2]uint64{from, to})
+			if to-from+1 > limit {
+				return nil, &testRPCError{code: -32005, message: "query returned more than 10000 results"}
+			}
+			return []types.Log{{BlockNumber: from}}, nil
+		})
+	return mockClient
+}
+
+func TestGhostClient_ScanLogs_HalvesRange(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	var ranges [][2]uint64
+	gc := &ghostClient{
+		client:  testLogClient(2500, &ranges),
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	token := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	query := ethereum.FilterQuery{FromBlock: big.NewInt(0), ToBlock: big.NewInt(9999), Addresses: []common.Address{token}}
+
+	var got []uint64
+	err := gc.ScanLogs(context.Background(), query, func(logs []types.Log) error {
+		for _, l := range logs {
+			got = append(got, l.BlockNumber)
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, [][2]uint64{
+		{0, 9999}, // rejected
+		{0, 4999}, // rejected
+		{0, 2499},
+		{2500, 4999},
+		{5000, 7499},
+		{7500, 9999},
+	}, ranges)
+	assert.Equal(t, []uint64{0, 2500, 5000, 7500}, got)
+
+	// the learned range is used straight away on the next scan
+	ranges = nil
+	query.FromBlock, query.ToBlock = big.NewInt(20000), big.NewInt(22999)
+	assert.NoError(t, gc.ScanLogs(context.Background(), query, func([]types.Log) error { return nil }))
+	assert.Equal(t, [][2]uint64{{20000, 22499}, {22500, 22999}}, ranges)
+}
+
+func TestGhostClient_ScanLogs_Errors(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	var ranges [][2]uint64
+	gc := &ghostClient{
+		client:  testLogClient(0, &ranges), // rejects even single blocks
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	noop := func([]types.Log) error { return nil }
+
+	err := gc.ScanLogs(context.Background(), ethereum.FilterQuery{FromBlock: big.NewInt(100), ToBlock: big.NewInt(103)}, noop)
+	assert.ErrorContains(t, err, "failed to get logs for blocks 100-100")
+	assert.Len(t, ranges, 3) // 4 blocks, 2, then 1
+
+	err = gc.ScanLogs(context.Background(), ethereum.FilterQuery{FromBlock: big.NewInt(100)}, noop)
+	assert.ErrorContains(t, err, "need both FromBlock and ToBlock")
+
+	// non-range errors are not retried
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("FilterLogs", mock.Anything, mock.Anything).Return(nil, errors.New("rate limited")).Once()
+	gc.client = mockClient
+	err = gc.ScanLogs(context.Background(), ethereum.FilterQuery{FromBlock: big.NewInt(0), ToBlock: big.NewInt(10)}, noop)
+	assert.ErrorContains(t, err, "rate limited")
+	mockClient.AssertExpectations(t)
+}
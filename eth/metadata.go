@@ -0,0 +1,91 @@
+package eth
+
+import (
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// maxTrackedMetadata bounds how many transactions' metadata the client remembers; the oldest
+// entries are dropped first
+const maxTrackedMetadata = 1024
+
+// hashRegistry remembers a value per transaction hash, dropping the oldest entries beyond
+// maxTrackedMetadata. The zero value is ready to use.
+type hashRegistry[V any] struct {
+	mu     sync.Mutex
+	byHash map[common.Hash]V
+	order  []common.Hash
+}
+
+// put records value for hash, replacing any earlier one
+func (r *hashRegistry[V]) put(hash common.Hash, value V) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.byHash == nil {
+		r.byHash = make(map[common.Hash]V)
+	}
+	if _, ok := r.byHash[hash]; !ok {
+		r.order = append(r.order, hash)
+	}
+	r.byHash[hash] = value
+	for len(r.order) > maxTrackedMetadata {
+		delete(r.byHash, r.order[0])
+		r.order = r.order[1:]
+	}
+}
+
+// get returns the value recorded for hash and whether there is one
+func (r *hashRegistry[V]) get(hash common.Hash) (V, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	value, ok := r.byHash[hash]
+	return value, ok
+}
+
+// metadataRegistry remembers the client-side Metadata of signed transactions by hash, so receipts
+// and confirmation updates for them can carry it. The zero value is ready to use.
+type metadataRegistry struct {
+	hashRegistry[map[string]string]
+}
+
+// put records a copy of metadata for hash; empty metadata is ignored
+func (r *metadataRegistry) put(hash common.Hash, metadata map[string]string) {
+	if len(metadata) == 0 {
+		return
+	}
+	r.hashRegistry.put(hash, maps.Clone(metadata))
+}
+
+// get returns a copy of the metadata recorded for hash, or nil
+func (r *metadataRegistry) get(hash common.Hash) map[string]string {
+	metadata, _ := r.hashRegistry.get(hash)
+	return maps.Clone(metadata)
+}
+
+// deadlineRegistry remembers the ValidUntil deadline of signed transactions by hash, so sending one
+// after its deadline fails. The zero value is ready to use.
+type deadlineRegistry struct {
+	hashRegistry[time.Time]
+}
+
+// put records deadline for hash; a zero deadline is ignored
+func (r *deadlineRegistry) put(hash common.Hash, deadline time.Time) {
+	if deadline.IsZero() {
+		return
+	}
+	r.hashRegistry.put(hash, deadline)
+}
+
+// check returns ErrExpired if the deadline recorded for hash has passed
+func (r *deadlineRegistry) check(hash common.Hash) error {
+	deadline, ok := r.hashRegistry.get(hash)
+	if ok && time.Now().After(deadline) {
+		return fmt.Errorf("%w: valid until %s", ErrExpired, deadline.Format(time.RFC3339))
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package eth
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGhostClient_TransactionMetadata(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient, heads := testAsyncClient(acc)
+	defer close(heads)
+	mockClient.On("TransactionReceipt", mock.Anything, mock.Anything).Return(
+		func(_ context.Context, hash common.Hash) *types.Receipt {
+			receipt, _ := testMinedTransaction(hash, 42)
+			return receipt
+		}, nil)
+	mockClient.On("TransactionByHash", mock.Anything, mock.Anything).Return(types.NewTx(&types.DynamicFeeTx{To: &acc.Address}), false, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	gc := &ghostClient{
+		client:         mockClient,
+		ctx:            ctx,
+		cancel:         cancel,
+		chainId:        1,
+		account:        acc,
+		config:         cfg,
+		log:            newTestLogger(),
+		subscribeHeads: true,
+	}
+
+	metadata := map[string]string{"invoice": "INV-1042", "user": "u-7"}
+	tx := &Transaction{From: acc.Address, To: acc.Address, Value: big.NewInt(1), Metadata: metadata}
+	future, err := gc.SendAsync(context.Background(), tx)
+	assert.NoError(t, err)
+	receipt, err := future.Wait(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, metadata, receipt.Metadata)
+
+	// The confirmation hook carries it too
+	var updates []ConfirmationUpdate
+	err = gc.WatchConfirmations(context.Background(), future.Hash(), 0, func(u ConfirmationUpdate) {
+		updates = append(updates, u)
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, updates, 1) {
+		assert.Equal(t, metadata, updates[0].Metadata)
+	}
+
+	// Callers can't change what was recorded through the input or a receipt
+	metadata["invoice"] = "changed"
+	receipt.Metadata["user"] = "changed"
+	fetched, err := gc.GetTransactionReceipt(future.Hash())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"invoice": "INV-1042", "user": "u-7"}, fetched.Metadata)
+}
+
+func TestMetadataRegistry_Bounded(t *testing.T) {
+	var r metadataRegistry
+	r.put(common.Hash{0x01}, nil)
+	assert.Nil(t, r.get(common.Hash{0x01}))
+
+	for i := 0; i <= maxTrackedMetadata; i++ {
+		r.put(common.BigToHash(big.NewInt(int64(i))), map[string]string{"n": big.NewInt(int64(i)).String()})
+	}
+	assert.Nil(t, r.get(common.BigToHash(big.NewInt(0))), "oldest entry should be evicted")
+	assert.Equal(t, map[string]string{"n": "1"}, r.get(common.BigToHash(big.NewInt(1))))
+	assert.Len(t, r.byHash, maxTrackedMetadata)
+}
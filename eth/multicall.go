@@ -0,0 +1,69 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// multicall3Address is the Multicall3 deployment, at the same address on mainnet, Base and most EVM chains
+var multicall3Address = common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+
+// multicall3ABIJSON covers the Multicall3 functions the client uses
+const multicall3ABIJSON = `[
+	{"type":"function","name":"aggregate3","stateMutability":"payable","inputs":[{"name":"calls","type":"tuple[]","components":[{"name":"target","type":"address"},{"name":"allowFailure","type":"bool"},{"name":"callData","type":"bytes"}]}],"outputs":[{"name":"returnData","type":"tuple[]","components":[{"name":"success","type":"bool"},{"name":"returnData","type":"bytes"}]}]},
+	{"type":"function","name":"getEthBalance","stateMutability":"view","inputs":[{"name":"addr","type":"address"}],"outputs":[{"name":"balance","type":"uint256"}]}
+]`
+
+// multicall3ABI is the parsed Multicall3 ABI
+var multicall3ABI = mustParseABI(multicall3ABIJSON)
+
+// multicall is a single call batched through aggregate3
+type multicall struct {
+	Target       common.Address
+	AllowFailure bool
+	CallData     []byte
+}
+
+// multicallResult is the outcome of one batched call
+type multicallResult struct {
+	Success    bool
+	ReturnData []byte
+}
+
+// aggregate executes calls in a single eth_call through Multicall3. Calls are allowed to fail
+// individually; callers check Success on each result.
+func (es *ghostClient) aggregate(ctx context.Context, calls []multicall) ([]multicallResult, error) {
+	data, err := multicall3ABI.Pack("aggregate3", calls)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode multicall: %w", err)
+	}
+
+	output, err := es.readClient().CallContract(ctx, ethereum.CallMsg{To: &multicall3Address, Data: data}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("multicall failed: %w", err)
+	}
+
+	unpacked, err := multicall3ABI.Unpack("aggregate3", output)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode multicall result: %w", err)
+	}
+	results := *abi.ConvertType(unpacked[0], new([]multicallResult)).(*[]multicallResult)
+	if len(results) != len(calls) {
+		return nil, fmt.Errorf("multicall returned %d results for %d calls", len(results), len(calls))
+	}
+	return results, nil
+}
+
+// unpackUint256 decodes a single uint256 return value
+func unpackUint256(contractABI abi.ABI, method string, data []byte) (*big.Int, error) {
+	out, err := contractABI.Unpack(method, data)
+	if err != nil {
+		return nil, err
+	}
+	return abi.ConvertType(out[0], new(big.Int)).(*big.Int), nil
+}
@@ -0,0 +1,198 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sirupsen/logrus"
+)
+
+// NonceManager hands out sequential nonces per address locally, so transactions signed in quick
+// succession don't all read the same pending nonce from the node. It tracks the next nonce to
+// hand out for each address, priming it from the network the first time an address is used.
+type NonceManager struct {
+	client nonceSource
+
+	mu   sync.Mutex
+	next map[common.Address]uint64
+}
+
+// nonceSource reads an address's pending nonce from the network
+type nonceSource interface {
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+}
+
+// pendingNonceFunc adapts a function to nonceSource
+type pendingNonceFunc func(ctx context.Context, account common.Address) (uint64, error)
+
+func (f pendingNonceFunc) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return f(ctx, account)
+}
+
+// NewNonceManager returns a NonceManager that primes unknown addresses from client's pending nonce
+func NewNonceManager(client EthClient) *NonceManager {
+	return &NonceManager{
+		client: client,
+		next:   make(map[common.Address]uint64),
+	}
+}
+
+// Next returns the nonce to use for addr's next transaction and advances the tracked value
+func (m *NonceManager) Next(ctx context.Context, addr common.Address) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	nonce, ok := m.next[addr]
+	if !ok {
+		pending, err := m.client.PendingNonceAt(ctx, addr)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get pending nonce for %s: %w", addr.Hex(), err)
+		}
+		nonce = pending
+	}
+	m.next[addr] = nonce + 1
+	return nonce, nil
+}
+
+// Reset forgets the tracked nonce for addr so the next call re-reads it from the network
+func (m *NonceManager) Reset(addr common.Address) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.next, addr)
+}
+
+// rollback hands nonce out again for addr if it was the last nonce handed out, reporting whether it
+// did. Once a later nonce has been handed out, rolling back would give that one out twice, so the
+// tracked value is kept.
+func (m *NonceManager) rollback(addr common.Address, nonce uint64) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if next, ok := m.next[addr]; !ok || next != nonce+1 {
+		return false
+	}
+	m.next[addr] = nonce
+	return true
+}
+
+// reserve marks nonce as in use by addr, raising the tracked value past it. It does nothing for an
+// untracked addr, which primes from the network anyway.
+func (m *NonceManager) reserve(addr common.Address, nonce uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if next, ok := m.next[addr]; ok && next <= nonce {
+		m.next[addr] = nonce + 1
+	}
+}
+
+// peek returns the next nonce tracked for addr without advancing it
+func (m *NonceManager) peek(addr common.Address) (uint64, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	nonce, ok := m.next[addr]
+	return nonce, ok
+}
+
+// resetAll forgets every tracked nonce
+func (m *NonceManager) resetAll() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	clear(m.next)
+}
+
+// Snapshot returns the next nonce to hand out for each tracked address, for persisting across restarts
+func (m *NonceManager) Snapshot() map[common.Address]uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state := make(map[common.Address]uint64, len(m.next))
+	for addr, nonce := range m.next {
+		state[addr] = nonce
+	}
+	return state
+}
+
+// Restore loads state produced by Snapshot. Restored values never move a tracked nonce backwards;
+// call Reconcile afterwards to account for transactions sent since the snapshot was taken.
+func (m *NonceManager) Restore(state map[common.Address]uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for addr, nonce := range state {
+		if current, ok := m.next[addr]; !ok || nonce > current {
+			m.next[addr] = nonce
+		}
+	}
+}
+
+// Reconcile raises each tracked nonce to the network's pending nonce where the chain is ahead,
+// keeping the larger of the stored and on-chain values so no nonce is ever reused
+func (m *NonceManager) Reconcile(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for addr, nonce := range m.next {
+		pending, err := m.client.PendingNonceAt(ctx, addr)
+		if err != nil {
+			return fmt.Errorf("failed to get pending nonce for %s: %w", addr.Hex(), err)
+		}
+		if pending > nonce {
+			m.next[addr] = pending
+		}
+	}
+	return nil
+}
+
+// newNonceManager returns a NonceManager priming from the read endpoint, following reconnects
+func (es *ghostClient) newNonceManager() *NonceManager {
+	return &NonceManager{
+		client: pendingNonceFunc(func(ctx context.Context, account common.Address) (uint64, error) {
+			return es.readClient().PendingNonceAt(ctx, account)
+		}),
+		next: make(map[common.Address]uint64),
+	}
+}
+
+// ResetNonce forgets the locally tracked nonces, so the next transaction of each account re-reads
+// its pending nonce from the network. Use it after sending transactions from the same account
+// outside this client, or after one was dropped. It does nothing unless ETH_NONCE_MANAGER is set.
+func (es *ghostClient) ResetNonce() {
+	if es.nonces == nil {
+		return
+	}
+	es.nonces.resetAll()
+	es.log.Info("Reset tracked nonces")
+}
+
+// resyncNonce hands nonce out again after a transaction of addr using it failed to go out, so the
+// next transaction doesn't leave a gap. If later nonces were already handed out the tracked value
+// is kept, as resetting it could hand those out twice, and the gap is logged instead.
+func (es *ghostClient) resyncNonce(addr common.Address, nonce uint64) {
+	if es.nonces == nil || es.nonces.rollback(addr, nonce) {
+		return
+	}
+	es.log.WithFields(logrus.Fields{
+		"address": addr.Hex(),
+		"nonce":   nonce,
+	}).Warn("Nonce of failed transaction left a gap, later nonces are already in use")
+}
+
+// ProjectNonce returns the nonce of the transaction addr sends after pendingTxCount more, i.e. the
+// pending nonce plus pendingTxCount, so schedulers can pre-assign nonces to queued transactions.
+// With ETH_NONCE_MANAGER set, an address's tracked nonce is used in place of the network's.
+func (es *ghostClient) ProjectNonce(ctx context.Context, addr common.Address, pendingTxCount int) (uint64, error) {
+	if pendingTxCount < 0 {
+		return 0, fmt.Errorf("pending transaction count must not be negative, got %d", pendingTxCount)
+	}
+	if es.nonces != nil {
+		if nonce, ok := es.nonces.peek(addr); ok {
+			return nonce + uint64(pendingTxCount), nil
+		}
+	}
+	nonce, err := es.readClient().PendingNonceAt(ctx, addr)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get pending nonce for %s: %w", addr.Hex(), err)
+	}
+	return nonce + uint64(pendingTxCount), nil
+}
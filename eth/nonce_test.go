@@ -0,0 +1,324 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNonceManager_Next(t *testing.T) {
+	mockClient := &internalmocks.EthClient{}
+	addr := common.HexToAddress("0x01")
+	mockClient.On("PendingNonceAt", mock.Anything, addr).Return(uint64(5), nil).Once()
+
+	m := NewNonceManager(mockClient)
+	for want := uint64(5); want < 8; want++ {
+		nonce, err := m.Next(context.Background(), addr)
+		assert.NoError(t, err)
+		assert.Equal(t, want, nonce)
+	}
+	mockClient.AssertExpectations(t)
+}
+
+func TestNonceManager_SnapshotRestore(t *testing.T) {
+	mockClient := &internalmocks.EthClient{}
+	a := common.HexToAddress("0x01")
+	b := common.HexToAddress("0x02")
+	mockClient.On("PendingNonceAt", mock.Anything, a).Return(uint64(3), nil).Once()
+	mockClient.On("PendingNonceAt", mock.Anything, b).Return(uint64(10), nil).Once()
+
+	m := NewNonceManager(mockClient)
+	_, _ = m.Next(context.Background(), a)
+	_, _ = m.Next(context.Background(), a)
+	_, _ = m.Next(context.Background(), b)
+
+	snapshot := m.Snapshot()
+	assert.Equal(t, map[common.Address]uint64{a: 5, b: 11}, snapshot)
+
+	// mutating the snapshot doesn't affect the manager
+	snapshot[a] = 100
+	assert.Equal(t, uint64(5), m.Snapshot()[a])
+
+	// a fresh manager picks up where the old one stopped without asking the network
+	restored := NewNonceManager(&internalmocks.EthClient{})
+	restored.Restore(map[common.Address]uint64{a: 5, b: 11})
+	nonce, err := restored.Next(context.Background(), a)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), nonce)
+	mockClient.AssertExpectations(t)
+}
+
+func TestNonceManager_Reconcile(t *testing.T) {
+	mockClient := &internalmocks.EthClient{}
+	behind := common.HexToAddress("0x01")
+	ahead := common.HexToAddress("0x02")
+	// transactions were sent for behind after the snapshot; ahead's stored nonce hasn't propagated
+	mockClient.On("PendingNonceAt", mock.Anything, behind).Return(uint64(9), nil)
+	mockClient.On("PendingNonceAt", mock.Anything, ahead).Return(uint64(2), nil)
+
+	m := NewNonceManager(mockClient)
+	m.Restore(map[common.Address]uint64{behind: 4, ahead: 7})
+	assert.NoError(t, m.Reconcile(context.Background()))
+
+	assert.Equal(t, map[common.Address]uint64{behind: 9, ahead: 7}, m.Snapshot())
+	mockClient.AssertExpectations(t)
+}
+
+func TestNonceManager_ReconcileError(t *testing.T) {
+	mockClient := &internalmocks.EthClient{}
+	addr := common.HexToAddress("0x01")
+	mockClient.On("PendingNonceAt", mock.Anything, addr).Return(uint64(0), errors.New("connection refused"))
+
+	m := NewNonceManager(mockClient)
+	m.Restore(map[common.Address]uint64{addr: 4})
+	assert.Error(t, m.Reconcile(context.Background()))
+	assert.Equal(t, uint64(4), m.Snapshot()[addr])
+}
+
+func TestGhostClient_SignTransaction_NonceManager(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	// -- read once, however many transactions are signed
+	mockClient.On("PendingNonceAt", mock.Anything, acc.Address).Return(uint64(7), nil).Once()
+	mockClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(21000), nil)
+	header := &types.Header{GasLimit: 30000000, BaseFee: big.NewInt(100)}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(header, nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	gc.nonces = gc.newNonceManager()
+
+	const count = 5
+	var wg sync.WaitGroup
+	nonces := make([]uint64, count)
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			signedTx, err := gc.SignTransaction(&Transaction{From: acc.Address, To: acc.Address, Value: big.NewInt(1)})
+			if assert.NoError(t, err) {
+				nonces[i] = signedTx.Nonce()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	sort.Slice(nonces, func(i, j int) bool { return nonces[i] < nonces[j] })
+	assert.Equal(t, []uint64{7, 8, 9, 10, 11}, nonces)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_ResetNonce(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("PendingNonceAt", mock.Anything, acc.Address).Return(uint64(7), nil).Once()
+	mockClient.On("PendingNonceAt", mock.Anything, acc.Address).Return(uint64(12), nil).Once()
+	mockClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(21000), nil)
+	header := &types.Header{GasLimit: 30000000, BaseFee: big.NewInt(100)}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(header, nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	gc.nonces = gc.newNonceManager()
+
+	signedTx, err := gc.SignTransaction(&Transaction{From: acc.Address, To: acc.Address, Value: big.NewInt(1)})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7), signedTx.Nonce())
+
+	// -- five transactions went out from another process in the meantime
+	gc.ResetNonce()
+	signedTx, err = gc.SignTransaction(&Transaction{From: acc.Address, To: acc.Address, Value: big.NewInt(1)})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(12), signedTx.Nonce())
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_ResetNonce_Disabled(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	gc := &ghostClient{
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	assert.NotPanics(t, gc.ResetNonce)
+}
+
+func TestGhostClient_NonceManager_ResyncsAfterFailedSend(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("PendingNonceAt", mock.Anything, acc.Address).Return(uint64(7), nil).Once()
+	mockClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(21000), nil)
+	header := &types.Header{GasLimit: 30000000, BaseFee: big.NewInt(100)}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(header, nil)
+	mockClient.On("SendTransaction", mock.Anything, mock.Anything).Return(errors.New("connection reset")).Once()
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	gc.nonces = gc.newNonceManager()
+
+	signedTx, err := gc.SignTransaction(&Transaction{From: acc.Address, To: acc.Address, Value: big.NewInt(1)})
+	assert.NoError(t, err)
+	_, err = gc.SendTransaction(signedTx)
+	assert.Error(t, err)
+
+	// -- nonce 7 never went out, so it is handed out again rather than leaving a gap
+	signedTx, err = gc.SignTransaction(&Transaction{From: acc.Address, To: acc.Address, Value: big.NewInt(2)})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7), signedTx.Nonce())
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_NonceManager_ReusesNonceAfterFailedSigning(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("PendingNonceAt", mock.Anything, acc.Address).Return(uint64(7), nil).Once()
+	mockClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(0), errors.New("connection reset")).Once()
+	mockClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(21000), nil)
+	header := &types.Header{GasLimit: 30000000, BaseFee: big.NewInt(100)}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(header, nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	gc.nonces = gc.newNonceManager()
+
+	_, err := gc.SignTransaction(&Transaction{From: acc.Address, To: acc.Address, Value: big.NewInt(1), Data: []byte{0x01}})
+	assert.Error(t, err)
+
+	signedTx, err := gc.SignTransaction(&Transaction{From: acc.Address, To: acc.Address, Value: big.NewInt(1), Data: []byte{0x01}})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7), signedTx.Nonce())
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_NonceManager_KeepsLaterNonces(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("PendingNonceAt", mock.Anything, acc.Address).Return(uint64(7), nil).Once()
+	mockClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(21000), nil)
+	header := &types.Header{GasLimit: 30000000, BaseFee: big.NewInt(100)}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(header, nil)
+	mockClient.On("SendTransaction", mock.Anything, mock.Anything).Return(errors.New("connection reset")).Once()
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	gc.nonces = gc.newNonceManager()
+
+	first, err := gc.SignTransaction(&Transaction{From: acc.Address, To: acc.Address, Value: big.NewInt(1)})
+	assert.NoError(t, err)
+	second, err := gc.SignTransaction(&Transaction{From: acc.Address, To: acc.Address, Value: big.NewInt(2)})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(8), second.Nonce())
+	_, err = gc.SendTransaction(first)
+	assert.Error(t, err)
+
+	// -- nonce 8 is already in use, so handing out 7 again would lead to 8 being handed out twice
+	third, err := gc.SignTransaction(&Transaction{From: acc.Address, To: acc.Address, Value: big.NewInt(3)})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(9), third.Nonce())
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_ProjectNonce(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	addr := common.HexToAddress("0x01")
+	mockClient.On("PendingNonceAt", mock.Anything, addr).Return(uint64(5), nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	nonce, err := gc.ProjectNonce(context.Background(), addr, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), nonce)
+
+	nonce, err = gc.ProjectNonce(context.Background(), addr, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(8), nonce)
+
+	_, err = gc.ProjectNonce(context.Background(), addr, -1)
+	assert.ErrorContains(t, err, "must not be negative")
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_ProjectNonce_NonceManager(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	addr := common.HexToAddress("0x01")
+	// -- the network hasn't seen the two transactions handed out locally yet
+	mockClient.On("PendingNonceAt", mock.Anything, addr).Return(uint64(5), nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	gc.nonces = gc.newNonceManager()
+	_, _ = gc.nonces.Next(context.Background(), addr)
+	_, _ = gc.nonces.Next(context.Background(), addr)
+
+	nonce, err := gc.ProjectNonce(context.Background(), addr, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(9), nonce)
+	mockClient.AssertNumberOfCalls(t, "PendingNonceAt", 1)
+}
+
+func TestGhostClient_ProjectNonce_Error(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	addr := common.HexToAddress("0x01")
+	mockClient.On("PendingNonceAt", mock.Anything, addr).Return(uint64(0), errors.New("connection refused"))
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	_, err := gc.ProjectNonce(context.Background(), addr, 1)
+	assert.ErrorContains(t, err, "connection refused")
+}
@@ -0,0 +1,79 @@
+package eth
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/sirupsen/logrus"
+)
+
+// SignBatchOffline signs fully populated transactions without any network call, e.g. to prepare
+// payouts on an air-gapped machine. Each transaction must carry its nonce, gas limit and fees
+// (GasPrice, or both MaxFeePerGas and MaxPriorityFeePerGas); nothing is estimated or looked up.
+// Results and errors are returned per transaction, in the order of txs.
+func (es *ghostClient) SignBatchOffline(txs []*Transaction) ([]*types.Transaction, []error) {
+	signed := make([]*types.Transaction, len(txs))
+	errs := make([]error, len(txs))
+	for i, tx := range txs {
+		signed[i], errs[i] = es.signOffline(tx)
+	}
+
+	var failed int
+	for _, err := range errs {
+		if err != nil {
+			failed++
+		}
+	}
+	es.log.WithFields(logrus.Fields{"transactions": len(txs), "failed": failed}).Info("Offline batch signing complete")
+	return signed, errs
+}
+
+// signOffline checks that tx needs nothing from the network and signs it
+func (es *ghostClient) signOffline(tx *Transaction) (*types.Transaction, error) {
+	if tx == nil {
+		return nil, errors.New("invalid transaction: nil")
+	}
+	if err := tx.checkDeadline(); err != nil {
+		return nil, err
+	}
+	if err := tx.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid transaction: %w", err)
+	}
+	if err := es.resolveRecipient(tx); err != nil {
+		return nil, fmt.Errorf("invalid transaction: %w", err)
+	}
+
+	// -- everything SignTransaction would otherwise fetch must be present
+	if tx.GasLimit == 0 {
+		return nil, errors.New("invalid transaction: gas limit must be set for offline signing")
+	}
+	if tx.GasPrice == nil && (tx.MaxFeePerGas == nil || tx.MaxPriorityFeePerGas == nil) {
+		return nil, errors.New("invalid transaction: fees must be set for offline signing (GasPrice, or MaxFeePerGas and MaxPriorityFeePerGas)")
+	}
+	if es.config.RequireEIP1559() && tx.GasPrice != nil {
+		return nil, errors.New("invalid transaction: legacy gas price set but EIP-1559 is required")
+	}
+	if tx.ChainID != nil && tx.ChainID.Cmp(big.NewInt(es.chainId)) != 0 {
+		return nil, fmt.Errorf("invalid transaction: chain ID %s does not match client chain %d", tx.ChainID, es.chainId)
+	}
+	if err := es.validateFees(tx); err != nil {
+		return nil, fmt.Errorf("invalid transaction: %w", err)
+	}
+
+	ethereumTx, err := es.buildTx(tx)
+	if err != nil {
+		return nil, err
+	}
+	signedTx, err := types.SignTx(ethereumTx, es.Signer(), es.account.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	es.metadata.put(signedTx.Hash(), tx.Metadata)
+	es.deadlines.put(signedTx.Hash(), tx.ValidUntil)
+	if err := es.recordTransaction(es.account, signedTx, TxRecordSigned); err != nil {
+		return nil, err
+	}
+	return signedTx, nil
+}
@@ -0,0 +1,103 @@
+package eth
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGhostClient_SignBatchOffline(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	to := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	txs := []*Transaction{
+		{From: acc.Address, To: to, Value: big.NewInt(1), Nonce: 0, GasLimit: 21000, MaxFeePerGas: big.NewInt(30 * GWEI), MaxPriorityFeePerGas: big.NewInt(GWEI)},
+		{From: acc.Address, To: to, Value: big.NewInt(2), Nonce: 1, GasLimit: 21000, MaxFeePerGas: big.NewInt(30 * GWEI), MaxPriorityFeePerGas: big.NewInt(GWEI)},
+		{From: acc.Address, To: to, Value: big.NewInt(3), Nonce: 2, GasLimit: 21000, GasPrice: big.NewInt(20 * GWEI)},
+	}
+
+	signed, errs := gc.SignBatchOffline(txs)
+	assert.Len(t, signed, 3)
+	for i, tx := range signed {
+		assert.NoError(t, errs[i])
+		from, err := types.Sender(types.LatestSignerForChainID(big.NewInt(1)), tx)
+		assert.NoError(t, err)
+		assert.Equal(t, acc.Address, from)
+		assert.Equal(t, uint64(i), tx.Nonce())
+		assert.Equal(t, txs[i].Value, tx.Value())
+	}
+	assert.Equal(t, uint8(types.DynamicFeeTxType), signed[0].Type())
+	assert.Equal(t, uint8(types.LegacyTxType), signed[2].Type())
+	assert.Empty(t, mockClient.Calls)
+}
+
+func TestGhostClient_SignBatchOffline_ContractCreation(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	gc := &ghostClient{
+		client:  &internalmocks.EthClient{},
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	initCode := []byte{0x60, 0x80, 0x60, 0x40}
+	txs := []*Transaction{
+		{From: acc.Address, IsContractCreation: true, Data: initCode, GasLimit: 100000, MaxFeePerGas: big.NewInt(30 * GWEI), MaxPriorityFeePerGas: big.NewInt(GWEI)},
+		{From: acc.Address, IsContractCreation: true, Data: initCode, Nonce: 1, GasLimit: 100000, GasPrice: big.NewInt(20 * GWEI)},
+	}
+
+	signed, errs := gc.SignBatchOffline(txs)
+	for i, tx := range signed {
+		assert.NoError(t, errs[i])
+		assert.Nil(t, tx.To())
+		assert.Equal(t, initCode, tx.Data())
+	}
+	assert.Equal(t, uint8(types.DynamicFeeTxType), signed[0].Type())
+	assert.Equal(t, uint8(types.LegacyTxType), signed[1].Type())
+
+	// -- a fetched creation converts back with the flag set
+	assert.True(t, newTransaction(signed[0], acc.Address).IsContractCreation)
+}
+
+func TestGhostClient_SignBatchOffline_Incomplete(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	to := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	txs := []*Transaction{
+		{From: acc.Address, To: to, Nonce: 3, MaxFeePerGas: big.NewInt(GWEI), MaxPriorityFeePerGas: big.NewInt(GWEI)},
+		{From: acc.Address, To: to, Nonce: 4, GasLimit: 21000, MaxFeePerGas: big.NewInt(GWEI)},
+		{From: acc.Address, To: to, Nonce: 5, GasLimit: 21000, GasPrice: big.NewInt(GWEI), ChainID: big.NewInt(8453)},
+		{From: acc.Address, To: to, Nonce: 6, GasLimit: 21000, GasPrice: big.NewInt(GWEI)},
+	}
+
+	signed, errs := gc.SignBatchOffline(txs)
+	assert.ErrorContains(t, errs[0], "gas limit must be set")
+	assert.ErrorContains(t, errs[1], "fees must be set")
+	assert.ErrorContains(t, errs[2], "does not match client chain")
+	assert.NoError(t, errs[3])
+	assert.Nil(t, signed[0])
+	assert.NotNil(t, signed[3])
+	assert.Empty(t, mockClient.Calls)
+}
@@ -0,0 +1,165 @@
+package eth
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Option customizes a GhostClient created with NewGhostClient
+type Option func(*ghostClient)
+
+// WithLightReceipts skips the TransactionByHash lookup that GetTransactionReceipt and
+// WaitForTransaction use to populate To, halving the RPC calls per confirmation.
+// Receipts then carry only hash, status, block, gas and logs.
+func WithLightReceipts() Option {
+	return func(es *ghostClient) {
+		es.lightReceipts = true
+	}
+}
+
+// WithReceiptTimestamps sets TransactionReceipt.Timestamp on receipts returned by
+// GetTransactionReceipt and WaitForTransaction, at the cost of one header lookup per receipt.
+func WithReceiptTimestamps() Option {
+	return func(es *ghostClient) {
+		es.receiptTimes = true
+	}
+}
+
+// WithRPCDebug writes every outgoing JSON-RPC request and its response or error to w, for
+// debugging provider-specific behaviour. Only HTTP endpoints are covered; websocket traffic is
+// not captured.
+func WithRPCDebug(w io.Writer) Option {
+	return func(es *ghostClient) {
+		es.rpcDebug = w
+	}
+}
+
+// WithEthClient makes NewGhostClient use client for reads and writes instead of dialing the
+// configured RPC URLs, e.g. the in-memory client of package ethtest. The client must serve the
+// account's chain and is used for head subscriptions when it supports them, otherwise waits poll.
+// Raw JSON-RPC features such as batching, access lists and the txpool are only available when
+// client also implements RPCClient.
+func WithEthClient(client EthClient) Option {
+	return func(es *ghostClient) {
+		es.client = client
+	}
+}
+
+// WithBroadcastCheck makes SendTransaction confirm that the provider knows a transaction after
+// accepting it, polling eth_getTransactionByHash on the write endpoint for up to window. A
+// transaction that doesn't show up fails with ErrBroadcastNotAccepted.
+func WithBroadcastCheck(window time.Duration) Option {
+	return func(es *ghostClient) {
+		es.broadcastCheck = window
+	}
+}
+
+// WithRPCHeaders sets headers sent with every RPC request, over HTTP and in the websocket
+// handshake, e.g. an Authorization header so API keys stay out of endpoint URLs. They are merged
+// with ETH_RPC_HEADERS, taking precedence on conflicts.
+func WithRPCHeaders(headers map[string]string) Option {
+	return func(es *ghostClient) {
+		if es.rpcHeaders == nil {
+			es.rpcHeaders = http.Header{}
+		}
+		for name, value := range headers {
+			es.rpcHeaders.Set(name, value)
+		}
+	}
+}
+
+// WithErrorClassifier sets a function mapping provider-specific errors to the package's typed
+// errors (ErrNonceTooLow, ErrInsufficientFunds, ...), consulted before the built-in message
+// patterns. It returns the typed error for errors it recognizes and nil otherwise.
+func WithErrorClassifier(classify func(error) error) Option {
+	return func(es *ghostClient) {
+		es.errorClassifier = classify
+	}
+}
+
+// WithAutoApprove makes every method that signs a Transaction (SignTransaction,
+// SendTransactionWithAccount, SendAsync, ...) check the sender's allowance for its TokenSpend and,
+// when it is too low, send and wait for an approve of the spent amount before signing the
+// transaction itself. With unlimited set the approval is for the maximum uint256 instead.
+func WithAutoApprove(unlimited bool) Option {
+	return func(es *ghostClient) {
+		es.autoApprove = true
+		es.approveUnlimited = unlimited
+	}
+}
+
+// WithPayableCheck simulates every transaction sending ETH to a contract before signing it and
+// refuses, with ErrNonPayableRecipient, those the contract would revert because it can't accept
+// the value. Strict mode always performs this check.
+func WithPayableCheck() Option {
+	return func(es *ghostClient) {
+		es.payableCheck = true
+	}
+}
+
+// WithFeeFallback makes SendTransaction re-sign an EIP-1559 transaction from the client's account
+// as legacy, priced at the node's suggested gas price, and broadcast it again when the provider
+// rejects it with ErrTxTypeNotSupported. A transaction with an access list is re-signed as an
+// EIP-2930 transaction instead, and the gas price may not exceed the original fee cap or
+// ETH_MAX_FEE_PER_GAS. The returned receipt then carries the new hash. It has no effect when
+// ETH_REQUIRE_EIP1559 is set.
+func WithFeeFallback() Option {
+	return func(es *ghostClient) {
+		es.feeFallback = true
+	}
+}
+
+// WithAutoAccessList makes SignTransaction request an access list with eth_createAccessList for
+// contract calls that don't carry one, and attach it when the gas estimate with the list is lower
+// than without. On chains without EIP-1559 the list is sent in an EIP-2930 transaction. Plain
+// transfers and transactions with an explicit GasPrice are left alone.
+func WithAutoAccessList() Option {
+	return func(es *ghostClient) {
+		es.autoAccessList = true
+	}
+}
+
+// WithBaseFeeGuard makes SendTransaction re-read the base fee right before broadcasting an
+// EIP-1559 transaction from the client's account and, when it has risen above the transaction's
+// MaxFeePerGas, re-sign it at twice the current base fee plus its tip, capped at
+// ETH_MAX_FEE_PER_GAS. The returned receipt then carries the new hash. Sending fails with
+// ErrBaseFeeAboveCeiling when the base fee is above the ceiling.
+func WithBaseFeeGuard() Option {
+	return func(es *ghostClient) {
+		es.baseFeeGuard = true
+	}
+}
+
+// WithTxStore makes the client keep an audit record of every transaction it signs in store,
+// updated when the transaction is sent and when WaitForTransaction (or SendTransactionAndWait)
+// sees it mined, so a service can reconstruct its state after a crash. Signing fails if the
+// signed transaction can't be recorded; later failures are logged, as the transaction is out.
+func WithTxStore(store TxStore) Option {
+	return func(es *ghostClient) {
+		es.txStore = store
+	}
+}
+
+// WithTransferGas makes SignTransaction skip eth_estimateGas for plain ETH transfers, those
+// without data or an access list, to externally owned accounts, and use 21000 gas with
+// ETH_GAS_LIMIT_BUFFER_SIMPLE instead. Whether a recipient has code is cached for a minute, so a
+// contract deployed at a cached address in that window is still treated as an account.
+func WithTransferGas() Option {
+	return func(es *ghostClient) {
+		es.transferGas = true
+	}
+}
+
+// WithPreSendHook sets a function called with every signed transaction right before it is
+// broadcast, including a transaction re-signed by the base fee guard or the legacy fee fallback,
+// e.g. for human approval, policy checks or rate limits. When it returns an error the transaction
+// isn't sent and the send fails with that error wrapped. The hook runs on the sending goroutine,
+// so a slow approval holds up that send only.
+func WithPreSendHook(hook func(*types.Transaction) error) Option {
+	return func(es *ghostClient) {
+		es.preSendHook = hook
+	}
+}
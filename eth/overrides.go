@@ -0,0 +1,113 @@
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/sirupsen/logrus"
+)
+
+// StateOverride replaces parts of an account's state for the duration of a call. State replaces
+// the whole storage while StateDiff patches individual slots; set at most one of them.
+type StateOverride struct {
+	Balance   *big.Int
+	Nonce     *uint64
+	Code      []byte
+	State     map[common.Hash]common.Hash
+	StateDiff map[common.Hash]common.Hash
+}
+
+// MarshalJSON encodes the override in the hex form expected by eth_estimateGas and eth_call
+func (o StateOverride) MarshalJSON() ([]byte, error) {
+	type override struct {
+		Balance   *hexutil.Big                `json:"balance,omitempty"`
+		Nonce     *hexutil.Uint64             `json:"nonce,omitempty"`
+		Code      hexutil.Bytes               `json:"code,omitempty"`
+		State     map[common.Hash]common.Hash `json:"state,omitempty"`
+		StateDiff map[common.Hash]common.Hash `json:"stateDiff,omitempty"`
+	}
+	return json.Marshal(override{
+		Balance:   (*hexutil.Big)(o.Balance),
+		Nonce:     (*hexutil.Uint64)(o.Nonce),
+		Code:      o.Code,
+		State:     o.State,
+		StateDiff: o.StateDiff,
+	})
+}
+
+// EstimateGasWithOverrides estimates gas for tx as if the given account state overrides applied,
+// e.g. a token balance or allowance that doesn't exist yet. It returns the raw node estimate
+// without the configured buffer.
+func (es *ghostClient) EstimateGasWithOverrides(ctx context.Context, tx *Transaction, overrides map[common.Address]StateOverride) (uint64, error) {
+	if err := tx.Validate(); err != nil {
+		return 0, fmt.Errorf("invalid transaction: %w", err)
+	}
+
+	var estimate hexutil.Uint64
+	err := es.rpcClient().CallContext(ctx, &estimate, "eth_estimateGas", toCallArg(tx), "latest", overrides)
+	if err != nil {
+		if overridesUnsupported(err) {
+			return 0, fmt.Errorf("%w: %v", ErrStateOverridesUnsupported, err)
+		}
+		es.log.WithError(err).Error("Failed to estimate gas with state overrides")
+		return 0, fmt.Errorf("failed to estimate gas: %w", err)
+	}
+
+	es.log.WithFields(logrus.Fields{
+		"estimated": uint64(estimate),
+		"overrides": len(overrides),
+	}).Info("Gas estimated with state overrides")
+	return uint64(estimate), nil
+}
+
+// toCallArg builds the JSON-RPC call object for tx, mirroring ethclient's encoding of CallMsg
+func toCallArg(tx *Transaction) map[string]interface{} {
+	from := tx.From
+	if tx.EstimateFrom != (common.Address{}) {
+		from = tx.EstimateFrom
+	}
+	arg := map[string]interface{}{
+		"from": from,
+		"to":   tx.recipient(),
+	}
+	if len(tx.Data) > 0 {
+		arg["input"] = hexutil.Bytes(tx.Data)
+	}
+	if tx.Value != nil {
+		arg["value"] = (*hexutil.Big)(tx.Value)
+	}
+	if tx.GasLimit != 0 {
+		arg["gas"] = hexutil.Uint64(tx.GasLimit)
+	}
+	if tx.GasPrice != nil {
+		arg["gasPrice"] = (*hexutil.Big)(tx.GasPrice)
+	}
+	if tx.MaxFeePerGas != nil {
+		arg["maxFeePerGas"] = (*hexutil.Big)(tx.MaxFeePerGas)
+	}
+	if tx.MaxPriorityFeePerGas != nil {
+		arg["maxPriorityFeePerGas"] = (*hexutil.Big)(tx.MaxPriorityFeePerGas)
+	}
+	if tx.AccessList != nil {
+		arg["accessList"] = tx.AccessList
+	}
+	return arg
+}
+
+// overridesUnsupported reports whether err shows the provider rejected the state override parameter
+func overridesUnsupported(err error) bool {
+	if isMethodNotFound(err) {
+		return true
+	}
+	if code, ok := RPCErrorCode(err); ok && code == -32602 {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "too many arguments") ||
+		strings.Contains(msg, "override") && strings.Contains(msg, "not supported")
+}
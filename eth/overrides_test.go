@@ -0,0 +1,80 @@
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGhostClient_EstimateGasWithOverrides(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockRPC := &internalmocks.RPCClient{}
+	token := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	slot := common.HexToHash("0x01")
+	nonce := uint64(7)
+	overrides := map[common.Address]StateOverride{
+		acc.Address: {Balance: big.NewInt(1000000000000000000), Nonce: &nonce},
+		token:       {StateDiff: map[common.Hash]common.Hash{slot: common.HexToHash("0xff")}},
+	}
+
+	var sent []byte
+	mockRPC.On("CallContext", mock.Anything, mock.Anything, "eth_estimateGas", mock.Anything, "latest", mock.Anything).
+		Run(func(args mock.Arguments) {
+			var err error
+			sent, err = json.Marshal(args.Get(5))
+			assert.NoError(t, err)
+			*args.Get(1).(*hexutil.Uint64) = 52000
+		}).
+		Return(nil).Once()
+	gc := &ghostClient{
+		rpc:     mockRPC,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	tx := &Transaction{From: acc.Address, To: token, Data: []byte{0xa9, 0x05, 0x9c, 0xbb}}
+	gas, err := gc.EstimateGasWithOverrides(context.Background(), tx, overrides)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(52000), gas)
+
+	var decoded map[common.Address]map[string]interface{}
+	assert.NoError(t, json.Unmarshal(sent, &decoded))
+	own := decoded[acc.Address]
+	assert.Equal(t, "0xde0b6b3a7640000", own["balance"])
+	assert.Equal(t, "0x7", own["nonce"])
+	assert.NotContains(t, own, "code")
+	diff := decoded[token]["stateDiff"].(map[string]interface{})
+	assert.Equal(t, common.HexToHash("0xff").Hex(), diff[slot.Hex()])
+	mockRPC.AssertExpectations(t)
+}
+
+func TestGhostClient_EstimateGasWithOverrides_Unsupported(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockRPC := &internalmocks.RPCClient{}
+	mockRPC.On("CallContext", mock.Anything, mock.Anything, "eth_estimateGas", mock.Anything, "latest", mock.Anything).
+		Return(&testRPCError{code: -32602, message: "too many arguments, want at most 2"})
+	gc := &ghostClient{
+		rpc:     mockRPC,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	tx := &Transaction{From: acc.Address, To: common.HexToAddress("0x02")}
+	_, err := gc.EstimateGasWithOverrides(context.Background(), tx, map[common.Address]StateOverride{
+		acc.Address: {Balance: big.NewInt(1)},
+	})
+	assert.ErrorIs(t, err, ErrStateOverridesUnsupported)
+}
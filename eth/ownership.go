@@ -0,0 +1,37 @@
+package eth
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ProveOwnership signs a server-issued challenge (typically a random nonce) with the account key
+// as an EIP-191 personal message, for a backend to check with VerifyOwnership
+func (es *ghostClient) ProveOwnership(challenge []byte) ([]byte, error) {
+	if len(challenge) == 0 {
+		return nil, errors.New("challenge is empty")
+	}
+	signature, err := signEIP191(es.account.PrivateKey, challenge)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign challenge: %w", err)
+	}
+	es.log.WithField("address", es.account.Address.Hex()).Info("Signed ownership challenge")
+	return signature, nil
+}
+
+// VerifyOwnership reports whether signature is addr's EIP-191 personal signature of challenge, as
+// produced by ProveOwnership or a wallet's personal_sign. A well-formed signature by another
+// address returns false; a malformed one returns an error. Callers should issue a fresh challenge
+// per attempt so signatures can't be replayed.
+func VerifyOwnership(addr common.Address, challenge, signature []byte) (bool, error) {
+	if len(challenge) == 0 {
+		return false, errors.New("challenge is empty")
+	}
+	signer, err := recoverEIP191(challenge, signature)
+	if err != nil {
+		return false, err
+	}
+	return signer == addr, nil
+}
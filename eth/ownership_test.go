@@ -0,0 +1,61 @@
+package eth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGhostClient_ProveOwnership(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	gc := &ghostClient{
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	challenge := []byte("Sign in to example.com\nNonce: 8f3a1c2e")
+
+	signature, err := gc.ProveOwnership(challenge)
+	assert.NoError(t, err)
+	assert.Len(t, signature, 65)
+	assert.Contains(t, []byte{27, 28}, signature[64])
+
+	ok, err := VerifyOwnership(acc.Address, challenge, signature)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// Matches what a wallet's personal_sign produces for the same key
+	walletSig, err := crypto.Sign(accounts.TextHash(challenge), acc.PrivateKey)
+	assert.NoError(t, err)
+	ok, err = VerifyOwnership(acc.Address, challenge, walletSig) // V as 0/1
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	_, err = gc.ProveOwnership(nil)
+	assert.Error(t, err)
+}
+
+func TestVerifyOwnership_Negative(t *testing.T) {
+	acc, _ := testAccountAndConfig()
+	other := testTreasuryAccount(t)
+	challenge := []byte("nonce-1234")
+	signature, err := signEIP191(other.PrivateKey, challenge)
+	assert.NoError(t, err)
+
+	ok, err := VerifyOwnership(acc.Address, challenge, signature)
+	assert.NoError(t, err)
+	assert.False(t, ok, "signature by another key must not verify")
+
+	ok, err = VerifyOwnership(other.Address, []byte("nonce-5678"), signature)
+	assert.NoError(t, err)
+	assert.False(t, ok, "signature over another challenge must not verify")
+
+	_, err = VerifyOwnership(acc.Address, challenge, hexutil.MustDecode("0x1234"))
+	assert.ErrorContains(t, err, "signature must be 65 bytes")
+}
@@ -0,0 +1,158 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/sirupsen/logrus"
+)
+
+// erc2612ABIJSON covers the ERC-2612 views SignPermit reads, plus the optional version()
+const erc2612ABIJSON = `[
+	{"type":"function","name":"nonces","stateMutability":"view","inputs":[{"name":"owner","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"DOMAIN_SEPARATOR","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"bytes32"}]},
+	{"type":"function","name":"version","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"string"}]}
+]`
+
+// erc2612ABI is the parsed ERC-2612 ABI
+var erc2612ABI = mustParseABI(erc2612ABIJSON)
+
+// PermitSignature is a signed ERC-2612 permit, ready to pass to the token's
+// permit(owner, spender, value, deadline, v, r, s)
+type PermitSignature struct {
+	Owner    common.Address `json:"owner"`
+	Spender  common.Address `json:"spender"`
+	Value    *big.Int       `json:"value"`
+	Nonce    *big.Int       `json:"nonce"`
+	Deadline *big.Int       `json:"deadline"`
+	V        uint8          `json:"v"`
+	R        common.Hash    `json:"r"`
+	S        common.Hash    `json:"s"`
+}
+
+// SignPermit signs an ERC-2612 permit letting spender transfer value of token from the account
+// until deadline (a Unix time). It reads the token's name, the account's permit nonce and the
+// token's DOMAIN_SEPARATOR, and checks that the domain it signs over matches the token's, trying
+// the token's version() and then versions "1" and "2". Tokens without nonces or DOMAIN_SEPARATOR,
+// or with a domain it can't reproduce (e.g. DAI's pre-standard permit), fail with
+// ErrPermitUnsupported.
+func (es *ghostClient) SignPermit(ctx context.Context, token common.Address, spender common.Address, value *big.Int, deadline *big.Int) (*PermitSignature, error) {
+	if value == nil || deadline == nil {
+		return nil, errors.New("permit value and deadline are required")
+	}
+	owner := es.account.Address
+
+	name, err := es.callToken(ctx, token, erc20ABI, "name")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read name of token %s: %w", token.Hex(), err)
+	}
+	nonce, err := es.callToken(ctx, token, erc2612ABI, "nonces", owner)
+	if err != nil {
+		return nil, fmt.Errorf("%w: nonces of token %s: %v", ErrPermitUnsupported, token.Hex(), err)
+	}
+	separator, err := es.callToken(ctx, token, erc2612ABI, "DOMAIN_SEPARATOR")
+	if err != nil {
+		return nil, fmt.Errorf("%w: DOMAIN_SEPARATOR of token %s: %v", ErrPermitUnsupported, token.Hex(), err)
+	}
+
+	versions := []string{"1", "2"}
+	if version, err := es.callToken(ctx, token, erc2612ABI, "version"); err == nil {
+		versions = append([]string{version.(string)}, versions...)
+	}
+	chainID := big.NewInt(es.chainId)
+	var version string
+	for _, candidate := range versions {
+		if ComputeDomainSeparator(name.(string), candidate, chainID, token) == common.Hash(separator.([32]byte)) {
+			version = candidate
+			break
+		}
+	}
+	if version == "" {
+		return nil, fmt.Errorf("%w: DOMAIN_SEPARATOR of token %s doesn't match its name %q", ErrPermitUnsupported, token.Hex(), name)
+	}
+
+	permit := &PermitSignature{
+		Owner:    owner,
+		Spender:  spender,
+		Value:    value,
+		Nonce:    nonce.(*big.Int),
+		Deadline: deadline,
+	}
+	signature, err := es.SignTypedData(permitTypedData(name.(string), version, chainID, token, permit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign permit: %w", err)
+	}
+	permit.R = common.BytesToHash(signature[:32])
+	permit.S = common.BytesToHash(signature[32:64])
+	permit.V = signature[crypto.RecoveryIDOffset]
+
+	es.log.WithFields(logrus.Fields{
+		"token":    token.Hex(),
+		"spender":  spender.Hex(),
+		"value":    value.String(),
+		"nonce":    permit.Nonce.String(),
+		"deadline": deadline.String(),
+	}).Info("Signed permit")
+	return permit, nil
+}
+
+// permitTypedData builds the EIP-712 typed data of an ERC-2612 Permit for the token's domain
+func permitTypedData(name, version string, chainID *big.Int, token common.Address, permit *PermitSignature) apitypes.TypedData {
+	return apitypes.TypedData{
+		Types: apitypes.Types{
+			"EIP712Domain": {
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"Permit": {
+				{Name: "owner", Type: "address"},
+				{Name: "spender", Type: "address"},
+				{Name: "value", Type: "uint256"},
+				{Name: "nonce", Type: "uint256"},
+				{Name: "deadline", Type: "uint256"},
+			},
+		},
+		PrimaryType: "Permit",
+		Domain: apitypes.TypedDataDomain{
+			Name:              name,
+			Version:           version,
+			ChainId:           (*math.HexOrDecimal256)(chainID),
+			VerifyingContract: token.Hex(),
+		},
+		Message: apitypes.TypedDataMessage{
+			"owner":    permit.Owner.Hex(),
+			"spender":  permit.Spender.Hex(),
+			"value":    permit.Value.String(),
+			"nonce":    permit.Nonce.String(),
+			"deadline": permit.Deadline.String(),
+		},
+	}
+}
+
+// callToken calls a view method on token and returns its single decoded output. Empty return
+// data, as from an address without code or a missing function with a fallback, is an error.
+func (es *ghostClient) callToken(ctx context.Context, token common.Address, contractABI abi.ABI, method string, args ...interface{}) (interface{}, error) {
+	data, err := contractABI.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", method, err)
+	}
+	out, err := es.readClient().CallContract(ctx, ethereum.CallMsg{To: &token, Data: data}, nil)
+	if err != nil {
+		return nil, err
+	}
+	values, err := contractABI.Unpack(method, out)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", method, err)
+	}
+	return values[0], nil
+}
@@ -0,0 +1,162 @@
+package eth
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// testPermitToken answers token calls by selector; methods missing from results revert
+func testPermitToken(mockClient *internalmocks.EthClient, token common.Address, results map[string][]byte) {
+	selectors := map[string][]byte{
+		"name":             erc20ABI.Methods["name"].ID,
+		"nonces":           erc2612ABI.Methods["nonces"].ID,
+		"DOMAIN_SEPARATOR": erc2612ABI.Methods["DOMAIN_SEPARATOR"].ID,
+		"version":          erc2612ABI.Methods["version"].ID,
+	}
+	call := func(method string) interface{} {
+		return mock.MatchedBy(func(msg ethereum.CallMsg) bool {
+			return msg.To != nil && *msg.To == token && bytes.HasPrefix(msg.Data, selectors[method])
+		})
+	}
+	for method := range selectors {
+		if out, ok := results[method]; ok {
+			mockClient.On("CallContract", mock.Anything, call(method), (*big.Int)(nil)).Return(out, nil)
+		} else {
+			mockClient.On("CallContract", mock.Anything, call(method), (*big.Int)(nil)).
+				Return(nil, &testRPCError{code: 3, message: "execution reverted"})
+		}
+	}
+}
+
+func mustPackOutput(t *testing.T, contractABI abi.ABI, method string, values ...interface{}) []byte {
+	out, err := contractABI.Methods[method].Outputs.Pack(values...)
+	assert.NoError(t, err)
+	return out
+}
+
+func TestGhostClient_SignPermit(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	usdc := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	spender := common.HexToAddress("0x000000000022D473030F116dDEE9F6B43aC78BA3")
+	separator := ComputeDomainSeparator("USD Coin", "2", big.NewInt(1), usdc)
+
+	mockClient := &internalmocks.EthClient{}
+	testPermitToken(mockClient, usdc, map[string][]byte{
+		"name":             mustPackOutput(t, erc20ABI, "name", "USD Coin"),
+		"nonces":           mustPackOutput(t, erc2612ABI, "nonces", big.NewInt(7)),
+		"DOMAIN_SEPARATOR": mustPackOutput(t, erc2612ABI, "DOMAIN_SEPARATOR", [32]byte(separator)),
+		"version":          mustPackOutput(t, erc2612ABI, "version", "2"),
+	})
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	value := big.NewInt(1_000_000)
+	deadline := big.NewInt(1893456000)
+	permit, err := gc.SignPermit(context.Background(), usdc, spender, value, deadline)
+	assert.NoError(t, err)
+	assert.Equal(t, acc.Address, permit.Owner)
+	assert.Equal(t, big.NewInt(7), permit.Nonce)
+	assert.Contains(t, []uint8{27, 28}, permit.V)
+
+	// -- recover the owner from the digest the token's permit() computes
+	permitTypeHash := crypto.Keccak256([]byte("Permit(address owner,address spender,uint256 value,uint256 nonce,uint256 deadline)"))
+	structHash := crypto.Keccak256(
+		permitTypeHash,
+		common.LeftPadBytes(acc.Address.Bytes(), 32),
+		common.LeftPadBytes(spender.Bytes(), 32),
+		common.LeftPadBytes(value.Bytes(), 32),
+		common.LeftPadBytes(big.NewInt(7).Bytes(), 32),
+		common.LeftPadBytes(deadline.Bytes(), 32),
+	)
+	digest := crypto.Keccak256([]byte{0x19, 0x01}, separator.Bytes(), structHash)
+	sig := append(append(permit.R.Bytes(), permit.S.Bytes()...), permit.V-27)
+	pub, err := crypto.SigToPub(digest, sig)
+	assert.NoError(t, err)
+	assert.Equal(t, acc.Address, crypto.PubkeyToAddress(*pub))
+}
+
+func TestGhostClient_SignPermit_NoVersion(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	token := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	// -- OpenZeppelin ERC20Permit without a version() view signs with version "1"
+	separator := ComputeDomainSeparator("Test Token", "1", big.NewInt(1), token)
+
+	mockClient := &internalmocks.EthClient{}
+	testPermitToken(mockClient, token, map[string][]byte{
+		"name":             mustPackOutput(t, erc20ABI, "name", "Test Token"),
+		"nonces":           mustPackOutput(t, erc2612ABI, "nonces", big.NewInt(0)),
+		"DOMAIN_SEPARATOR": mustPackOutput(t, erc2612ABI, "DOMAIN_SEPARATOR", [32]byte(separator)),
+	})
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	permit, err := gc.SignPermit(context.Background(), token, common.HexToAddress("0x02"), big.NewInt(1), big.NewInt(1893456000))
+	assert.NoError(t, err)
+	assert.Zero(t, permit.Nonce.Sign())
+}
+
+func TestGhostClient_SignPermit_Unsupported(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	token := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+
+	mockClient := &internalmocks.EthClient{}
+	testPermitToken(mockClient, token, map[string][]byte{
+		"name": mustPackOutput(t, erc20ABI, "name", "Plain Token"),
+	})
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	_, err := gc.SignPermit(context.Background(), token, common.HexToAddress("0x02"), big.NewInt(1), big.NewInt(1893456000))
+	assert.ErrorIs(t, err, ErrPermitUnsupported)
+}
+
+func TestGhostClient_SignPermit_DomainMismatch(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	token := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+
+	mockClient := &internalmocks.EthClient{}
+	testPermitToken(mockClient, token, map[string][]byte{
+		"name":             mustPackOutput(t, erc20ABI, "name", "Dai Stablecoin"),
+		"nonces":           mustPackOutput(t, erc2612ABI, "nonces", big.NewInt(0)),
+		"DOMAIN_SEPARATOR": mustPackOutput(t, erc2612ABI, "DOMAIN_SEPARATOR", [32]byte(common.HexToHash("0xdead"))),
+	})
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	_, err := gc.SignPermit(context.Background(), token, common.HexToAddress("0x02"), big.NewInt(1), big.NewInt(1893456000))
+	assert.ErrorIs(t, err, ErrPermitUnsupported)
+}
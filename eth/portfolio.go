@@ -0,0 +1,91 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Portfolio returns the account's ETH balance and its balances of the given ERC-20 tokens,
+// fetched together with their symbol and decimals in a single multicall
+func (es *ghostClient) Portfolio(ctx context.Context, tokens []common.Address) (*Portfolio, error) {
+	owner := es.account.Address
+
+	ethBalanceData, err := multicall3ABI.Pack("getEthBalance", owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode getEthBalance: %w", err)
+	}
+	balanceOfData, err := erc20ABI.Pack("balanceOf", owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode balanceOf: %w", err)
+	}
+	symbolData, _ := erc20ABI.Pack("symbol")
+	decimalsData, _ := erc20ABI.Pack("decimals")
+
+	// -- getEthBalance first, then balanceOf, symbol and decimals for each token
+	calls := []multicall{{Target: multicall3Address, CallData: ethBalanceData}}
+	for _, token := range tokens {
+		calls = append(calls,
+			multicall{Target: token, AllowFailure: true, CallData: balanceOfData},
+			multicall{Target: token, AllowFailure: true, CallData: symbolData},
+			multicall{Target: token, AllowFailure: true, CallData: decimalsData},
+		)
+	}
+
+	results, err := es.aggregate(ctx, calls)
+	if err != nil {
+		return nil, err
+	}
+
+	native, err := unpackUint256(multicall3ABI, "getEthBalance", results[0].ReturnData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode ETH balance: %w", err)
+	}
+	portfolio := &Portfolio{
+		Account:         owner,
+		NativeBalance:   native,
+		NativeFormatted: FormatUnits(native, 18),
+		Tokens:          make([]TokenBalance, 0, len(tokens)),
+	}
+
+	for i, token := range tokens {
+		balanceRes, symbolRes, decimalsRes := results[1+3*i], results[2+3*i], results[3+3*i]
+
+		if !balanceRes.Success {
+			return nil, fmt.Errorf("balanceOf failed for token %s", token.Hex())
+		}
+		balance, err := unpackUint256(erc20ABI, "balanceOf", balanceRes.ReturnData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode balance of token %s: %w", token.Hex(), err)
+		}
+
+		if !decimalsRes.Success {
+			return nil, fmt.Errorf("decimals failed for token %s", token.Hex())
+		}
+		out, err := erc20ABI.Unpack("decimals", decimalsRes.ReturnData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode decimals of token %s: %w", token.Hex(), err)
+		}
+		decimals := out[0].(uint8)
+
+		// Some older tokens return bytes32 or nothing for symbol; leave it empty rather than fail
+		var symbol string
+		if symbolRes.Success {
+			if out, err := erc20ABI.Unpack("symbol", symbolRes.ReturnData); err == nil {
+				symbol = out[0].(string)
+			}
+		}
+
+		portfolio.Tokens = append(portfolio.Tokens, TokenBalance{
+			Token:     token,
+			Symbol:    symbol,
+			Decimals:  decimals,
+			Balance:   balance,
+			Formatted: FormatUnits(balance, decimals),
+		})
+	}
+
+	es.log.WithField("tokens", len(tokens)).Info("Fetched portfolio")
+	return portfolio, nil
+}
@@ -0,0 +1,103 @@
+package eth
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// testMulticallOutput encodes aggregate3 return data
+func testMulticallOutput(t *testing.T, results ...multicallResult) []byte {
+	out, err := multicall3ABI.Methods["aggregate3"].Outputs.Pack(results)
+	assert.NoError(t, err)
+	return out
+}
+
+// testReturn encodes a single return value of method
+func testReturn(t *testing.T, method string, value interface{}) multicallResult {
+	contractABI := erc20ABI
+	if method == "getEthBalance" {
+		contractABI = multicall3ABI
+	}
+	out, err := contractABI.Methods[method].Outputs.Pack(value)
+	assert.NoError(t, err)
+	return multicallResult{Success: true, ReturnData: out}
+}
+
+func TestGhostClient_Portfolio(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	usdc := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	mkr := common.HexToAddress("0x9f8F72aA9304c8B593d555F12eF6589cC3A579A2")
+
+	output := testMulticallOutput(t,
+		testReturn(t, "getEthBalance", big.NewInt(1500000000000000000)),
+		testReturn(t, "balanceOf", big.NewInt(2500000)),
+		testReturn(t, "symbol", "USDC"),
+		testReturn(t, "decimals", uint8(6)),
+		testReturn(t, "balanceOf", big.NewInt(0)),
+		multicallResult{Success: true, ReturnData: common.LeftPadBytes([]byte("MKR"), 32)}, // bytes32 symbol
+		testReturn(t, "decimals", uint8(18)),
+	)
+	mockClient.On("CallContract", mock.Anything, mock.MatchedBy(func(msg ethereum.CallMsg) bool {
+		if msg.To == nil || *msg.To != multicall3Address {
+			return false
+		}
+		args, err := multicall3ABI.Methods["aggregate3"].Inputs.Unpack(msg.Data[4:])
+		return err == nil && len(args) == 1
+	}), (*big.Int)(nil)).Return(output, nil).Once()
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	portfolio, err := gc.Portfolio(context.Background(), []common.Address{usdc, mkr})
+	assert.NoError(t, err)
+	assert.Equal(t, acc.Address, portfolio.Account)
+	assert.Equal(t, "1.5", portfolio.NativeFormatted)
+	assert.Len(t, portfolio.Tokens, 2)
+
+	assert.Equal(t, usdc, portfolio.Tokens[0].Token)
+	assert.Equal(t, "USDC", portfolio.Tokens[0].Symbol)
+	assert.Equal(t, uint8(6), portfolio.Tokens[0].Decimals)
+	assert.Equal(t, "2.5", portfolio.Tokens[0].Formatted)
+
+	assert.Equal(t, "", portfolio.Tokens[1].Symbol)
+	assert.Equal(t, "0", portfolio.Tokens[1].Formatted)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_Portfolio_TokenCallFails(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	token := common.HexToAddress("0x0000000000000000000000000000000000000bad")
+
+	output := testMulticallOutput(t,
+		testReturn(t, "getEthBalance", big.NewInt(0)),
+		multicallResult{Success: false},
+		multicallResult{Success: false},
+		multicallResult{Success: false},
+	)
+	mockClient.On("CallContract", mock.Anything, mock.Anything, mock.Anything).Return(output, nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	_, err := gc.Portfolio(context.Background(), []common.Address{token})
+	assert.ErrorContains(t, err, "balanceOf failed")
+}
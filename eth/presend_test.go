@@ -0,0 +1,92 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGhostClient_SendTransaction_PreSendHook_Rejects(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	signedTx := testBroadcastTx(t, acc)
+	errNotApproved := errors.New("not approved")
+
+	mockClient := &internalmocks.EthClient{}
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	WithPreSendHook(func(tx *types.Transaction) error { return errNotApproved })(gc)
+
+	_, err := gc.SendTransaction(signedTx)
+	assert.ErrorIs(t, err, errNotApproved)
+	assert.ErrorContains(t, err, signedTx.Hash().Hex())
+	mockClient.AssertNotCalled(t, "SendTransaction", mock.Anything, mock.Anything)
+}
+
+func TestGhostClient_SendTransaction_PreSendHook_Approves(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	signedTx := testBroadcastTx(t, acc)
+
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("SendTransaction", mock.Anything, signedTx).Return(nil).Once()
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	var inspected *types.Transaction
+	WithPreSendHook(func(tx *types.Transaction) error {
+		inspected = tx
+		return nil
+	})(gc)
+
+	receipt, err := gc.SendTransaction(signedTx)
+	assert.NoError(t, err)
+	assert.Equal(t, signedTx.Hash(), receipt.TxHash)
+	assert.Equal(t, signedTx.Hash(), inspected.Hash())
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_SendTransaction_PreSendHook_SeesResignedTx(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	signedTx := testBroadcastTx(t, acc) // max fee 100 wei, below the base fee
+
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).
+		Return(&types.Header{Number: big.NewInt(42), BaseFee: big.NewInt(150)}, nil).Once()
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	WithBaseFeeGuard()(gc)
+	var inspected *types.Transaction
+	WithPreSendHook(func(tx *types.Transaction) error {
+		inspected = tx
+		return errors.New("fee too high")
+	})(gc)
+
+	_, err := gc.SendTransaction(signedTx)
+	assert.ErrorContains(t, err, "fee too high")
+	if assert.NotNil(t, inspected) {
+		assert.Equal(t, big.NewInt(301), inspected.GasFeeCap())
+	}
+	mockClient.AssertNotCalled(t, "SendTransaction", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,61 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/sirupsen/logrus"
+)
+
+// InclusionProof fetches the receipt and the containing block of a mined transaction and
+// verifies that the transaction sits at the claimed index and that the block's transaction
+// root matches the transactions it carries.
+func (es *ghostClient) InclusionProof(ctx context.Context, hash common.Hash) (*InclusionProof, error) {
+	receipt, err := es.readClient().TransactionReceipt(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("transaction not found or pending: %w", err)
+	}
+
+	block, err := es.readClient().BlockByHash(ctx, receipt.BlockHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block %s: %w", receipt.BlockHash.Hex(), err)
+	}
+
+	// -- verify the block is the one the receipt points at
+	if block.Hash() != receipt.BlockHash {
+		return nil, fmt.Errorf("block hash mismatch: receipt claims %s, got %s", receipt.BlockHash.Hex(), block.Hash().Hex())
+	}
+
+	// -- verify the transaction is at the claimed index
+	txs := block.Transactions()
+	if receipt.TransactionIndex >= uint(len(txs)) {
+		return nil, fmt.Errorf("transaction index %d out of range for block with %d transactions", receipt.TransactionIndex, len(txs))
+	}
+	if txs[receipt.TransactionIndex].Hash() != hash {
+		return nil, fmt.Errorf("transaction %s not found at index %d of block %s", hash.Hex(), receipt.TransactionIndex, block.Hash().Hex())
+	}
+
+	// -- verify the transaction root commits to the block's transactions
+	header := block.Header()
+	if root := types.DeriveSha(txs, trie.NewStackTrie(nil)); root != header.TxHash {
+		return nil, fmt.Errorf("transaction root mismatch: header has %s, computed %s", header.TxHash.Hex(), root.Hex())
+	}
+
+	es.log.WithFields(logrus.Fields{
+		"hash":         hash.Hex(),
+		"block_number": header.Number.Uint64(),
+		"index":        receipt.TransactionIndex,
+	}).Info("Transaction inclusion verified")
+
+	return &InclusionProof{
+		TxHash:           hash,
+		BlockHash:        block.Hash(),
+		BlockNumber:      header.Number.Uint64(),
+		TransactionIndex: receipt.TransactionIndex,
+		TxRoot:           header.TxHash,
+		Header:           header,
+	}, nil
+}
@@ -0,0 +1,115 @@
+package eth
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/trie"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testBlockWithTransactions(number int64, count int) *types.Block {
+	to := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	var txs []*types.Transaction
+	for i := 0; i < count; i++ {
+		txs = append(txs, types.NewTx(&types.DynamicFeeTx{
+			ChainID: big.NewInt(1),
+			Nonce:   uint64(i),
+			To:      &to,
+			Value:   big.NewInt(1),
+			Gas:     21000,
+		}))
+	}
+	header := &types.Header{Number: big.NewInt(number), GasLimit: 30000000}
+	return types.NewBlock(header, &types.Body{Transactions: txs}, nil, trie.NewStackTrie(nil))
+}
+
+func TestGhostClient_InclusionProof_Success(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	block := testBlockWithTransactions(123, 3)
+	tx := block.Transactions()[1]
+	receipt := &types.Receipt{
+		TxHash:           tx.Hash(),
+		BlockHash:        block.Hash(),
+		BlockNumber:      big.NewInt(123),
+		TransactionIndex: 1,
+	}
+	mockClient.On("TransactionReceipt", mock.Anything, tx.Hash()).Return(receipt, nil)
+	mockClient.On("BlockByHash", mock.Anything, block.Hash()).Return(block, nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	proof, err := gc.InclusionProof(context.Background(), tx.Hash())
+	assert.NoError(t, err)
+	assert.Equal(t, tx.Hash(), proof.TxHash)
+	assert.Equal(t, block.Hash(), proof.BlockHash)
+	assert.Equal(t, uint64(123), proof.BlockNumber)
+	assert.Equal(t, uint(1), proof.TransactionIndex)
+	assert.Equal(t, block.Header().TxHash, proof.TxRoot)
+	assert.Equal(t, block.Hash(), proof.Header.Hash())
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_InclusionProof_WrongIndex(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	block := testBlockWithTransactions(123, 3)
+	tx := block.Transactions()[1]
+	receipt := &types.Receipt{
+		TxHash:           tx.Hash(),
+		BlockHash:        block.Hash(),
+		BlockNumber:      big.NewInt(123),
+		TransactionIndex: 2, // claims the wrong position
+	}
+	mockClient.On("TransactionReceipt", mock.Anything, tx.Hash()).Return(receipt, nil)
+	mockClient.On("BlockByHash", mock.Anything, block.Hash()).Return(block, nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	_, err := gc.InclusionProof(context.Background(), tx.Hash())
+	assert.Error(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_InclusionProof_BlockMismatch(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	block := testBlockWithTransactions(123, 2)
+	other := testBlockWithTransactions(124, 2)
+	tx := block.Transactions()[0]
+	receipt := &types.Receipt{
+		TxHash:           tx.Hash(),
+		BlockHash:        block.Hash(),
+		BlockNumber:      big.NewInt(123),
+		TransactionIndex: 0,
+	}
+	mockClient.On("TransactionReceipt", mock.Anything, tx.Hash()).Return(receipt, nil)
+	mockClient.On("BlockByHash", mock.Anything, block.Hash()).Return(other, nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	_, err := gc.InclusionProof(context.Background(), tx.Hash())
+	assert.Error(t, err)
+	mockClient.AssertExpectations(t)
+}
@@ -0,0 +1,92 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/sirupsen/logrus"
+)
+
+// GetBlockReceipts returns the receipts of every transaction in a block using a single
+// eth_getBlockReceipts call, falling back to per-transaction fetches on providers that don't
+// implement it. Senders are recovered from the block's transactions.
+func (es *ghostClient) GetBlockReceipts(ctx context.Context, blockNumber *big.Int) ([]*TransactionReceipt, error) {
+	block, err := es.readClient().BlockByNumber(ctx, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block: %w", err)
+	}
+	txs := block.Transactions()
+
+	receipts, err := es.readClient().BlockReceipts(ctx, rpc.BlockNumberOrHashWithHash(block.Hash(), false))
+	if err != nil {
+		if !isMethodNotFound(err) {
+			return nil, fmt.Errorf("failed to get block receipts: %w", err)
+		}
+
+		es.log.WithField("block_number", block.NumberU64()).Warn("eth_getBlockReceipts not supported, fetching receipts individually")
+		receipts = make([]*types.Receipt, 0, len(txs))
+		for _, tx := range txs {
+			receipt, err := es.readClient().TransactionReceipt(ctx, tx.Hash())
+			if err != nil {
+				return nil, fmt.Errorf("failed to get receipt for %s: %w", tx.Hash().Hex(), err)
+			}
+			receipts = append(receipts, receipt)
+		}
+	}
+
+	if len(receipts) != len(txs) {
+		return nil, fmt.Errorf("block %d has %d transactions but %d receipts", block.NumberU64(), len(txs), len(receipts))
+	}
+
+	signer := es.Signer()
+	result := make([]*TransactionReceipt, 0, len(receipts))
+	for i, receipt := range receipts {
+		from, err := types.Sender(signer, txs[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to recover sender of %s: %w", txs[i].Hash().Hex(), err)
+		}
+		r := newTransactionReceipt(receipt, txs[i], from)
+		r.Timestamp = block.Time()
+		result = append(result, r)
+	}
+
+	es.log.WithFields(logrus.Fields{
+		"block_number": block.NumberU64(),
+		"receipts":     len(result),
+	}).Info("Fetched block receipts")
+	return result, nil
+}
+
+// newTransactionReceipt converts a go-ethereum receipt and its transaction into a TransactionReceipt.
+// tx may be nil, in which case To and Value are left unset.
+func newTransactionReceipt(receipt *types.Receipt, tx *types.Transaction, from common.Address) *TransactionReceipt {
+	var to common.Address
+	var value *big.Int
+	if tx != nil {
+		if tx.To() != nil {
+			to = *tx.To()
+		}
+		value = tx.Value()
+	}
+	var blockNumber uint64
+	if receipt.BlockNumber != nil {
+		blockNumber = receipt.BlockNumber.Uint64()
+	}
+	return &TransactionReceipt{
+		TxHash:            receipt.TxHash,
+		Status:            receipt.Status,
+		BlockNumber:       blockNumber,
+		GasUsed:           receipt.GasUsed,
+		CumulativeGasUsed: receipt.CumulativeGasUsed,
+		From:              from,
+		To:                to,
+		Logs:              receipt.Logs,
+		Type:              receipt.Type,
+		Value:             value,
+		EffectiveGasPrice: receipt.EffectiveGasPrice,
+	}
+}
@@ -0,0 +1,291 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/holiman/uint256"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testSignedBlock(t *testing.T, acc *Account, number int64, count int) (*types.Block, []*types.Receipt) {
+	to := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	signer := types.LatestSignerForChainID(big.NewInt(acc.ChainId))
+	var txs []*types.Transaction
+	var receipts []*types.Receipt
+	for i := 0; i < count; i++ {
+		tx, err := types.SignNewTx(acc.PrivateKey, signer, &types.DynamicFeeTx{
+			ChainID:   big.NewInt(acc.ChainId),
+			Nonce:     uint64(i),
+			To:        &to,
+			Value:     big.NewInt(1),
+			Gas:       21000,
+			GasFeeCap: big.NewInt(100),
+			GasTipCap: big.NewInt(1),
+		})
+		assert.NoError(t, err)
+		txs = append(txs, tx)
+		receipts = append(receipts, &types.Receipt{
+			TxHash:           tx.Hash(),
+			Status:           types.ReceiptStatusSuccessful,
+			BlockNumber:      big.NewInt(number),
+			GasUsed:          21000,
+			TransactionIndex: uint(i),
+		})
+	}
+	header := &types.Header{Number: big.NewInt(number), GasLimit: 30000000, Time: 1700000000 + uint64(number)}
+	return types.NewBlock(header, &types.Body{Transactions: txs}, nil, trie.NewStackTrie(nil)), receipts
+}
+
+func TestGhostClient_GetBlockReceipts(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	block, receipts := testSignedBlock(t, acc, 500, 3)
+	mockClient.On("BlockByNumber", mock.Anything, big.NewInt(500)).Return(block, nil)
+	mockClient.On("BlockReceipts", mock.Anything, mock.Anything).Return(receipts, nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	result, err := gc.GetBlockReceipts(context.Background(), big.NewInt(500))
+	assert.NoError(t, err)
+	assert.Len(t, result, 3)
+	for i, r := range result {
+		assert.Equal(t, block.Transactions()[i].Hash(), r.TxHash)
+		assert.Equal(t, uint64(500), r.BlockNumber)
+		assert.Equal(t, acc.Address, r.From)
+		assert.Equal(t, common.HexToAddress("0x0000000000000000000000000000000000000002"), r.To)
+		assert.Equal(t, block.Time(), r.Timestamp)
+	}
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNotCalled(t, "TransactionReceipt", mock.Anything, mock.Anything)
+}
+
+func TestGhostClient_GetBlockReceipts_Fallback(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	block, receipts := testSignedBlock(t, acc, 500, 2)
+	mockClient.On("BlockByNumber", mock.Anything, big.NewInt(500)).Return(block, nil)
+	mockClient.On("BlockReceipts", mock.Anything, mock.Anything).
+		Return(nil, &testRPCError{code: -32601, message: "the method eth_getBlockReceipts does not exist/is not available"})
+	for i, tx := range block.Transactions() {
+		mockClient.On("TransactionReceipt", mock.Anything, tx.Hash()).Return(receipts[i], nil)
+	}
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	result, err := gc.GetBlockReceipts(context.Background(), big.NewInt(500))
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_GetBlockReceipts_Error(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	block, _ := testSignedBlock(t, acc, 500, 1)
+	mockClient.On("BlockByNumber", mock.Anything, big.NewInt(500)).Return(block, nil)
+	mockClient.On("BlockReceipts", mock.Anything, mock.Anything).Return(nil, errors.New("rate limited"))
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	_, err := gc.GetBlockReceipts(context.Background(), big.NewInt(500))
+	assert.Error(t, err)
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNotCalled(t, "TransactionReceipt", mock.Anything, mock.Anything)
+}
+
+func TestGhostClient_GetTransactionReceipt_Light(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	hash := common.HexToHash("0xabc")
+	receipt := &types.Receipt{
+		TxHash:      hash,
+		Status:      types.ReceiptStatusSuccessful,
+		BlockNumber: big.NewInt(123),
+		GasUsed:     21000,
+	}
+	mockClient.On("TransactionReceipt", mock.Anything, hash).Return(receipt, nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	WithLightReceipts()(gc)
+
+	result, err := gc.GetTransactionReceipt(hash)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), result.Status)
+	assert.Equal(t, uint64(123), result.BlockNumber)
+	assert.Equal(t, uint64(21000), result.GasUsed)
+	assert.Equal(t, common.Address{}, result.To)
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNotCalled(t, "TransactionByHash", mock.Anything, mock.Anything)
+	assert.Len(t, mockClient.Calls, 1)
+}
+
+func TestGhostClient_GetTransactionReceipt_Timestamp(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	hash := common.HexToHash("0xabc")
+	blockHash := common.HexToHash("0xb10c")
+	receipt := &types.Receipt{
+		TxHash:      hash,
+		Status:      types.ReceiptStatusSuccessful,
+		BlockHash:   blockHash,
+		BlockNumber: big.NewInt(123),
+		GasUsed:     21000,
+	}
+	newClient := func() (*ghostClient, *internalmocks.EthClient) {
+		mockClient := &internalmocks.EthClient{}
+		mockClient.On("TransactionReceipt", mock.Anything, hash).Return(receipt, nil)
+		return &ghostClient{
+			client:  mockClient,
+			ctx:     context.Background(),
+			chainId: 1,
+			account: acc,
+			config:  cfg,
+			log:     newTestLogger(),
+		}, mockClient
+	}
+
+	// enabled: the block header is fetched by hash
+	gc, mockClient := newClient()
+	mockClient.On("HeaderByHash", mock.Anything, blockHash).Return(&types.Header{Number: big.NewInt(123), Time: 1718000000}, nil)
+	WithLightReceipts()(gc)
+	WithReceiptTimestamps()(gc)
+	result, err := gc.GetTransactionReceipt(hash)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1718000000), result.Timestamp)
+	mockClient.AssertExpectations(t)
+
+	// disabled by default: no extra lookup
+	gc, mockClient = newClient()
+	WithLightReceipts()(gc)
+	result, err = gc.GetTransactionReceipt(hash)
+	assert.NoError(t, err)
+	assert.Zero(t, result.Timestamp)
+	mockClient.AssertNotCalled(t, "HeaderByHash", mock.Anything, mock.Anything)
+}
+
+func TestGhostClient_GetBlockReceipts_UsesCachedSigner(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	block, receipts := testSignedBlock(t, acc, 500, 2)
+	mockClient.On("BlockByNumber", mock.Anything, big.NewInt(500)).Return(block, nil)
+	mockClient.On("BlockReceipts", mock.Anything, mock.Anything).Return(receipts, nil)
+	signer := &countingSigner{Signer: types.LatestSignerForChainID(big.NewInt(1))}
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+		signer:  signer,
+	}
+	result, err := gc.GetBlockReceipts(context.Background(), big.NewInt(500))
+	assert.NoError(t, err)
+	for _, r := range result {
+		assert.Equal(t, acc.Address, r.From)
+	}
+	assert.Equal(t, 2, signer.senders)
+}
+
+func TestGhostClient_GetTransaction_Types(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	signer := types.LatestSignerForChainID(big.NewInt(1))
+	to := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	sign := func(data types.TxData) *types.Transaction {
+		tx, err := types.SignNewTx(acc.PrivateKey, signer, data)
+		assert.NoError(t, err)
+		return tx
+	}
+	legacy := sign(&types.LegacyTx{Nonce: 1, To: &to, Value: big.NewInt(5), Gas: 21000, GasPrice: big.NewInt(30)})
+	dynamic := sign(&types.DynamicFeeTx{ChainID: big.NewInt(1), Nonce: 2, To: &to, Value: big.NewInt(5), Gas: 21000, GasFeeCap: big.NewInt(100), GasTipCap: big.NewInt(2)})
+	blob := sign(&types.BlobTx{
+		ChainID:    uint256.NewInt(1),
+		Nonce:      3,
+		To:         to,
+		Gas:        21000,
+		GasFeeCap:  uint256.NewInt(100),
+		GasTipCap:  uint256.NewInt(2),
+		BlobFeeCap: uint256.NewInt(1),
+		BlobHashes: []common.Hash{{0x01}},
+	})
+
+	mockClient := &internalmocks.EthClient{}
+	for _, tx := range []*types.Transaction{legacy, dynamic, blob} {
+		mockClient.On("TransactionByHash", mock.Anything, tx.Hash()).Return(tx, false, nil)
+	}
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	result, err := gc.GetTransaction(context.Background(), legacy.Hash())
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(types.LegacyTxType), result.Type)
+	assert.Equal(t, acc.Address, result.From)
+	assert.Equal(t, to, result.To)
+	assert.Equal(t, big.NewInt(30), result.GasPrice)
+	assert.Nil(t, result.MaxFeePerGas)
+	assert.Equal(t, uint64(1), result.Nonce)
+
+	result, err = gc.GetTransaction(context.Background(), dynamic.Hash())
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(types.DynamicFeeTxType), result.Type)
+	assert.Equal(t, big.NewInt(100), result.MaxFeePerGas)
+	assert.Equal(t, big.NewInt(2), result.MaxPriorityFeePerGas)
+	assert.Nil(t, result.GasPrice)
+
+	result, err = gc.GetTransaction(context.Background(), blob.Hash())
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(types.BlobTxType), result.Type)
+	assert.Equal(t, acc.Address, result.From)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_GetTransaction_NotFound(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("TransactionByHash", mock.Anything, mock.Anything).Return(nil, false, ethereum.NotFound)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	_, err := gc.GetTransaction(context.Background(), common.Hash{0x01})
+	assert.ErrorIs(t, err, ethereum.NotFound)
+}
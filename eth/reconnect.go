@@ -0,0 +1,173 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/sirupsen/logrus"
+)
+
+// --- Reconnect backoff ---
+const (
+	reconnectInitialBackoff = 500 * time.Millisecond
+	reconnectMaxBackoff     = 30 * time.Second
+	reconnectMaxAttempts    = 8
+)
+
+// headSupervisor keeps a new-head subscription alive across dropped websocket connections. When
+// the underlying subscription fails it re-dials the endpoint with backoff and re-subscribes into
+// the same channel; only a failure to reconnect at all is reported through Err.
+type headSupervisor struct {
+	es    *ghostClient
+	heads chan<- *types.Header
+
+	// client the current subscription was made on, used to avoid re-dialing a connection
+	// another subscription has already replaced
+	client EthClient
+
+	err      chan error
+	quit     chan struct{}
+	done     chan struct{}
+	quitOnce sync.Once
+}
+
+var _ ethereum.Subscription = (*headSupervisor)(nil)
+
+// subscribeNewHeads subscribes to new heads on the read client, re-establishing the subscription
+// if the connection drops
+func (es *ghostClient) subscribeNewHeads(ctx context.Context, heads chan<- *types.Header) (ethereum.Subscription, error) {
+	client := es.readClient()
+	sub, err := client.SubscribeNewHead(ctx, heads)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &headSupervisor{
+		es:     es,
+		heads:  heads,
+		client: client,
+		err:    make(chan error, 1),
+		quit:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go s.run(sub)
+	return s, nil
+}
+
+// Unsubscribe stops the supervisor and the underlying subscription
+func (s *headSupervisor) Unsubscribe() {
+	s.quitOnce.Do(func() { close(s.quit) })
+	<-s.done
+}
+
+// Err returns a channel that receives a fatal error if the subscription cannot be re-established.
+// It is closed when the supervisor stops.
+func (s *headSupervisor) Err() <-chan error {
+	return s.err
+}
+
+func (s *headSupervisor) run(sub ethereum.Subscription) {
+	defer close(s.done)
+	defer close(s.err)
+
+	for {
+		select {
+		case <-s.quit:
+			sub.Unsubscribe()
+			return
+		case err := <-sub.Err():
+			sub.Unsubscribe()
+			s.es.log.WithError(err).Warn("Head subscription dropped, reconnecting")
+
+			next, err := s.resubscribe()
+			if err != nil {
+				s.err <- err
+				return
+			}
+			if next == nil { // unsubscribed while reconnecting
+				return
+			}
+			sub = next
+		}
+	}
+}
+
+// resubscribe retries with exponential backoff until a new subscription is established. It returns
+// a nil subscription and nil error if the supervisor is stopped in the meantime.
+func (s *headSupervisor) resubscribe() (ethereum.Subscription, error) {
+	backoff := s.es.reconnectBackoff
+	if backoff <= 0 {
+		backoff = reconnectInitialBackoff
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= reconnectMaxAttempts; attempt++ {
+		timer := time.NewTimer(backoff)
+		select {
+		case <-s.quit:
+			timer.Stop()
+			return nil, nil
+		case <-timer.C:
+		}
+
+		sub, err := s.es.reconnect(s)
+		if err == nil {
+			s.es.log.WithField("attempt", attempt).Info("Head subscription re-established")
+			return sub, nil
+		}
+		lastErr = err
+		s.es.log.WithFields(logrus.Fields{
+			"attempt": attempt,
+			"backoff": backoff.String(),
+		}).WithError(err).Warn("Failed to re-establish head subscription")
+
+		backoff *= 2
+		if backoff > reconnectMaxBackoff {
+			backoff = reconnectMaxBackoff
+		}
+	}
+	return nil, fmt.Errorf("%w after %d attempts: %v", ErrReconnectFailed, reconnectMaxAttempts, lastErr)
+}
+
+// reconnect re-subscribes s, first re-dialing the read endpoint unless another subscription has
+// already replaced the connection s was using
+func (es *ghostClient) reconnect(s *headSupervisor) (ethereum.Subscription, error) {
+	es.reconnectMu.Lock()
+	defer es.reconnectMu.Unlock()
+
+	current := es.readClient()
+	if current != s.client || es.redial == nil {
+		sub, err := current.SubscribeNewHead(es.ctx, s.heads)
+		if err != nil {
+			return nil, err
+		}
+		s.client = current
+		return sub, nil
+	}
+
+	client, err := es.redial(es.ctx)
+	if err != nil {
+		return nil, err
+	}
+	sub, err := client.SubscribeNewHead(es.ctx, s.heads)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+
+	es.clientMu.Lock()
+	es.client = client
+	if raw, ok := client.(interface{ Client() *rpc.Client }); ok {
+		es.rpc = raw.Client()
+	}
+	es.clientMu.Unlock()
+	current.Close()
+
+	s.client = client
+	return sub, nil
+}
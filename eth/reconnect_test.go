@@ -0,0 +1,132 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGhostClient_SubscribeNewHeads_Resubscribes(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	dropped := newTestSubscription()
+	restored := newTestSubscription()
+
+	mockClient.On("SubscribeNewHead", mock.Anything, mock.Anything).Return(dropped, nil).Once()
+	mockClient.On("SubscribeNewHead", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			heads := args.Get(1).(chan<- *types.Header)
+			go func() { heads <- &types.Header{Number: big.NewInt(7)} }()
+		}).
+		Return(restored, nil).Once()
+	gc := &ghostClient{
+		client:           mockClient,
+		ctx:              context.Background(),
+		chainId:          1,
+		account:          acc,
+		config:           cfg,
+		log:              newTestLogger(),
+		reconnectBackoff: time.Millisecond,
+	}
+
+	heads := make(chan *types.Header, 1)
+	sub, err := gc.subscribeNewHeads(context.Background(), heads)
+	assert.NoError(t, err)
+
+	dropped.errCh <- errors.New("websocket: close 1006 (abnormal closure)")
+
+	select {
+	case head := <-heads:
+		assert.Equal(t, int64(7), head.Number.Int64())
+	case <-time.After(5 * time.Second):
+		t.Fatal("no head received after resubscribing")
+	}
+
+	sub.Unsubscribe()
+	assert.True(t, dropped.unsubscribed)
+	assert.True(t, restored.unsubscribed)
+	mockClient.AssertNumberOfCalls(t, "SubscribeNewHead", 2)
+}
+
+func TestGhostClient_SubscribeNewHeads_Redials(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	oldClient := &internalmocks.EthClient{}
+	newClient := &internalmocks.EthClient{}
+	dropped := newTestSubscription()
+	restored := newTestSubscription()
+
+	oldClient.On("SubscribeNewHead", mock.Anything, mock.Anything).Return(dropped, nil).Once()
+	oldClient.On("Close").Return().Once()
+	newClient.On("SubscribeNewHead", mock.Anything, mock.Anything).Return(restored, nil).Once()
+	redials := 0
+	gc := &ghostClient{
+		client:  oldClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+		redial: func(ctx context.Context) (EthClient, error) {
+			redials++
+			if redials == 1 {
+				return nil, errors.New("dial tcp: connection refused")
+			}
+			return newClient, nil
+		},
+		reconnectBackoff: time.Millisecond,
+	}
+
+	sub, err := gc.subscribeNewHeads(context.Background(), make(chan *types.Header))
+	assert.NoError(t, err)
+
+	dropped.errCh <- errors.New("websocket: close 1006 (abnormal closure)")
+
+	assert.Eventually(t, func() bool { return gc.readClient() == newClient }, 5*time.Second, time.Millisecond)
+	sub.Unsubscribe()
+
+	assert.Equal(t, 2, redials)
+	assert.True(t, restored.unsubscribed)
+	oldClient.AssertExpectations(t)
+	newClient.AssertExpectations(t)
+}
+
+func TestGhostClient_SubscribeNewHeads_FatalAfterRetries(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	dropped := newTestSubscription()
+
+	mockClient.On("SubscribeNewHead", mock.Anything, mock.Anything).Return(dropped, nil).Once()
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+		redial: func(ctx context.Context) (EthClient, error) {
+			return nil, errors.New("dial tcp: connection refused")
+		},
+		reconnectBackoff: time.Microsecond,
+	}
+
+	sub, err := gc.subscribeNewHeads(context.Background(), make(chan *types.Header))
+	assert.NoError(t, err)
+
+	dropped.errCh <- errors.New("websocket: close 1006 (abnormal closure)")
+
+	select {
+	case err := <-sub.Err():
+		assert.ErrorIs(t, err, ErrReconnectFailed)
+	case <-time.After(5 * time.Second):
+		t.Fatal("fatal reconnect error not surfaced")
+	}
+	sub.Unsubscribe()
+	assert.Equal(t, mockClient, gc.readClient())
+}
@@ -0,0 +1,66 @@
+package eth
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// debugTransport writes every JSON-RPC request and response body passing through it to w.
+// Requests are prefixed with "-->" and responses with "<--"; bodies are written verbatim, so
+// batches and provider-specific error payloads show up exactly as sent. No secrets travel on
+// the wire (transactions are signed locally), so nothing is redacted.
+type debugTransport struct {
+	base http.RoundTripper
+
+	mu sync.Mutex // serializes writes to w
+	w  io.Writer
+}
+
+func newDebugTransport(w io.Writer, base http.RoundTripper) *debugTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &debugTransport{base: base, w: w}
+}
+
+func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+	t.write("--> %s %s", req.URL.Redacted(), bytes.TrimSpace(reqBody))
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		t.write("<-- error (%s): %v", elapsed, err)
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		t.write("<-- %s (%s): failed to read body: %v", resp.Status, elapsed, err)
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	t.write("<-- %s (%s) %s", resp.Status, elapsed, bytes.TrimSpace(respBody))
+	return resp, nil
+}
+
+func (t *debugTransport) write(format string, args ...interface{}) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	fmt.Fprintf(t.w, format+"\n", args...)
+}
@@ -0,0 +1,81 @@
+package eth
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+// testRPCServer answers eth_chainId and eth_getBalance, failing any other method
+func testRPCServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(testRPCHandler(t))
+}
+
+func testRPCHandler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		switch req.Method {
+		case "eth_chainId":
+			resp["result"] = "0x1"
+		case "eth_getBalance":
+			resp["result"] = "0x2a"
+		default:
+			resp["error"] = map[string]interface{}{"code": -32601, "message": "method not found"}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
+
+func TestWithRPCDebug_GetBalance(t *testing.T) {
+	srv := testRPCServer(t)
+	defer srv.Close()
+
+	acc, cfg := testAccountAndConfig()
+	cfg.rpcURL = srv.URL
+	var debug bytes.Buffer
+	client, err := NewGhostClient(acc, cfg, newTestLogger(), WithRPCDebug(&debug))
+	assert.NoError(t, err)
+	defer client.Close()
+
+	addr := common.HexToAddress("0x00000000000000000000000000000000000000b1")
+	balance, err := client.GetBalance(addr)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(42), balance)
+
+	lines := strings.Split(strings.TrimSpace(debug.String()), "\n")
+	assert.Len(t, lines, 4) // eth_chainId and eth_getBalance, each request and response
+	assert.True(t, strings.HasPrefix(lines[2], "--> "))
+	assert.Contains(t, lines[2], `"method":"eth_getBalance"`)
+	assert.Contains(t, lines[2], strings.ToLower(addr.Hex()))
+	assert.Contains(t, lines[2], `"latest"`)
+	assert.True(t, strings.HasPrefix(lines[3], "<-- 200 OK"))
+	assert.Contains(t, lines[3], `"result":"0x2a"`)
+}
+
+func TestDebugTransport_Error(t *testing.T) {
+	srv := testRPCServer(t)
+	srv.Close() // refuse connections
+
+	var debug bytes.Buffer
+	transport := newDebugTransport(&debug, nil)
+	req, err := http.NewRequest(http.MethodPost, srv.URL, strings.NewReader(`{"method":"eth_chainId"}`))
+	assert.NoError(t, err)
+
+	_, err = transport.RoundTrip(req)
+	assert.Error(t, err)
+	assert.Contains(t, debug.String(), `--> `+srv.URL+` {"method":"eth_chainId"}`)
+	assert.Contains(t, debug.String(), "<-- error")
+}
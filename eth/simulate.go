@@ -0,0 +1,86 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/sirupsen/logrus"
+)
+
+// prestateAccount is the part of a prestateTracer account entry we need
+type prestateAccount struct {
+	Balance *hexutil.Big `json:"balance"`
+}
+
+// prestateDiff is the prestateTracer result in diff mode. Post only lists the fields that
+// changed; an account present in Pre but missing from Post was deleted.
+type prestateDiff struct {
+	Pre  map[common.Address]prestateAccount `json:"pre"`
+	Post map[common.Address]prestateAccount `json:"post"`
+}
+
+// SimulateBalanceChanges traces tx against the latest block with debug_traceCall and returns the
+// net ETH balance change of every address it would touch, including the gas paid by the sender
+// when fees are set. Addresses whose balance is unchanged are omitted. Providers without the
+// debug namespace fail with ErrTracingUnsupported.
+func (es *ghostClient) SimulateBalanceChanges(ctx context.Context, tx *Transaction) (map[common.Address]*big.Int, error) {
+	if err := tx.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid transaction: %w", err)
+	}
+
+	var diff prestateDiff
+	tracer := map[string]interface{}{
+		"tracer":       "prestateTracer",
+		"tracerConfig": map[string]interface{}{"diffMode": true},
+	}
+	err := es.rpcClient().CallContext(ctx, &diff, "debug_traceCall", toCallArg(tx), "latest", tracer)
+	if err != nil {
+		if isMethodNotFound(err) {
+			return nil, fmt.Errorf("%w: %v", ErrTracingUnsupported, err)
+		}
+		es.log.WithError(err).Error("Failed to trace transaction")
+		return nil, fmt.Errorf("failed to trace transaction: %w", err)
+	}
+
+	changes := balanceChanges(diff)
+	es.log.WithFields(logrus.Fields{
+		"from":    tx.From.Hex(),
+		"to":      tx.To.Hex(),
+		"changed": len(changes),
+	}).Info("Simulated balance changes")
+	return changes, nil
+}
+
+// balanceChanges computes post minus pre balances from a prestate diff
+func balanceChanges(diff prestateDiff) map[common.Address]*big.Int {
+	changes := make(map[common.Address]*big.Int)
+	balance := func(acc prestateAccount) *big.Int {
+		if acc.Balance == nil {
+			return new(big.Int)
+		}
+		return acc.Balance.ToInt()
+	}
+
+	for addr, pre := range diff.Pre {
+		post, ok := diff.Post[addr]
+		switch {
+		case !ok: // deleted, e.g. self-destructed
+			if delta := new(big.Int).Neg(balance(pre)); delta.Sign() != 0 {
+				changes[addr] = delta
+			}
+		case post.Balance != nil:
+			if delta := new(big.Int).Sub(balance(post), balance(pre)); delta.Sign() != 0 {
+				changes[addr] = delta
+			}
+		}
+	}
+	for addr, post := range diff.Post {
+		if _, ok := diff.Pre[addr]; !ok && balance(post).Sign() != 0 { // created by the call
+			changes[addr] = new(big.Int).Set(balance(post))
+		}
+	}
+	return changes
+}
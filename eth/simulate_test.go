@@ -0,0 +1,86 @@
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGhostClient_SimulateBalanceChanges(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	recipient := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	miner := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	untouched := common.HexToAddress("0x3333333333333333333333333333333333333333")
+	created := common.HexToAddress("0x4444444444444444444444444444444444444444")
+
+	// The sender pays 1 ETH plus 21000 gas at 1 gwei and the miner earns 21000 wei. untouched
+	// only has a storage change and created is funded by an internal call.
+	trace := `{
+		"pre": {
+			"` + acc.Address.Hex() + `": {"balance": "0x1bc16d674ec80000", "nonce": 3},
+			"` + recipient.Hex() + `": {"balance": "0x0"},
+			"` + miner.Hex() + `": {"balance": "0x64"},
+			"` + untouched.Hex() + `": {"balance": "0x5", "storage": {}}
+		},
+		"post": {
+			"` + acc.Address.Hex() + `": {"balance": "0xde0a39a35d9b000", "nonce": 4},
+			"` + recipient.Hex() + `": {"balance": "0xde0b6b3a7640000"},
+			"` + miner.Hex() + `": {"balance": "0x526c"},
+			"` + untouched.Hex() + `": {"storage": {}},
+			"` + created.Hex() + `": {"balance": "0x2"}
+		}
+	}`
+
+	mockRPC := &internalmocks.RPCClient{}
+	mockRPC.On("CallContext", mock.Anything, mock.Anything, "debug_traceCall", mock.Anything, "latest", mock.Anything).
+		Run(func(args mock.Arguments) {
+			tracer := args.Get(5).(map[string]interface{})
+			assert.Equal(t, "prestateTracer", tracer["tracer"])
+			assert.NoError(t, json.Unmarshal([]byte(trace), args.Get(1)))
+		}).
+		Return(nil).Once()
+	gc := &ghostClient{
+		rpc:     mockRPC,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	tx := &Transaction{From: acc.Address, To: recipient, Value: big.NewInt(1e18), GasPrice: big.NewInt(GWEI)}
+	changes, err := gc.SimulateBalanceChanges(context.Background(), tx)
+	assert.NoError(t, err)
+	assert.Len(t, changes, 4)
+	assert.Equal(t, big.NewInt(-1e18-21000*GWEI), changes[acc.Address])
+	assert.Equal(t, big.NewInt(1e18), changes[recipient])
+	assert.Equal(t, big.NewInt(21000), changes[miner])
+	assert.Equal(t, big.NewInt(2), changes[created])
+	assert.NotContains(t, changes, untouched)
+	mockRPC.AssertExpectations(t)
+}
+
+func TestGhostClient_SimulateBalanceChanges_Unsupported(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockRPC := &internalmocks.RPCClient{}
+	mockRPC.On("CallContext", mock.Anything, mock.Anything, "debug_traceCall", mock.Anything, "latest", mock.Anything).
+		Return(&testRPCError{code: -32601, message: "the method debug_traceCall does not exist/is not available"}).Once()
+	gc := &ghostClient{
+		rpc:     mockRPC,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	_, err := gc.SimulateBalanceChanges(context.Background(), &Transaction{From: acc.Address, To: acc.Address})
+	assert.ErrorIs(t, err, ErrTracingUnsupported)
+	mockRPC.AssertExpectations(t)
+}
@@ -0,0 +1,115 @@
+package eth
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/sirupsen/logrus"
+)
+
+// SpeedUpTransaction replaces the pending transaction hash, sent from the account, with a copy at
+// the same nonce, recipient, value, data and gas limit whose fees are raised by bumpPercent, and
+// sends it. bumpPercent must be at least the 10% nodes require of a replacement; bumped fees are
+// rounded up and rise by at least 1 wei. Legacy transactions stay legacy with the gas price bumped.
+// The replacement is rejected when its fee cap exceeds ETH_MAX_FEE_PER_GAS, and an error is
+// returned when hash is already mined.
+func (es *ghostClient) SpeedUpTransaction(hash common.Hash, bumpPercent int) (*types.Transaction, error) {
+	if bumpPercent < replacementPriceBump {
+		return nil, fmt.Errorf("bump percent %d is below the minimum replacement bump of %d%%", bumpPercent, replacementPriceBump)
+	}
+
+	oldTx, isPending, err := es.readClient().TransactionByHash(es.ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction %s: %w", hash.Hex(), err)
+	}
+	if !isPending {
+		return nil, fmt.Errorf("transaction %s is already mined", hash.Hex())
+	}
+
+	var newTx *types.Transaction
+	ceiling := es.config.MaxFeePerGas()
+	switch oldTx.Type() {
+	case types.DynamicFeeTxType:
+		maxFee := bumpFee(oldTx.GasFeeCap(), bumpPercent)
+		if maxFee.Cmp(ceiling) > 0 {
+			return nil, fmt.Errorf("bumped max fee per gas %s exceeds the ceiling of %s", maxFee, ceiling)
+		}
+		newTx, err = es.resignWithFees(es.account, oldTx, maxFee, bumpFee(oldTx.GasTipCap(), bumpPercent))
+	case types.LegacyTxType:
+		gasPrice := bumpFee(oldTx.GasPrice(), bumpPercent)
+		if gasPrice.Cmp(ceiling) > 0 {
+			return nil, fmt.Errorf("bumped gas price %s exceeds the ceiling of %s", gasPrice, ceiling)
+		}
+		newTx, err = es.resignWithGasPrice(es.account, oldTx, gasPrice)
+	default:
+		return nil, fmt.Errorf("%w: cannot speed up transaction %s of type %d", ErrTxTypeNotSupported, hash.Hex(), oldTx.Type())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to re-sign transaction %s: %w", hash.Hex(), err)
+	}
+
+	es.log.WithFields(logrus.Fields{
+		"hash":         hash.Hex(),
+		"replacement":  newTx.Hash().Hex(),
+		"nonce":        newTx.Nonce(),
+		"bump_percent": bumpPercent,
+		"old_fee_cap":  oldTx.GasFeeCap().String(),
+		"new_fee_cap":  newTx.GasFeeCap().String(),
+	}).Info("Speeding up transaction")
+
+	es.metadata.put(newTx.Hash(), es.metadata.get(hash))
+	if _, err := es.sendTransaction(es.account, newTx); err != nil {
+		// -- a rejected replacement hands its nonce back, but the original transaction still holds it
+		if es.nonces != nil {
+			es.nonces.reserve(es.account.Address, newTx.Nonce())
+		}
+		return nil, err
+	}
+	return newTx, nil
+}
+
+// bumpFee returns fee raised by percent, rounded up and at least 1 wei higher, as nodes only accept
+// a replacement whose fees strictly increase
+func bumpFee(fee *big.Int, percent int) *big.Int {
+	bumped := new(big.Int).Mul(fee, big.NewInt(int64(100+percent)))
+	bumped.Add(bumped, big.NewInt(99))
+	bumped.Div(bumped, big.NewInt(100))
+	if bumped.Cmp(fee) <= 0 {
+		bumped.Add(fee, big.NewInt(1))
+	}
+	return bumped
+}
+
+// resignWithGasPrice re-signs the legacy transaction signedTx with acc with the same nonce,
+// recipient, value, gas limit and data, and the given gas price. signedTx must have been signed by
+// acc.
+func (es *ghostClient) resignWithGasPrice(acc *Account, signedTx *types.Transaction, gasPrice *big.Int) (*types.Transaction, error) {
+	from, err := types.Sender(es.Signer(), signedTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover sender: %w", err)
+	}
+	if from != acc.Address {
+		return nil, fmt.Errorf("transaction is from %s, not the signing account", from.Hex())
+	}
+
+	tx := &Transaction{
+		From:     from,
+		Value:    signedTx.Value(),
+		Data:     signedTx.Data(),
+		GasLimit: signedTx.Gas(),
+		GasPrice: gasPrice,
+		Nonce:    signedTx.Nonce(),
+	}
+	if to := signedTx.To(); to != nil {
+		tx.To = *to
+	} else {
+		tx.IsContractCreation = true
+	}
+	ethereumTx, err := es.buildTx(tx)
+	if err != nil {
+		return nil, err
+	}
+	return types.SignTx(ethereumTx, es.Signer(), acc.PrivateKey)
+}
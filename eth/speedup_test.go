@@ -0,0 +1,107 @@
+package eth
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGhostClient_SpeedUpTransaction(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	oldTx := testBroadcastTx(t, acc)
+
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("TransactionByHash", mock.Anything, oldTx.Hash()).Return(oldTx, true, nil)
+	var sent *types.Transaction
+	mockClient.On("SendTransaction", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		sent = args.Get(1).(*types.Transaction)
+	}).Return(nil).Once()
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	newTx, err := gc.SpeedUpTransaction(oldTx.Hash(), 20)
+	assert.NoError(t, err)
+	assert.Equal(t, newTx.Hash(), sent.Hash())
+	assert.Equal(t, oldTx.Nonce(), newTx.Nonce())
+	assert.Equal(t, oldTx.To(), newTx.To())
+	assert.Equal(t, oldTx.Gas(), newTx.Gas())
+	assert.Equal(t, big.NewInt(120), newTx.GasFeeCap())
+	assert.Equal(t, big.NewInt(2), newTx.GasTipCap()) // 1.2 rounded up
+	assert.False(t, gc.IsReplacementUnderpriced(context.Background(), oldTx, newTx))
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_SpeedUpTransaction_Legacy(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	to := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	oldTx, err := types.SignNewTx(acc.PrivateKey, types.LatestSignerForChainID(big.NewInt(1)), &types.LegacyTx{
+		Nonce: 3, To: &to, Gas: 21000, GasPrice: big.NewInt(100),
+	})
+	assert.NoError(t, err)
+
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("TransactionByHash", mock.Anything, oldTx.Hash()).Return(oldTx, true, nil)
+	mockClient.On("SendTransaction", mock.Anything, mock.Anything).Return(nil).Once()
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	newTx, err := gc.SpeedUpTransaction(oldTx.Hash(), 10)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(types.LegacyTxType), newTx.Type())
+	assert.Equal(t, uint64(3), newTx.Nonce())
+	assert.Equal(t, big.NewInt(110), newTx.GasPrice())
+	assert.False(t, gc.IsReplacementUnderpriced(context.Background(), oldTx, newTx))
+}
+
+func TestGhostClient_SpeedUpTransaction_Rejected(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	oldTx := testBroadcastTx(t, acc)
+	expensiveTx, err := types.SignNewTx(acc.PrivateKey, types.LatestSignerForChainID(big.NewInt(1)), &types.DynamicFeeTx{
+		ChainID: big.NewInt(1), Nonce: 1, Gas: 21000, GasFeeCap: big.NewInt(DEFAULT_MAX_FEE_PER_GAS), GasTipCap: big.NewInt(1),
+	})
+	assert.NoError(t, err)
+
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("TransactionByHash", mock.Anything, oldTx.Hash()).Return(oldTx, false, nil)
+	mockClient.On("TransactionByHash", mock.Anything, expensiveTx.Hash()).Return(expensiveTx, true, nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	_, err = gc.SpeedUpTransaction(oldTx.Hash(), 5)
+	assert.ErrorContains(t, err, "below the minimum replacement bump")
+	_, err = gc.SpeedUpTransaction(oldTx.Hash(), 20)
+	assert.ErrorContains(t, err, "already mined")
+	_, err = gc.SpeedUpTransaction(expensiveTx.Hash(), 20)
+	assert.ErrorContains(t, err, "exceeds the ceiling")
+	mockClient.AssertNotCalled(t, "SendTransaction", mock.Anything, mock.Anything)
+}
+
+func TestBumpFee(t *testing.T) {
+	assert.Equal(t, big.NewInt(110), bumpFee(big.NewInt(100), 10))
+	assert.Equal(t, big.NewInt(13), bumpFee(big.NewInt(11), 10)) // 12.1 rounded up
+	assert.Equal(t, big.NewInt(1), bumpFee(big.NewInt(0), 10))
+}
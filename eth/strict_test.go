@@ -0,0 +1,224 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGhostClient_StrictMode_NilValue(t *testing.T) {
+	t.Setenv("ETH_STRICT_MODE", "true")
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	_, err := gc.SignTransaction(&Transaction{From: acc.Address, To: acc.Address, Data: []byte{0x01}})
+	assert.ErrorContains(t, err, "value is nil")
+	mockClient.AssertExpectations(t) // rejected before any network call
+}
+
+func TestGhostClient_StrictMode_ContractRejectsETH(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	contract := common.HexToAddress("0x00000000000000000000000000000000000c0de1")
+	header := &types.Header{GasLimit: 30000000, BaseFee: big.NewInt(100)}
+	newTx := func() *Transaction {
+		return &Transaction{From: acc.Address, To: contract, Value: big.NewInt(1e18), Nonce: 1, GasLimit: 30000}
+	}
+
+	// non-strict: the transfer is signed without checking the recipient
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(header, nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	_, err := gc.SignTransaction(newTx())
+	assert.NoError(t, err)
+	mockClient.AssertNotCalled(t, "CodeAt", mock.Anything, mock.Anything, mock.Anything)
+
+	// strict: the simulated transfer reverts
+	t.Setenv("ETH_STRICT_MODE", "true")
+	mockClient.On("CodeAt", mock.Anything, contract, (*big.Int)(nil)).Return([]byte{0x60, 0x80}, nil)
+	mockClient.On("CallContract", mock.Anything, mock.Anything, (*big.Int)(nil)).Return(nil, errors.New("execution reverted"))
+	_, err = gc.SignTransaction(newTx())
+	assert.ErrorContains(t, err, "cannot receive ETH")
+	assert.ErrorIs(t, err, ErrNonPayableRecipient)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_StrictMode_BlockGasCap(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(21000), nil)
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(nil, errors.New("header unavailable"))
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	tx := &Transaction{From: acc.Address, To: acc.Address}
+
+	// non-strict: an unavailable header skips the cap check
+	assert.NoError(t, gc.estimateGasAndSetLimit(tx))
+
+	t.Setenv("ETH_STRICT_MODE", "true")
+	tx.GasLimit = 0
+	assert.ErrorContains(t, gc.estimateGasAndSetLimit(tx), "block gas cap")
+}
+
+func TestGhostClient_StrictMode_PresetGasLimitAboveCap(t *testing.T) {
+	t.Setenv("ETH_STRICT_MODE", "true")
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{GasLimit: 12000000}, nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	_, err := gc.SignTransaction(&Transaction{From: acc.Address, To: acc.Address, Value: big.NewInt(0), Nonce: 1, GasLimit: 10000000})
+	assert.ErrorContains(t, err, "exceeds maximum allowed")
+}
+
+func TestGhostClient_StrictMode_ChainIDMismatch(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("SendTransaction", mock.Anything, mock.Anything).Return(nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	to := common.HexToAddress("0x02")
+	signed, err := types.SignNewTx(acc.PrivateKey, types.LatestSignerForChainID(big.NewInt(8453)), &types.DynamicFeeTx{
+		ChainID: big.NewInt(8453), To: &to, Gas: 21000, GasFeeCap: big.NewInt(1), GasTipCap: big.NewInt(1),
+	})
+	assert.NoError(t, err)
+
+	// non-strict: broadcast is left to the node to reject
+	_, err = gc.SendTransaction(signed)
+	assert.NoError(t, err)
+
+	t.Setenv("ETH_STRICT_MODE", "true")
+	_, err = gc.SendTransaction(signed)
+	assert.ErrorContains(t, err, "does not match connected chain")
+	mockClient.AssertNumberOfCalls(t, "SendTransaction", 1)
+}
+
+func TestGhostClient_StrictMode_ChainIDMismatch_NonceManager(t *testing.T) {
+	t.Setenv("ETH_STRICT_MODE", "true")
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("PendingNonceAt", mock.Anything, acc.Address).Return(uint64(7), nil).Once()
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	gc.nonces = gc.newNonceManager()
+	nonce, err := gc.nonces.Next(context.Background(), acc.Address)
+	assert.NoError(t, err)
+
+	to := common.HexToAddress("0x02")
+	signed, err := types.SignNewTx(acc.PrivateKey, types.LatestSignerForChainID(big.NewInt(8453)), &types.DynamicFeeTx{
+		ChainID: big.NewInt(8453), Nonce: nonce, To: &to, Gas: 21000, GasFeeCap: big.NewInt(1), GasTipCap: big.NewInt(1),
+	})
+	assert.NoError(t, err)
+	_, err = gc.SendTransaction(signed)
+	assert.ErrorContains(t, err, "does not match connected chain")
+
+	// -- the rejected transaction's nonce is handed out again
+	next, err := gc.nonces.Next(context.Background(), acc.Address)
+	assert.NoError(t, err)
+	assert.Equal(t, nonce, next)
+	mockClient.AssertNotCalled(t, "SendTransaction", mock.Anything, mock.Anything)
+}
+
+func TestNewGhostClient_StrictMode_AccountChainIDMismatch(t *testing.T) {
+	t.Setenv("ETH_STRICT_MODE", "true")
+	acc, cfg := testAccountAndConfig()
+	acc.ChainId = 8453
+
+	_, err := NewGhostClient(acc, cfg, newTestLogger())
+	assert.ErrorContains(t, err, "does not match configured chain ID")
+}
+
+func TestGhostClient_PayableCheck(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	contract := common.HexToAddress("0x00000000000000000000000000000000000c0de1")
+	header := &types.Header{GasLimit: 30000000, BaseFee: big.NewInt(100)}
+	withValue := mock.MatchedBy(func(msg ethereum.CallMsg) bool { return msg.Value != nil && msg.Value.Sign() > 0 })
+	withoutValue := mock.MatchedBy(func(msg ethereum.CallMsg) bool { return msg.Value == nil || msg.Value.Sign() == 0 })
+	reverted := &testRPCError{code: 3, message: "execution reverted"}
+
+	tests := []struct {
+		name        string
+		data        []byte
+		valueErr    error
+		zeroErr     error
+		nonPayable  bool
+		errContains string
+	}{
+		{name: "transfer without payable receive", valueErr: reverted, nonPayable: true},
+		{name: "call to non-payable function", data: []byte{0xa9, 0x05, 0x9c, 0xbb}, valueErr: reverted, nonPayable: true},
+		{name: "call reverting regardless of value", data: []byte{0xa9, 0x05, 0x9c, 0xbb}, valueErr: reverted, zeroErr: reverted, errContains: "reverts"},
+		{name: "simulation unavailable", valueErr: errors.New("connection refused"), errContains: "failed to simulate"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &internalmocks.EthClient{}
+			mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(header, nil)
+			mockClient.On("CodeAt", mock.Anything, contract, (*big.Int)(nil)).Return([]byte{0x60, 0x80}, nil)
+			mockClient.On("CallContract", mock.Anything, withValue, (*big.Int)(nil)).Return(nil, tt.valueErr)
+			mockClient.On("CallContract", mock.Anything, withoutValue, (*big.Int)(nil)).Return([]byte{}, tt.zeroErr)
+			gc := &ghostClient{
+				client:  mockClient,
+				ctx:     context.Background(),
+				chainId: 1,
+				account: acc,
+				config:  cfg,
+				log:     newTestLogger(),
+			}
+			WithPayableCheck()(gc)
+
+			_, err := gc.SignTransaction(&Transaction{From: acc.Address, To: contract, Value: big.NewInt(1e18), Data: tt.data, Nonce: 1, GasLimit: 60000})
+			assert.Error(t, err)
+			assert.Equal(t, tt.nonPayable, errors.Is(err, ErrNonPayableRecipient))
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sirupsen/logrus"
+)
+
+// SweepTokens transfers the account's full balance of each token to the destination, skipping
+// tokens it holds none of. Nonces are assigned sequentially from the pending nonce, or by the
+// nonce manager when ETH_NONCE_MANAGER is set, so the transfers can be broadcast back to back. On
+// error the hashes of the transfers already sent are returned along with it.
+func (es *ghostClient) SweepTokens(ctx context.Context, tokens []common.Address, to common.Address) ([]common.Hash, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	owner := es.account.Address
+
+	balanceOfData, err := erc20ABI.Pack("balanceOf", owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode balanceOf: %w", err)
+	}
+	// -- with ETH_NONCE_MANAGER set, SignTransaction assigns tracked nonces instead
+	var nonce uint64
+	if es.nonces == nil {
+		nonce, err = es.readClient().PendingNonceAt(ctx, owner)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get nonce: %w", err)
+		}
+	}
+
+	var hashes []common.Hash
+	for _, token := range tokens {
+		out, err := es.readClient().CallContract(ctx, ethereum.CallMsg{From: owner, To: &token, Data: balanceOfData}, nil)
+		if err != nil {
+			return hashes, fmt.Errorf("balanceOf failed for token %s: %w", token.Hex(), err)
+		}
+		balance, err := unpackUint256(erc20ABI, "balanceOf", out)
+		if err != nil {
+			return hashes, fmt.Errorf("failed to decode balance of token %s: %w", token.Hex(), err)
+		}
+		if balance.Sign() == 0 {
+			es.log.WithField("token", token.Hex()).Debug("Skipping token with zero balance")
+			continue
+		}
+
+		tx, err := NewContractTx(token, erc20ABI, "transfer", big.NewInt(0), to, balance)
+		if err != nil {
+			return hashes, err
+		}
+		tx.From = owner
+		if es.nonces == nil {
+			tx.Nonce = nonce
+		}
+
+		signedTx, err := es.SignTransaction(tx)
+		if err != nil {
+			return hashes, fmt.Errorf("failed to sign transfer of token %s: %w", token.Hex(), err)
+		}
+		receipt, err := es.SendTransaction(signedTx)
+		if err != nil {
+			return hashes, fmt.Errorf("failed to send transfer of token %s: %w", token.Hex(), err)
+		}
+		hashes = append(hashes, receipt.TxHash)
+		nonce = signedTx.Nonce() + 1
+
+		es.log.WithFields(logrus.Fields{
+			"token":  token.Hex(),
+			"amount": balance.String(),
+			"hash":   receipt.TxHash.Hex(),
+		}).Info("Swept token balance")
+	}
+	return hashes, nil
+}
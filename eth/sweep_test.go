@@ -0,0 +1,94 @@
+package eth
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGhostClient_SweepTokens(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	emptyToken := common.HexToAddress("0x00000000000000000000000000000000000000e1")
+	fullToken := common.HexToAddress("0x00000000000000000000000000000000000000f1")
+	dest := common.HexToAddress("0x00000000000000000000000000000000000000d1")
+
+	callTo := func(token common.Address) interface{} {
+		return mock.MatchedBy(func(msg ethereum.CallMsg) bool { return msg.To != nil && *msg.To == token })
+	}
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("PendingNonceAt", mock.Anything, acc.Address).Return(uint64(4), nil)
+	mockClient.On("CallContract", mock.Anything, callTo(emptyToken), (*big.Int)(nil)).
+		Return(testReturn(t, "balanceOf", big.NewInt(0)).ReturnData, nil)
+	mockClient.On("CallContract", mock.Anything, callTo(fullToken), (*big.Int)(nil)).
+		Return(testReturn(t, "balanceOf", big.NewInt(2500)).ReturnData, nil)
+	mockClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(50000), nil)
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{GasLimit: 30000000, BaseFee: big.NewInt(100)}, nil)
+	var sent []*types.Transaction
+	mockClient.On("SendTransaction", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { sent = append(sent, args.Get(1).(*types.Transaction)) }).
+		Return(nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	hashes, err := gc.SweepTokens(context.Background(), []common.Address{emptyToken, fullToken}, dest)
+	assert.NoError(t, err)
+	assert.Len(t, hashes, 1)
+	assert.Len(t, sent, 1)
+	mockClient.AssertNumberOfCalls(t, "SendTransaction", 1)
+
+	tx := sent[0]
+	assert.Equal(t, hashes[0], tx.Hash())
+	assert.Equal(t, fullToken, *tx.To())
+	assert.Equal(t, uint64(4), tx.Nonce())
+	assert.Equal(t, 0, tx.Value().Sign())
+	expected, err := EncodeCall(erc20ABI, "transfer", dest, big.NewInt(2500))
+	assert.NoError(t, err)
+	assert.Equal(t, expected, tx.Data())
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_SweepTokens_NonceManager(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	tokenA := common.HexToAddress("0x00000000000000000000000000000000000000a1")
+	tokenB := common.HexToAddress("0x00000000000000000000000000000000000000b1")
+	dest := common.HexToAddress("0x00000000000000000000000000000000000000d1")
+
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("PendingNonceAt", mock.Anything, acc.Address).Return(uint64(4), nil).Once()
+	mockClient.On("CallContract", mock.Anything, mock.Anything, (*big.Int)(nil)).
+		Return(testReturn(t, "balanceOf", big.NewInt(2500)).ReturnData, nil)
+	mockClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(50000), nil)
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{GasLimit: 30000000, BaseFee: big.NewInt(100)}, nil)
+	mockClient.On("SendTransaction", mock.Anything, mock.Anything).Return(nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	gc.nonces = gc.newNonceManager()
+
+	_, err := gc.SweepTokens(context.Background(), []common.Address{tokenA, tokenB}, dest)
+	assert.NoError(t, err)
+
+	// -- the sweep's nonces came from the manager, so the next transaction follows them
+	signedTx, err := gc.SignTransaction(&Transaction{From: acc.Address, To: acc.Address, Value: big.NewInt(1)})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(6), signedTx.Nonce())
+	mockClient.AssertExpectations(t)
+}
@@ -0,0 +1,51 @@
+package eth
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// codeCacheTTL is how long WithTransferGas trusts a recipient's code lookup
+const codeCacheTTL = time.Minute
+
+// cachedCode records whether an address had code when it was last looked up
+type cachedCode struct {
+	hasCode   bool
+	fetchedAt time.Time
+}
+
+// isPlainTransfer reports whether tx only moves ETH to an externally owned account, so its gas is
+// exactly the 21000 intrinsic cost. A failed code lookup counts as not plain, leaving the decision
+// to gas estimation.
+func (es *ghostClient) isPlainTransfer(tx *Transaction) bool {
+	if tx.IsContractCreation || len(tx.Data) > 0 || len(tx.AccessList) > 0 {
+		return false
+	}
+	hasCode, err := es.hasCode(tx.To)
+	if err != nil {
+		es.log.WithError(err).WithField("to", tx.To.Hex()).Warn("Failed to check recipient code, estimating gas")
+		return false
+	}
+	return !hasCode
+}
+
+// hasCode reports whether address has code deployed, caching the answer for codeCacheTTL
+func (es *ghostClient) hasCode(address common.Address) (bool, error) {
+	es.codeCacheMu.Lock()
+	defer es.codeCacheMu.Unlock()
+
+	if cached, ok := es.codeCache[address]; ok && time.Since(cached.fetchedAt) < codeCacheTTL {
+		return cached.hasCode, nil
+	}
+	size, err := es.CodeSize(es.ctx, address)
+	if err != nil {
+		return false, err
+	}
+
+	if es.codeCache == nil {
+		es.codeCache = make(map[common.Address]cachedCode)
+	}
+	es.codeCache[address] = cachedCode{hasCode: size > 0, fetchedAt: time.Now()}
+	return size > 0, nil
+}
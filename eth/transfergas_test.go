@@ -0,0 +1,73 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGhostClient_TransferGas_SkipsEstimateForEOA(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	to := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	mockClient := &internalmocks.EthClient{}
+	// -- looked up once, then served from the cache
+	mockClient.On("CodeAt", mock.Anything, to, (*big.Int)(nil)).Return([]byte{}, nil).Once()
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{GasLimit: 30000000}, nil)
+	gc := &ghostClient{
+		client:      mockClient,
+		ctx:         context.Background(),
+		chainId:     1,
+		account:     acc,
+		config:      cfg,
+		log:         newTestLogger(),
+		transferGas: true,
+	}
+
+	for i := 0; i < 2; i++ {
+		tx := &Transaction{From: acc.Address, To: to, Value: big.NewInt(1)}
+		assert.NoError(t, gc.estimateGasAndSetLimit(tx))
+		// -- 21000 with the default simple buffer of 1.1
+		assert.Equal(t, uint64(23100), tx.GasLimit)
+	}
+	mockClient.AssertNotCalled(t, "EstimateGas", mock.Anything, mock.Anything)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_TransferGas_EstimatesOtherwise(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	contract := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	unknown := common.HexToAddress("0x01")
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("CodeAt", mock.Anything, contract, (*big.Int)(nil)).Return([]byte{0x60, 0x80}, nil).Once()
+	mockClient.On("CodeAt", mock.Anything, unknown, (*big.Int)(nil)).Return(nil, errors.New("connection refused")).Once()
+	mockClient.On("EstimateGas", mock.Anything, mock.Anything).Return(uint64(30000), nil)
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{GasLimit: 30000000}, nil)
+	gc := &ghostClient{
+		client:      mockClient,
+		ctx:         context.Background(),
+		chainId:     1,
+		account:     acc,
+		config:      cfg,
+		log:         newTestLogger(),
+		transferGas: true,
+	}
+
+	for _, tx := range []*Transaction{
+		{From: acc.Address, To: contract, Value: big.NewInt(1)},                        // contract recipient
+		{From: acc.Address, To: unknown, Value: big.NewInt(1)},                         // code lookup failed
+		{From: acc.Address, To: acc.Address, Value: big.NewInt(1), Data: []byte{0x01}}, // call data
+		{From: acc.Address, To: acc.Address, Value: big.NewInt(1), AccessList: types.AccessList{{Address: contract}}},
+	} {
+		assert.NoError(t, gc.estimateGasAndSetLimit(tx))
+		assert.NotEqual(t, uint64(23100), tx.GasLimit)
+	}
+	mockClient.AssertNumberOfCalls(t, "EstimateGas", 4)
+	mockClient.AssertExpectations(t)
+}
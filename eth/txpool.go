@@ -0,0 +1,83 @@
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sirupsen/logrus"
+)
+
+// txPoolContent is the txpool_content result: transactions by sub-pool, sender and nonce. Each
+// nonce normally holds one transaction, but some nodes list several, so entries are decoded by
+// txPoolEntry.
+type txPoolContent struct {
+	Pending map[string]map[string]json.RawMessage `json:"pending"`
+	Queued  map[string]map[string]json.RawMessage `json:"queued"`
+}
+
+// txPoolTx is the part of a txpool transaction FindReplacements needs
+type txPoolTx struct {
+	Hash common.Hash `json:"hash"`
+}
+
+// txPoolEntry decodes the transactions listed under one nonce, a single object or an array
+func txPoolEntry(raw json.RawMessage) ([]txPoolTx, error) {
+	var txs []txPoolTx
+	if err := json.Unmarshal(raw, &txs); err == nil {
+		return txs, nil
+	}
+	var tx txPoolTx
+	if err := json.Unmarshal(raw, &tx); err != nil {
+		return nil, err
+	}
+	return []txPoolTx{tx}, nil
+}
+
+// FindReplacements returns the hashes of the transactions from addr with the given nonce in the
+// node's mempool, pending ones first, as listed by txpool_content. These are the candidates a
+// speed-up or cancellation may have replaced each other with. Nodes without the txpool namespace
+// fail with ErrTxPoolUnavailable.
+func (es *ghostClient) FindReplacements(ctx context.Context, addr common.Address, nonce uint64) ([]common.Hash, error) {
+	var content txPoolContent
+	if err := es.rpcClient().CallContext(ctx, &content, "txpool_content"); err != nil {
+		if isMethodNotFound(err) {
+			return nil, fmt.Errorf("%w: %v", ErrTxPoolUnavailable, err)
+		}
+		return nil, fmt.Errorf("failed to read txpool: %w", err)
+	}
+
+	var hashes []common.Hash
+	seen := make(map[common.Hash]bool)
+	for _, pool := range []map[string]map[string]json.RawMessage{content.Pending, content.Queued} {
+		for sender, byNonce := range pool {
+			if !strings.EqualFold(sender, addr.Hex()) {
+				continue
+			}
+			raw, ok := byNonce[strconv.FormatUint(nonce, 10)]
+			if !ok {
+				continue
+			}
+			txs, err := txPoolEntry(raw)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode txpool entry for %s nonce %d: %w", addr.Hex(), nonce, err)
+			}
+			for _, tx := range txs {
+				if !seen[tx.Hash] {
+					seen[tx.Hash] = true
+					hashes = append(hashes, tx.Hash)
+				}
+			}
+		}
+	}
+
+	es.log.WithFields(logrus.Fields{
+		"address": addr.Hex(),
+		"nonce":   nonce,
+		"count":   len(hashes),
+	}).Debug("Found replacement candidates")
+	return hashes, nil
+}
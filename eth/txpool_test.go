@@ -0,0 +1,121 @@
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGhostClient_FindReplacements(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	original := common.HexToHash("0x01")
+	speedUp := common.HexToHash("0x02")
+	queued := common.HexToHash("0x03")
+	otherNonce := common.HexToHash("0x04")
+	otherSender := common.HexToHash("0x05")
+
+	// Two transactions share nonce 7 in the pending pool and a third is queued under the
+	// sender's lowercase address, as some nodes report it
+	content := `{
+		"pending": {
+			"` + acc.Address.Hex() + `": {
+				"7": [{"hash": "` + original.Hex() + `", "nonce": "0x7"}, {"hash": "` + speedUp.Hex() + `", "nonce": "0x7"}],
+				"8": {"hash": "` + otherNonce.Hex() + `", "nonce": "0x8"}
+			},
+			"0x1111111111111111111111111111111111111111": {
+				"7": {"hash": "` + otherSender.Hex() + `", "nonce": "0x7"}
+			}
+		},
+		"queued": {
+			"` + strings.ToLower(acc.Address.Hex()) + `": {
+				"7": {"hash": "` + queued.Hex() + `", "nonce": "0x7"}
+			}
+		}
+	}`
+
+	mockRPC := &internalmocks.RPCClient{}
+	mockRPC.On("CallContext", mock.Anything, mock.Anything, "txpool_content").
+		Run(func(args mock.Arguments) {
+			assert.NoError(t, json.Unmarshal([]byte(content), args.Get(1)))
+		}).
+		Return(nil).Once()
+	gc := &ghostClient{
+		rpc:     mockRPC,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	hashes, err := gc.FindReplacements(context.Background(), acc.Address, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, []common.Hash{original, speedUp, queued}, hashes)
+	mockRPC.AssertExpectations(t)
+}
+
+func TestGhostClient_FindReplacements_None(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockRPC := &internalmocks.RPCClient{}
+	mockRPC.On("CallContext", mock.Anything, mock.Anything, "txpool_content").
+		Run(func(args mock.Arguments) {
+			assert.NoError(t, json.Unmarshal([]byte(`{"pending": {}, "queued": {}}`), args.Get(1)))
+		}).
+		Return(nil).Once()
+	gc := &ghostClient{
+		rpc:     mockRPC,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	hashes, err := gc.FindReplacements(context.Background(), acc.Address, 7)
+	assert.NoError(t, err)
+	assert.Empty(t, hashes)
+}
+
+func TestGhostClient_FindReplacements_Unavailable(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockRPC := &internalmocks.RPCClient{}
+	mockRPC.On("CallContext", mock.Anything, mock.Anything, "txpool_content").
+		Return(&testRPCError{code: -32601, message: "the method txpool_content does not exist/is not available"}).Once()
+	gc := &ghostClient{
+		rpc:     mockRPC,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	_, err := gc.FindReplacements(context.Background(), acc.Address, 7)
+	assert.ErrorIs(t, err, ErrTxPoolUnavailable)
+}
+
+func TestGhostClient_FindReplacements_Error(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockRPC := &internalmocks.RPCClient{}
+	mockRPC.On("CallContext", mock.Anything, mock.Anything, "txpool_content").
+		Return(errors.New("connection reset")).Once()
+	gc := &ghostClient{
+		rpc:     mockRPC,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	_, err := gc.FindReplacements(context.Background(), acc.Address, 7)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrTxPoolUnavailable)
+}
@@ -0,0 +1,111 @@
+package eth
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// testCalldataTx signs a transaction from acc carrying dataLen bytes of calldata
+func testCalldataTx(t *testing.T, acc *Account, dataLen int) *types.Transaction {
+	to := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	tx, err := types.SignNewTx(acc.PrivateKey, types.LatestSignerForChainID(big.NewInt(1)), &types.DynamicFeeTx{
+		ChainID: big.NewInt(1), To: &to, Gas: 5000000, GasFeeCap: big.NewInt(100), GasTipCap: big.NewInt(1),
+		Data: make([]byte, dataLen),
+	})
+	assert.NoError(t, err)
+	return tx
+}
+
+func TestGhostClient_SendTransaction_MaxTxSize(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	// -- the encoding adds well under 200 bytes to the calldata
+	underTx := testCalldataTx(t, acc, DEFAULT_MAX_TX_SIZE_BYTES-200)
+	overTx := testCalldataTx(t, acc, DEFAULT_MAX_TX_SIZE_BYTES)
+	assert.Less(t, underTx.Size(), uint64(DEFAULT_MAX_TX_SIZE_BYTES))
+	assert.Greater(t, overTx.Size(), uint64(DEFAULT_MAX_TX_SIZE_BYTES))
+
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("SendTransaction", mock.Anything, underTx).Return(nil).Once()
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	receipt, err := gc.SendTransaction(underTx)
+	assert.NoError(t, err)
+	assert.Equal(t, underTx.Hash(), receipt.TxHash)
+
+	_, err = gc.SendTransaction(overTx)
+	assert.ErrorIs(t, err, ErrTxTooLarge)
+	mockClient.AssertNotCalled(t, "SendTransaction", mock.Anything, overTx)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_SendTransaction_MaxTxSizeConfigured(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	tx := testCalldataTx(t, acc, 1000)
+
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("SendTransaction", mock.Anything, tx).Return(nil).Once()
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	// -- one byte over the limit
+	t.Setenv(envMaxTxSizeBytes, strconv.FormatUint(tx.Size()-1, 10))
+	_, err := gc.SendTransaction(tx)
+	assert.ErrorIs(t, err, ErrTxTooLarge)
+	mockClient.AssertNotCalled(t, "SendTransaction", mock.Anything, tx)
+
+	// -- exactly at the limit
+	t.Setenv(envMaxTxSizeBytes, strconv.FormatUint(tx.Size(), 10))
+	_, err = gc.SendTransaction(tx)
+	assert.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_SendTransaction_MaxTxSize_NonceManager(t *testing.T) {
+	t.Setenv(envMaxTxSizeBytes, "300")
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("PendingNonceAt", mock.Anything, acc.Address).Return(uint64(7), nil).Once()
+	mockClient.On("HeaderByNumber", mock.Anything, (*big.Int)(nil)).Return(&types.Header{GasLimit: 30000000, BaseFee: big.NewInt(100)}, nil)
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	gc.nonces = gc.newNonceManager()
+
+	oversized, err := gc.SignTransaction(&Transaction{From: acc.Address, To: acc.Address, Value: big.NewInt(1), GasLimit: 100000, Data: make([]byte, 400)})
+	assert.NoError(t, err)
+	_, err = gc.SendTransaction(oversized)
+	assert.ErrorIs(t, err, ErrTxTooLarge)
+
+	// -- the oversized transaction never went out, so its nonce is handed out again
+	signedTx, err := gc.SignTransaction(&Transaction{From: acc.Address, To: acc.Address, Value: big.NewInt(1), GasLimit: 21000})
+	assert.NoError(t, err)
+	assert.Equal(t, oversized.Nonce(), signedTx.Nonce())
+	mockClient.AssertNotCalled(t, "SendTransaction", mock.Anything, mock.Anything)
+	mockClient.AssertExpectations(t)
+}
@@ -0,0 +1,128 @@
+package eth
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// ErrTxRecordNotFound is returned by TxStore.Get for hashes it holds no record of
+var ErrTxRecordNotFound = errors.New("transaction record not found")
+
+// TxRecordStatus is how far a recorded transaction got
+type TxRecordStatus string
+
+const (
+	TxRecordSigned TxRecordStatus = "signed" // signed, not yet accepted by the provider
+	TxRecordSent   TxRecordStatus = "sent"   // accepted by the provider, not yet mined
+	TxRecordMined  TxRecordStatus = "mined"  // included in a block, see Receipt for the outcome
+)
+
+// TransactionRecord is the audit trail a TxStore keeps for one transaction. RawTx is the signed
+// transaction's binary encoding, enough to decode and rebroadcast it after a crash.
+type TransactionRecord struct {
+	Hash      common.Hash         `json:"hash"`
+	From      common.Address      `json:"from"`
+	Nonce     uint64              `json:"nonce"`
+	RawTx     []byte              `json:"raw_tx,omitempty"`
+	Status    TxRecordStatus      `json:"status"`
+	Receipt   *TransactionReceipt `json:"receipt,omitempty"`
+	Metadata  map[string]string   `json:"metadata,omitempty"`
+	UpdatedAt time.Time           `json:"updated_at"`
+}
+
+// TxStore persists TransactionRecords, see WithTxStore. Implementations must be safe for
+// concurrent use.
+//
+// A SQL or Redis backend keys records by Hash: Record upserts the whole record (the client always
+// passes every field it knows, so a later Record replaces an earlier one), and Get returns
+// ErrTxRecordNotFound, possibly wrapped, for unknown hashes. Records can be stored as JSON or
+// column by column; RawTx is opaque bytes.
+type TxStore interface {
+	// Record inserts or replaces the record for rec.Hash
+	Record(rec *TransactionRecord) error
+	// Get returns the record for hash
+	Get(hash common.Hash) (*TransactionRecord, error)
+}
+
+// MemoryTxStore is a TxStore holding records in memory for the life of the process. It doesn't
+// survive a crash and never forgets a record, so it suits tests and short-lived tools.
+type MemoryTxStore struct {
+	mu      sync.RWMutex
+	records map[common.Hash]TransactionRecord
+}
+
+// NewMemoryTxStore returns an empty MemoryTxStore
+func NewMemoryTxStore() *MemoryTxStore {
+	return &MemoryTxStore{records: make(map[common.Hash]TransactionRecord)}
+}
+
+// Record stores a copy of rec
+func (s *MemoryTxStore) Record(rec *TransactionRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[rec.Hash] = *rec
+	return nil
+}
+
+// Get returns a copy of the record for hash
+func (s *MemoryTxStore) Get(hash common.Hash) (*TransactionRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	rec, ok := s.records[hash]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrTxRecordNotFound, hash.Hex())
+	}
+	return &rec, nil
+}
+
+// recordTransaction stores signedTx, signed by acc, with status in the configured TxStore
+func (es *ghostClient) recordTransaction(acc *Account, signedTx *types.Transaction, status TxRecordStatus) error {
+	if es.txStore == nil {
+		return nil
+	}
+	raw, err := signedTx.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("failed to encode transaction for the store: %w", err)
+	}
+	err = es.txStore.Record(&TransactionRecord{
+		Hash:      signedTx.Hash(),
+		From:      acc.Address,
+		Nonce:     signedTx.Nonce(),
+		RawTx:     raw,
+		Status:    status,
+		Metadata:  es.metadata.get(signedTx.Hash()),
+		UpdatedAt: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record transaction %s: %w", signedTx.Hash().Hex(), err)
+	}
+	return nil
+}
+
+// recordReceipt marks the transaction of receipt as mined in the configured TxStore. Failures are
+// logged: the transaction is on chain either way.
+func (es *ghostClient) recordReceipt(receipt *TransactionReceipt) {
+	if es.txStore == nil {
+		return
+	}
+	l := es.log.WithField("hash", receipt.TxHash.Hex())
+	rec, err := es.txStore.Get(receipt.TxHash)
+	if errors.Is(err, ErrTxRecordNotFound) {
+		// -- not signed through this client, keep what the receipt tells
+		rec = &TransactionRecord{Hash: receipt.TxHash, From: receipt.From, Metadata: receipt.Metadata}
+	} else if err != nil {
+		l.WithError(err).Error("Failed to read transaction record")
+		return
+	}
+	rec.Status = TxRecordMined
+	rec.Receipt = receipt
+	rec.UpdatedAt = time.Now()
+	if err := es.txStore.Record(rec); err != nil {
+		l.WithError(err).Error("Failed to record transaction receipt")
+	}
+}
@@ -0,0 +1,158 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMemoryTxStore(t *testing.T) {
+	store := NewMemoryTxStore()
+	hash := common.HexToHash("0xabc")
+
+	_, err := store.Get(hash)
+	assert.ErrorIs(t, err, ErrTxRecordNotFound)
+
+	rec := &TransactionRecord{Hash: hash, Nonce: 7, Status: TxRecordSigned}
+	assert.NoError(t, store.Record(rec))
+	rec.Status = TxRecordSent // the store keeps its own copy
+
+	got, err := store.Get(hash)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7), got.Nonce)
+	assert.Equal(t, TxRecordSigned, got.Status)
+}
+
+func TestGhostClient_TxStore_RecordsLifecycle(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	to := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	store := NewMemoryTxStore()
+	sub := newTestSubscription()
+
+	mockClient := &internalmocks.EthClient{}
+	gc := &ghostClient{
+		client:         mockClient,
+		ctx:            context.Background(),
+		chainId:        1,
+		account:        acc,
+		config:         cfg,
+		log:            newTestLogger(),
+		subscribeHeads: true,
+	}
+	WithTxStore(store)(gc)
+
+	signed, errs := gc.SignBatchOffline([]*Transaction{{
+		From: acc.Address, To: to, Value: big.NewInt(1), Nonce: 5, GasLimit: 21000,
+		MaxFeePerGas: big.NewInt(30 * GWEI), MaxPriorityFeePerGas: big.NewInt(GWEI),
+		Metadata: map[string]string{"order_id": "42"},
+	}})
+	assert.NoError(t, errs[0])
+	signedTx := signed[0]
+	hash := signedTx.Hash()
+
+	rec, err := store.Get(hash)
+	assert.NoError(t, err)
+	assert.Equal(t, TxRecordSigned, rec.Status)
+	assert.Equal(t, acc.Address, rec.From)
+	assert.Equal(t, uint64(5), rec.Nonce)
+	assert.Equal(t, "42", rec.Metadata["order_id"])
+	var decoded types.Transaction
+	assert.NoError(t, decoded.UnmarshalBinary(rec.RawTx))
+	assert.Equal(t, hash, decoded.Hash())
+
+	mockClient.On("SendTransaction", mock.Anything, signedTx).Return(nil).Once()
+	_, err = gc.SendTransaction(signedTx)
+	assert.NoError(t, err)
+
+	rec, err = store.Get(hash)
+	assert.NoError(t, err)
+	assert.Equal(t, TxRecordSent, rec.Status)
+	assert.Nil(t, rec.Receipt)
+
+	receipt, tx := testMinedTransaction(hash, 101)
+	mockClient.On("TransactionReceipt", mock.Anything, hash).Return(nil, ethereum.NotFound).Once()
+	mockClient.On("SubscribeNewHead", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			heads := args.Get(1).(chan<- *types.Header)
+			go func() { heads <- &types.Header{Number: big.NewInt(101)} }()
+		}).
+		Return(sub, nil)
+	mockClient.On("TransactionReceipt", mock.Anything, hash).Return(receipt, nil)
+	mockClient.On("TransactionByHash", mock.Anything, hash).Return(tx, false, nil)
+	_, err = gc.WaitForTransaction(hash)
+	assert.NoError(t, err)
+
+	rec, err = store.Get(hash)
+	assert.NoError(t, err)
+	assert.Equal(t, TxRecordMined, rec.Status)
+	assert.Equal(t, uint64(101), rec.Receipt.BlockNumber)
+	assert.Equal(t, uint64(types.ReceiptStatusSuccessful), rec.Receipt.Status)
+	assert.NotEmpty(t, rec.RawTx)
+	assert.Equal(t, "42", rec.Metadata["order_id"])
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_TxStore_RecordsForeignReceipt(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	store := NewMemoryTxStore()
+	hash := common.HexToHash("0xabc")
+	receipt, tx := testMinedTransaction(hash, 101)
+
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("SubscribeNewHead", mock.Anything, mock.Anything).Return(newTestSubscription(), nil)
+	mockClient.On("TransactionReceipt", mock.Anything, hash).Return(receipt, nil)
+	mockClient.On("TransactionByHash", mock.Anything, hash).Return(tx, false, nil)
+	gc := &ghostClient{
+		client:         mockClient,
+		ctx:            context.Background(),
+		chainId:        1,
+		account:        acc,
+		config:         cfg,
+		log:            newTestLogger(),
+		subscribeHeads: true, // the receipt is found right after subscribing
+	}
+	WithTxStore(store)(gc)
+
+	_, err := gc.WaitForTransaction(hash)
+	assert.NoError(t, err)
+
+	rec, err := store.Get(hash)
+	assert.NoError(t, err)
+	assert.Equal(t, TxRecordMined, rec.Status)
+	assert.Equal(t, uint64(101), rec.Receipt.BlockNumber)
+	assert.Empty(t, rec.RawTx)
+}
+
+// failingTxStore rejects every record
+type failingTxStore struct{ MemoryTxStore }
+
+func (s *failingTxStore) Record(*TransactionRecord) error { return errors.New("disk full") }
+
+func TestGhostClient_TxStore_SignFailsWhenUnrecorded(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	to := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	gc := &ghostClient{
+		client:  &internalmocks.EthClient{},
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	WithTxStore(&failingTxStore{})(gc)
+
+	signed, errs := gc.SignBatchOffline([]*Transaction{{
+		From: acc.Address, To: to, Value: big.NewInt(1), Nonce: 5, GasLimit: 21000,
+		MaxFeePerGas: big.NewInt(30 * GWEI), MaxPriorityFeePerGas: big.NewInt(GWEI),
+	}})
+	assert.Nil(t, signed[0])
+	assert.ErrorContains(t, errs[0], "disk full")
+}
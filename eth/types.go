@@ -2,9 +2,20 @@ package eth
 
 import (
 	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-	"math/big"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Bounds for gas limit buffer multipliers, whether configured or set per transaction
+const (
+	minGasLimitBuffer = 0.5
+	maxGasLimitBuffer = 3.0
 )
 
 // High-level Ethereum types and structures, for application-specific use
@@ -14,20 +25,179 @@ type Account struct {
 	ChainId    int64             // Chain ID for transaction signing
 	Label      string            // Optional: human-readable label
 	PrivateKey *ecdsa.PrivateKey // Private key for signing transactions
+	RPCURL     string            // Optional: endpoint of the account's chain, overriding the configured ones
 }
 
 // Transaction represents an Ethereum transaction
 type Transaction struct {
 	From                 common.Address `json:"from"`
 	To                   common.Address `json:"to"`
-	Value                *big.Int       `json:"value"`
-	Data                 []byte         `json:"data"`
+	Value                *big.Int       `json:"value"` // nil is treated as zero (e.g. pure contract calls)
+	Data                 []byte         `json:"data"`  // nil or empty means a plain transfer
 	GasLimit             uint64         `json:"gas_limit"`
 	GasPrice             *big.Int       `json:"gas_price"`
 	MaxFeePerGas         *big.Int       `json:"max_fee_per_gas"`
 	MaxPriorityFeePerGas *big.Int       `json:"max_priority_fee_per_gas"`
 	Nonce                uint64         `json:"nonce"`
 	ChainID              *big.Int       `json:"chain_id"`
+	// EstimateFrom, when set, replaces From as the sender used for gas estimation
+	// (e.g. sponsored transactions where another address pays)
+	EstimateFrom common.Address `json:"estimate_from"`
+	// ToLabel, when set, names a configured account whose address is used as To at sign time
+	ToLabel string `json:"to_label"`
+	// IsContractCreation deploys a contract: To is left zero, Data holds the init code, and the
+	// signed transaction has no recipient
+	IsContractCreation bool `json:"is_contract_creation,omitempty"`
+	// ValidUntil, when set, is a client-side deadline: signing or sending after it fails with
+	// ErrExpired. It is not part of the on-chain transaction; the client remembers it for the
+	// signed hash, so SendTransaction of a transaction signed by this client enforces it too.
+	ValidUntil time.Time `json:"valid_until"`
+	// GasLimitBuffer, when non-zero, replaces the configured simple/complex buffer applied to
+	// this transaction's gas estimate
+	GasLimitBuffer float64 `json:"gas_limit_buffer"`
+	// TokenSpend, when set, declares the ERC-20 amount To will pull from the sender; with
+	// WithAutoApprove, signing raises the sender's allowance first if needed
+	TokenSpend *TokenSpend `json:"token_spend,omitempty"`
+	// AccessList, when set, is attached to the transaction; with a legacy GasPrice it is sent as
+	// EIP-2930. WithAutoAccessList fills it for contract calls when that lowers the gas.
+	AccessList types.AccessList `json:"access_list,omitempty"`
+	// Metadata is client-side context such as an invoice or user ID. It is never sent on-chain;
+	// the client logs it and attaches it to receipts and confirmation updates for the signed hash.
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// Type is the EIP-2718 type of a fetched transaction, e.g. types.DynamicFeeTxType. It is
+	// ignored when signing, where the type follows from the fee fields.
+	Type uint8 `json:"type"`
+}
+
+// newTransaction converts a fetched transaction sent by from into a Transaction. Legacy and
+// access list transactions carry GasPrice, later types MaxFeePerGas and MaxPriorityFeePerGas.
+// Contract creations have IsContractCreation set and To left zero.
+func newTransaction(tx *types.Transaction, from common.Address) *Transaction {
+	result := &Transaction{
+		From:               from,
+		Value:              tx.Value(),
+		Data:               tx.Data(),
+		GasLimit:           tx.Gas(),
+		Nonce:              tx.Nonce(),
+		ChainID:            tx.ChainId(),
+		Type:               tx.Type(),
+		IsContractCreation: tx.To() == nil,
+	}
+	if tx.To() != nil {
+		result.To = *tx.To()
+	}
+	switch tx.Type() {
+	case types.LegacyTxType, types.AccessListTxType:
+		result.GasPrice = tx.GasPrice()
+	default:
+		result.MaxFeePerGas = tx.GasFeeCap()
+		result.MaxPriorityFeePerGas = tx.GasTipCap()
+	}
+	if tx.Type() != types.LegacyTxType {
+		result.AccessList = tx.AccessList()
+	}
+	return result
+}
+
+// recipient returns the address the transaction is sent to, nil for contract creations
+func (tx *Transaction) recipient() *common.Address {
+	if tx.IsContractCreation {
+		return nil
+	}
+	return &tx.To
+}
+
+// Expired reports whether the transaction's ValidUntil deadline has passed at now. A zero
+// ValidUntil never expires.
+func (tx *Transaction) Expired(now time.Time) bool {
+	return !tx.ValidUntil.IsZero() && now.After(tx.ValidUntil)
+}
+
+// checkDeadline returns ErrExpired if the transaction's deadline has passed
+func (tx *Transaction) checkDeadline() error {
+	if tx.Expired(time.Now()) {
+		return fmt.Errorf("%w: valid until %s", ErrExpired, tx.ValidUntil.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// Validate checks the transaction for invalid or conflicting fields before any RPC round trip.
+// A nil Value is normalized to zero and nil Data to an empty slice. All problems found are
+// returned together.
+func (tx *Transaction) Validate() error {
+	var errs []error
+
+	if tx.Data == nil {
+		tx.Data = []byte{}
+	}
+
+	if tx.Value == nil {
+		tx.Value = big.NewInt(0)
+	} else if tx.Value.Sign() < 0 {
+		errs = append(errs, fmt.Errorf("value must not be negative: %s", tx.Value.String()))
+	}
+
+	// -- contract creation: no recipient, init code required
+	if tx.IsContractCreation {
+		if tx.To != (common.Address{}) || tx.ToLabel != "" {
+			errs = append(errs, errors.New("contract creation must not set To or ToLabel"))
+		}
+		if len(tx.Data) == 0 {
+			errs = append(errs, errors.New("contract creation requires init code in Data"))
+		}
+	}
+
+	// -- fee fields: legacy GasPrice and EIP-1559 caps are mutually exclusive
+	if tx.GasPrice != nil && (tx.MaxFeePerGas != nil || tx.MaxPriorityFeePerGas != nil) {
+		errs = append(errs, errors.New("GasPrice cannot be combined with MaxFeePerGas or MaxPriorityFeePerGas"))
+	}
+	fees := []struct {
+		name string
+		fee  *big.Int
+	}{
+		{"GasPrice", tx.GasPrice},
+		{"MaxFeePerGas", tx.MaxFeePerGas},
+		{"MaxPriorityFeePerGas", tx.MaxPriorityFeePerGas},
+	}
+	for _, f := range fees {
+		if f.fee != nil && f.fee.Sign() < 0 {
+			errs = append(errs, fmt.Errorf("%s must not be negative: %s", f.name, f.fee.String()))
+		}
+	}
+	if tx.MaxFeePerGas != nil && tx.MaxPriorityFeePerGas != nil && tx.MaxPriorityFeePerGas.Cmp(tx.MaxFeePerGas) > 0 {
+		errs = append(errs, fmt.Errorf("MaxPriorityFeePerGas %s exceeds MaxFeePerGas %s", tx.MaxPriorityFeePerGas.String(), tx.MaxFeePerGas.String()))
+	}
+
+	// -- gas limit: zero means "estimate", anything else must be within protocol bounds
+	if tx.GasLimit != 0 && tx.GasLimit < params.TxGas {
+		errs = append(errs, fmt.Errorf("gas limit %d is below the intrinsic minimum %d", tx.GasLimit, params.TxGas))
+	}
+	if tx.GasLimitBuffer != 0 && (tx.GasLimitBuffer < minGasLimitBuffer || tx.GasLimitBuffer > maxGasLimitBuffer) {
+		errs = append(errs, fmt.Errorf("gas limit buffer %g is outside the allowed range %g to %g", tx.GasLimitBuffer, minGasLimitBuffer, maxGasLimitBuffer))
+	}
+
+	return errors.Join(errs...)
+}
+
+// EffectiveGasPrice returns the per-gas price the transaction pays at the given base fee:
+// min(MaxFeePerGas, baseFee + MaxPriorityFeePerGas) for EIP-1559 transactions and GasPrice for
+// legacy ones. With a nil baseFee an EIP-1559 transaction is priced at its fee cap, the worst case.
+// It returns nil when no fees are set.
+func (tx *Transaction) EffectiveGasPrice(baseFee *big.Int) *big.Int {
+	if tx.MaxFeePerGas != nil {
+		if baseFee == nil || tx.MaxPriorityFeePerGas == nil {
+			return new(big.Int).Set(tx.MaxFeePerGas)
+		}
+		price := new(big.Int).Add(baseFee, tx.MaxPriorityFeePerGas)
+		if price.Cmp(tx.MaxFeePerGas) > 0 {
+			price.Set(tx.MaxFeePerGas)
+		}
+		return price
+	}
+	if tx.GasPrice != nil {
+		return new(big.Int).Set(tx.GasPrice)
+	}
+	return nil
 }
 
 // TransactionReceipt represents transaction execution result
@@ -39,4 +209,106 @@ type TransactionReceipt struct {
 	From        common.Address `json:"from"`
 	To          common.Address `json:"to"`
 	Logs        []*types.Log   `json:"logs"`
+	Type        uint8          `json:"type"` // transaction type, e.g. types.DynamicFeeTxType
+	// Timestamp is the Unix time of the including block; zero unless receipt timestamps are
+	// enabled (see WithReceiptTimestamps) or the receipt came from GetBlockReceipts
+	Timestamp uint64 `json:"timestamp"`
+	// Value is the transferred wei and EffectiveGasPrice the price paid per gas; both are nil
+	// when unknown
+	Value             *big.Int `json:"value,omitempty"`
+	EffectiveGasPrice *big.Int `json:"effective_gas_price,omitempty"`
+	// CumulativeGasUsed is the gas used by this and all earlier transactions in the block
+	CumulativeGasUsed uint64 `json:"cumulative_gas_used"`
+	// Metadata is the Metadata of the Transaction this client signed, if any
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// Fee returns the wei paid for gas, GasUsed times EffectiveGasPrice, or nil if the price is unknown
+func (r *TransactionReceipt) Fee() *big.Int {
+	if r.EffectiveGasPrice == nil {
+		return nil
+	}
+	return new(big.Int).Mul(new(big.Int).SetUint64(r.GasUsed), r.EffectiveGasPrice)
+}
+
+// IsLegacy reports whether the receipt belongs to a legacy (pre-EIP-2718) transaction
+func (r *TransactionReceipt) IsLegacy() bool {
+	return r.Type == types.LegacyTxType
+}
+
+// IsEIP1559 reports whether the receipt belongs to an EIP-1559 dynamic fee transaction
+func (r *TransactionReceipt) IsEIP1559() bool {
+	return r.Type == types.DynamicFeeTxType
+}
+
+// IsBlob reports whether the receipt belongs to an EIP-4844 blob transaction
+func (r *TransactionReceipt) IsBlob() bool {
+	return r.Type == types.BlobTxType
+}
+
+// InclusionProof locates a mined transaction within its block, together with the
+// block header needed to verify the transaction root independently
+type InclusionProof struct {
+	TxHash           common.Hash   `json:"tx_hash"`
+	BlockHash        common.Hash   `json:"block_hash"`
+	BlockNumber      uint64        `json:"block_number"`
+	TransactionIndex uint          `json:"transaction_index"`
+	TxRoot           common.Hash   `json:"tx_root"`
+	Header           *types.Header `json:"header"`
+}
+
+// TokenBalance is the account's balance of a single ERC-20 token
+type TokenBalance struct {
+	Token     common.Address `json:"token"`
+	Symbol    string         `json:"symbol"` // empty if the token doesn't expose a string symbol
+	Decimals  uint8          `json:"decimals"`
+	Balance   *big.Int       `json:"balance"`
+	Formatted string         `json:"formatted"`
+}
+
+// Portfolio is the account's native balance and token balances
+type Portfolio struct {
+	Account         common.Address `json:"account"`
+	NativeBalance   *big.Int       `json:"native_balance"`
+	NativeFormatted string         `json:"native_formatted"`
+	Tokens          []TokenBalance `json:"tokens"`
+}
+
+// GasStats summarizes base fees and block fullness over a window of recent blocks
+type GasStats struct {
+	OldestBlock     uint64   `json:"oldest_block"`
+	Blocks          int      `json:"blocks"`
+	MinBaseFee      *big.Int `json:"min_base_fee"`
+	MaxBaseFee      *big.Int `json:"max_base_fee"`
+	AvgBaseFee      *big.Int `json:"avg_base_fee"`
+	MinGasUsedRatio float64  `json:"min_gas_used_ratio"`
+	MaxGasUsedRatio float64  `json:"max_gas_used_ratio"`
+	AvgGasUsedRatio float64  `json:"avg_gas_used_ratio"`
+}
+
+// TransactionCost is the estimated cost of one transaction of a BatchCostReport, in wei with ETH
+// renderings. Fee is the most the gas can cost, GasLimit at the max fee per gas (or gas price), and
+// Total adds Value to it.
+type TransactionCost struct {
+	GasLimit       uint64   `json:"gas_limit"`
+	FeePerGas      *big.Int `json:"fee_per_gas"`
+	Fee            *big.Int `json:"fee"`
+	Value          *big.Int `json:"value"`
+	Total          *big.Int `json:"total"`
+	FeeFormatted   string   `json:"fee_formatted"`
+	ValueFormatted string   `json:"value_formatted"`
+	TotalFormatted string   `json:"total_formatted"`
+}
+
+// BatchCostReport is the estimated outlay of a planned batch of transactions, in the order given,
+// with totals across all of them
+type BatchCostReport struct {
+	Transactions   []TransactionCost `json:"transactions"`
+	TotalGas       uint64            `json:"total_gas"`
+	TotalFee       *big.Int          `json:"total_fee"`
+	TotalValue     *big.Int          `json:"total_value"`
+	Total          *big.Int          `json:"total"`
+	FeeFormatted   string            `json:"fee_formatted"`
+	ValueFormatted string            `json:"value_formatted"`
+	TotalFormatted string            `json:"total_formatted"`
 }
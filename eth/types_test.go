@@ -0,0 +1,152 @@
+package eth
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransaction_Validate_NilValueNormalized(t *testing.T) {
+	tx := &Transaction{}
+	err := tx.Validate()
+	assert.NoError(t, err)
+	assert.NotNil(t, tx.Value)
+	assert.Equal(t, 0, tx.Value.Sign())
+}
+
+func TestTransaction_Validate_ConflictingFees(t *testing.T) {
+	tx := &Transaction{
+		Value:        big.NewInt(1),
+		GasPrice:     big.NewInt(10),
+		MaxFeePerGas: big.NewInt(20),
+	}
+	err := tx.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "GasPrice cannot be combined")
+}
+
+func TestTransaction_Validate_AggregatesErrors(t *testing.T) {
+	tx := &Transaction{
+		Value:                big.NewInt(-1),
+		GasLimit:             100,
+		MaxFeePerGas:         big.NewInt(10),
+		MaxPriorityFeePerGas: big.NewInt(20),
+	}
+	err := tx.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "value must not be negative")
+	assert.Contains(t, err.Error(), "exceeds MaxFeePerGas")
+	assert.Contains(t, err.Error(), "below the intrinsic minimum")
+}
+
+func TestTransaction_Validate_GasLimitAboveEIP7825Cap(t *testing.T) {
+	// -- the per-transaction cap is fork-dependent, so the block gas cap check is left to enforce limits
+	tx := &Transaction{GasLimit: 1<<24 + 1}
+	assert.NoError(t, tx.Validate())
+}
+
+func TestTransaction_Validate_GasLimitBuffer(t *testing.T) {
+	assert.NoError(t, (&Transaction{}).Validate())
+	assert.NoError(t, (&Transaction{GasLimitBuffer: 0.5}).Validate())
+	assert.NoError(t, (&Transaction{GasLimitBuffer: 3.0}).Validate())
+	assert.ErrorContains(t, (&Transaction{GasLimitBuffer: 0.4}).Validate(), "gas limit buffer 0.4 is outside the allowed range")
+	assert.ErrorContains(t, (&Transaction{GasLimitBuffer: 3.5}).Validate(), "gas limit buffer 3.5 is outside the allowed range")
+}
+
+func TestTransaction_Validate_ContractCreation(t *testing.T) {
+	initCode := []byte{0x60, 0x80, 0x60, 0x40}
+	assert.NoError(t, (&Transaction{IsContractCreation: true, Data: initCode}).Validate())
+	assert.ErrorContains(t, (&Transaction{IsContractCreation: true}).Validate(), "requires init code")
+	assert.ErrorContains(t, (&Transaction{IsContractCreation: true, Data: initCode, To: common.HexToAddress("0x02")}).Validate(), "must not set To")
+	assert.ErrorContains(t, (&Transaction{IsContractCreation: true, Data: initCode, ToLabel: "main"}).Validate(), "must not set To")
+}
+
+func TestGhostClient_SignTransaction_InvalidSkipsRPC(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+	tx := &Transaction{
+		From:                 acc.Address,
+		To:                   acc.Address,
+		GasPrice:             big.NewInt(10),
+		MaxPriorityFeePerGas: big.NewInt(1),
+	}
+	_, err := gc.SignTransaction(tx)
+	assert.Error(t, err)
+	// no expectations were set, so any RPC call would have panicked
+	mockClient.AssertExpectations(t)
+}
+
+func TestTransaction_Validate_NilDataNormalized(t *testing.T) {
+	tx := &Transaction{Value: big.NewInt(1)}
+	assert.NoError(t, tx.Validate())
+	assert.NotNil(t, tx.Data)
+	assert.Empty(t, tx.Data)
+}
+
+func TestTransactionReceipt_TypeHelpers(t *testing.T) {
+	tests := []struct {
+		txType                uint8
+		legacy, eip1559, blob bool
+	}{
+		{types.LegacyTxType, true, false, false},
+		{types.AccessListTxType, false, false, false},
+		{types.DynamicFeeTxType, false, true, false},
+		{types.BlobTxType, false, false, true},
+		{types.SetCodeTxType, false, false, false},
+	}
+	for _, tt := range tests {
+		r := &TransactionReceipt{Type: tt.txType}
+		assert.Equal(t, tt.legacy, r.IsLegacy(), "IsLegacy for type %d", tt.txType)
+		assert.Equal(t, tt.eip1559, r.IsEIP1559(), "IsEIP1559 for type %d", tt.txType)
+		assert.Equal(t, tt.blob, r.IsBlob(), "IsBlob for type %d", tt.txType)
+	}
+}
+
+func TestNewTransactionReceipt_Type(t *testing.T) {
+	receipt := &types.Receipt{Type: types.DynamicFeeTxType, BlockNumber: big.NewInt(1)}
+	assert.True(t, newTransactionReceipt(receipt, nil, common.Address{}).IsEIP1559())
+}
+
+func TestTransaction_EffectiveGasPrice(t *testing.T) {
+	gwei := func(n int64) *big.Int { return new(big.Int).Mul(big.NewInt(n), big.NewInt(GWEI)) }
+	dynamic := &Transaction{MaxFeePerGas: gwei(50), MaxPriorityFeePerGas: gwei(2)}
+
+	// base fee plus tip fits under the cap
+	assert.Equal(t, gwei(32), dynamic.EffectiveGasPrice(gwei(30)))
+	// base fee plus tip is capped at the max fee
+	assert.Equal(t, gwei(50), dynamic.EffectiveGasPrice(gwei(49)))
+	// unknown base fee prices at the cap
+	assert.Equal(t, gwei(50), dynamic.EffectiveGasPrice(nil))
+
+	legacy := &Transaction{GasPrice: gwei(40)}
+	assert.Equal(t, gwei(40), legacy.EffectiveGasPrice(gwei(30)))
+
+	// returned values are copies
+	legacy.EffectiveGasPrice(nil).SetInt64(0)
+	assert.Equal(t, gwei(40), legacy.GasPrice)
+
+	assert.Nil(t, (&Transaction{}).EffectiveGasPrice(gwei(30)))
+}
+
+func TestTransaction_Expired(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.False(t, (&Transaction{}).Expired(now))
+	assert.False(t, (&Transaction{ValidUntil: now}).Expired(now))
+	assert.False(t, (&Transaction{ValidUntil: now.Add(time.Minute)}).Expired(now))
+	assert.True(t, (&Transaction{ValidUntil: now.Add(-time.Second)}).Expired(now))
+}
@@ -0,0 +1,133 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// waitForTransaction waits for a transaction to be mined, or until ctx is done. Over websocket
+// endpoints it checks for the receipt on every new head; otherwise, or if the subscription fails,
+// it polls on a ticker.
+func (es *ghostClient) waitForTransaction(ctx context.Context, hash common.Hash) (*TransactionReceipt, error) {
+	return es.waitForReceipt(ctx, hash, nil)
+}
+
+// WaitForReceiptMatching waits like WaitForTransactionContext, but only returns once the receipt
+// also satisfies pred, e.g. carries an expected log; receipts that don't are checked again on the
+// next poll or block, as a reorg may replace them. The configured timeout and poll limit apply,
+// and a nil pred matches any receipt.
+func (es *ghostClient) WaitForReceiptMatching(ctx context.Context, hash common.Hash, pred func(*TransactionReceipt) bool) (*TransactionReceipt, error) {
+	return es.waitForReceipt(ctx, hash, pred)
+}
+
+// waitForReceipt is waitForTransaction for a receipt satisfying match, or any receipt if match is
+// nil
+func (es *ghostClient) waitForReceipt(ctx context.Context, hash common.Hash, match func(*TransactionReceipt) bool) (*TransactionReceipt, error) {
+	deadline := time.Now().Add(time.Duration(es.config.TransactionTimeoutSeconds()) * time.Second)
+	budget := &pollBudget{max: es.config.TransactionMaxPolls(), match: match}
+	var receipt *TransactionReceipt
+	var err error
+	if es.subscribeHeads {
+		receipt, err = es.waitForTransactionByHeads(ctx, hash, deadline, budget)
+	} else {
+		receipt, err = es.pollForTransaction(ctx, hash, deadline, budget)
+	}
+	if err != nil {
+		return nil, err
+	}
+	es.recordReceipt(receipt)
+	return receipt, nil
+}
+
+// pollBudget counts receipt checks against the configured maximum (0 means unlimited). match, when
+// set, is the condition a receipt must meet to end the wait.
+type pollBudget struct {
+	max   int
+	used  int
+	match func(*TransactionReceipt) bool
+}
+
+// checkReceipt looks the receipt up once. It returns a nil receipt and nil error while the
+// transaction is pending or its receipt doesn't match yet, ErrPollLimitReached once the budget is
+// spent, and ctx's error if ctx is done.
+func (es *ghostClient) checkReceipt(ctx context.Context, hash common.Hash, budget *pollBudget) (*TransactionReceipt, error) {
+	receipt, err := es.getTransactionReceipt(ctx, hash)
+	if err == nil && (budget.match == nil || budget.match(receipt)) {
+		return receipt, nil
+	}
+	if ctx.Err() != nil {
+		return nil, fmt.Errorf("stopped waiting for %s: %w", hash.Hex(), ctx.Err())
+	}
+	budget.used++
+	if budget.max > 0 && budget.used >= budget.max {
+		return nil, fmt.Errorf("%w: %d checks for %s", ErrPollLimitReached, budget.used, hash.Hex())
+	}
+	return nil, nil
+}
+
+// pollForTransaction checks for the receipt on every tick until the deadline
+func (es *ghostClient) pollForTransaction(ctx context.Context, hash common.Hash, deadline time.Time, budget *pollBudget) (*TransactionReceipt, error) {
+	tickerInterval := time.Duration(es.config.TransactionTickerSeconds()) * time.Second
+
+	timeoutChan := time.After(time.Until(deadline))
+	ticker := time.NewTicker(tickerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-timeoutChan:
+			return nil, fmt.Errorf("transaction timeout: %s", hash.Hex())
+		case <-ctx.Done():
+			return nil, fmt.Errorf("stopped waiting for %s: %w", hash.Hex(), ctx.Err())
+		case <-ticker.C:
+			receipt, err := es.checkReceipt(ctx, hash, budget)
+			if receipt != nil || err != nil {
+				return receipt, err
+			}
+		}
+	}
+}
+
+// waitForTransactionByHeads checks for the receipt each time a new block arrives until the deadline
+func (es *ghostClient) waitForTransactionByHeads(ctx context.Context, hash common.Hash, deadline time.Time, budget *pollBudget) (*TransactionReceipt, error) {
+	heads := make(chan *types.Header, 16)
+	sub, err := es.subscribeNewHeads(ctx, heads)
+	if err != nil {
+		es.log.WithError(err).Warn("Failed to subscribe to new heads, falling back to polling")
+		return es.pollForTransaction(ctx, hash, deadline, budget)
+	}
+	defer sub.Unsubscribe()
+
+	// The transaction may have been mined before the subscription was established
+	if receipt, err := es.checkReceipt(ctx, hash, budget); receipt != nil || err != nil {
+		return receipt, err
+	}
+
+	timeoutChan := time.After(time.Until(deadline))
+	for {
+		select {
+		case <-timeoutChan:
+			return nil, fmt.Errorf("transaction timeout: %s", hash.Hex())
+		case <-ctx.Done():
+			return nil, fmt.Errorf("stopped waiting for %s: %w", hash.Hex(), ctx.Err())
+		case err := <-sub.Err():
+			es.log.WithError(err).Warn("Head subscription could not be re-established, falling back to polling")
+			return es.pollForTransaction(ctx, hash, deadline, budget)
+		case <-heads:
+			receipt, err := es.checkReceipt(ctx, hash, budget)
+			if receipt != nil || err != nil {
+				return receipt, err
+			}
+		}
+	}
+}
+
+// isWebsocketURL reports whether the endpoint supports subscriptions over websocket
+func isWebsocketURL(url string) bool {
+	return strings.HasPrefix(url, "ws://") || strings.HasPrefix(url, "wss://")
+}
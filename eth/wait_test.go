@@ -0,0 +1,345 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	internalmocks "github.com/nando-os/ghost-eth/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// testSubscription is a controllable ethereum.Subscription
+type testSubscription struct {
+	errCh        chan error
+	unsubscribed bool
+}
+
+func newTestSubscription() *testSubscription {
+	return &testSubscription{errCh: make(chan error, 1)}
+}
+
+func (s *testSubscription) Unsubscribe()      { s.unsubscribed = true }
+func (s *testSubscription) Err() <-chan error { return s.errCh }
+
+var _ ethereum.Subscription = (*testSubscription)(nil)
+
+func testMinedTransaction(hash common.Hash, blockNumber int64) (*types.Receipt, *types.Transaction) {
+	to := common.HexToAddress("0x0000000000000000000000000000000000000002")
+	receipt := &types.Receipt{
+		TxHash:      hash,
+		Status:      types.ReceiptStatusSuccessful,
+		BlockNumber: big.NewInt(blockNumber),
+		GasUsed:     21000,
+	}
+	return receipt, types.NewTx(&types.DynamicFeeTx{To: &to})
+}
+
+func TestGhostClient_WaitForTransaction_Subscription(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	hash := common.HexToHash("0xabc")
+	receipt, tx := testMinedTransaction(hash, 101)
+	sub := newTestSubscription()
+
+	// the receipt is not available until the block containing the transaction arrives
+	mockClient.On("TransactionReceipt", mock.Anything, hash).Return(nil, ethereum.NotFound).Once()
+	mockClient.On("SubscribeNewHead", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			heads := args.Get(1).(chan<- *types.Header)
+			go func() { heads <- &types.Header{Number: big.NewInt(101)} }()
+		}).
+		Return(sub, nil)
+	mockClient.On("TransactionReceipt", mock.Anything, hash).Return(receipt, nil)
+	mockClient.On("TransactionByHash", mock.Anything, hash).Return(tx, false, nil)
+	gc := &ghostClient{
+		client:         mockClient,
+		ctx:            context.Background(),
+		chainId:        1,
+		account:        acc,
+		config:         cfg,
+		log:            newTestLogger(),
+		subscribeHeads: true,
+	}
+
+	result, err := gc.WaitForTransaction(hash)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(101), result.BlockNumber)
+	assert.True(t, sub.unsubscribed)
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNumberOfCalls(t, "TransactionReceipt", 2) // pre-subscription check, then on the new head
+}
+
+func TestGhostClient_WaitForTransaction_SubscriptionFallback(t *testing.T) {
+	t.Setenv("ETH_TRANSACTION_TICKER_SECONDS", "1")
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	hash := common.HexToHash("0xabc")
+	receipt, tx := testMinedTransaction(hash, 101)
+
+	mockClient.On("SubscribeNewHead", mock.Anything, mock.Anything).Return(nil, errors.New("notifications not supported"))
+	mockClient.On("TransactionReceipt", mock.Anything, hash).Return(receipt, nil)
+	mockClient.On("TransactionByHash", mock.Anything, hash).Return(tx, false, nil)
+	gc := &ghostClient{
+		client:         mockClient,
+		ctx:            context.Background(),
+		chainId:        1,
+		account:        acc,
+		config:         cfg,
+		log:            newTestLogger(),
+		subscribeHeads: true,
+	}
+
+	result, err := gc.WaitForTransaction(hash)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(101), result.BlockNumber)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_WaitForTransactionContext_Cancelled(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	hash := common.HexToHash("0xabc")
+
+	for _, subscribe := range []bool{false, true} {
+		sub := newTestSubscription()
+		mockClient := &internalmocks.EthClient{}
+		mockClient.On("SubscribeNewHead", mock.Anything, mock.Anything).Return(sub, nil)
+		mockClient.On("TransactionReceipt", mock.Anything, hash).Return(nil, ethereum.NotFound)
+		gc := &ghostClient{
+			client:         mockClient,
+			ctx:            context.Background(),
+			chainId:        1,
+			account:        acc,
+			config:         cfg,
+			log:            newTestLogger(),
+			subscribeHeads: subscribe,
+		}
+
+		// Default timeout is minutes and the ticker seconds; cancellation must cut both short
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+		start := time.Now()
+		_, err := gc.WaitForTransactionContext(ctx, hash)
+		assert.ErrorIs(t, err, context.Canceled, "subscribe=%v", subscribe)
+		assert.Less(t, time.Since(start), time.Second, "subscribe=%v", subscribe)
+		if subscribe {
+			assert.True(t, sub.unsubscribed, "head subscription should be closed")
+		}
+	}
+}
+
+func TestIsWebsocketURL(t *testing.T) {
+	assert.True(t, isWebsocketURL("ws://localhost:8546"))
+	assert.True(t, isWebsocketURL("wss://mainnet.example/ws"))
+	assert.False(t, isWebsocketURL("http://localhost:8545"))
+	assert.False(t, isWebsocketURL("https://mainnet.example"))
+}
+
+func TestUseEthClient_SubscribeHeads(t *testing.T) {
+	tests := []struct {
+		name    string
+		sub     ethereum.Subscription
+		err     error
+		enabled bool
+	}{
+		{"subscriptions supported", newTestSubscription(), nil, true},
+		{"http client", nil, errors.New("notifications not supported"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := &internalmocks.EthClient{}
+			mockClient.On("ChainID", mock.Anything).Return(big.NewInt(1), nil)
+			mockClient.On("SubscribeNewHead", mock.Anything, mock.Anything).Return(tt.sub, tt.err).Once()
+			gc := &ghostClient{client: mockClient, log: newTestLogger()}
+
+			assert.NoError(t, useEthClient(context.Background(), gc, 1))
+			assert.Equal(t, tt.enabled, gc.subscribeHeads)
+			if sub, ok := tt.sub.(*testSubscription); ok {
+				assert.True(t, sub.unsubscribed)
+			}
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestGhostClient_WaitForTransaction_PollLimit(t *testing.T) {
+	t.Setenv("ETH_TRANSACTION_MAX_POLLS", "3")
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	hash := common.HexToHash("0xabc")
+	sub := newTestSubscription()
+
+	// heads keep arriving but the transaction is never mined
+	mockClient.On("SubscribeNewHead", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			heads := args.Get(1).(chan<- *types.Header)
+			go func() {
+				for i := int64(100); i < 110; i++ {
+					heads <- &types.Header{Number: big.NewInt(i)}
+				}
+			}()
+		}).
+		Return(sub, nil)
+	mockClient.On("TransactionReceipt", mock.Anything, hash).Return(nil, ethereum.NotFound)
+	gc := &ghostClient{
+		client:         mockClient,
+		ctx:            context.Background(),
+		chainId:        1,
+		account:        acc,
+		config:         cfg,
+		log:            newTestLogger(),
+		subscribeHeads: true,
+	}
+
+	_, err := gc.WaitForTransaction(hash)
+	assert.ErrorIs(t, err, ErrPollLimitReached)
+	mockClient.AssertNumberOfCalls(t, "TransactionReceipt", 3)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_SendTransactionAndWait(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	signedTx := testBroadcastTx(t, acc)
+	receipt, tx := testMinedTransaction(signedTx.Hash(), 101)
+	sub := newTestSubscription()
+
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("SendTransaction", mock.Anything, signedTx).Return(nil).Once()
+	mockClient.On("TransactionReceipt", mock.Anything, signedTx.Hash()).Return(nil, ethereum.NotFound).Once()
+	mockClient.On("SubscribeNewHead", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			heads := args.Get(1).(chan<- *types.Header)
+			go func() { heads <- &types.Header{Number: big.NewInt(101)} }()
+		}).
+		Return(sub, nil)
+	mockClient.On("TransactionReceipt", mock.Anything, signedTx.Hash()).Return(receipt, nil)
+	mockClient.On("TransactionByHash", mock.Anything, signedTx.Hash()).Return(tx, false, nil)
+	gc := &ghostClient{
+		client:         mockClient,
+		ctx:            context.Background(),
+		chainId:        1,
+		account:        acc,
+		config:         cfg,
+		log:            newTestLogger(),
+		subscribeHeads: true,
+	}
+
+	result, err := gc.SendTransactionAndWait(signedTx)
+	assert.NoError(t, err)
+	assert.Equal(t, signedTx.Hash(), result.TxHash)
+	assert.Equal(t, uint64(101), result.BlockNumber)
+	assert.Equal(t, uint64(types.ReceiptStatusSuccessful), result.Status)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_SendTransactionAndWait_SendError(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	signedTx := testBroadcastTx(t, acc)
+
+	mockClient := &internalmocks.EthClient{}
+	mockClient.On("SendTransaction", mock.Anything, signedTx).
+		Return(&testRPCError{code: -32000, message: "nonce too low"}).Once()
+	gc := &ghostClient{
+		client:  mockClient,
+		ctx:     context.Background(),
+		chainId: 1,
+		account: acc,
+		config:  cfg,
+		log:     newTestLogger(),
+	}
+
+	_, err := gc.SendTransactionAndWait(signedTx)
+	assert.ErrorIs(t, err, ErrNonceTooLow)
+	mockClient.AssertNotCalled(t, "TransactionReceipt", mock.Anything, mock.Anything)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_WaitForReceiptMatching(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	hash := common.HexToHash("0xabc")
+	token := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	transferTopic := common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	sub := newTestSubscription()
+
+	// -- the first receipt lacks the log, e.g. the transaction was mined in a block later reorged out
+	withoutLog, tx := testMinedTransaction(hash, 101)
+	withLog, _ := testMinedTransaction(hash, 102)
+	withLog.Logs = []*types.Log{{Address: token, Topics: []common.Hash{transferTopic}}}
+	mockClient.On("SubscribeNewHead", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			heads := args.Get(1).(chan<- *types.Header)
+			go func() {
+				for i := int64(101); i < 110; i++ {
+					heads <- &types.Header{Number: big.NewInt(i)}
+				}
+			}()
+		}).
+		Return(sub, nil)
+	mockClient.On("TransactionReceipt", mock.Anything, hash).Return(withoutLog, nil).Twice()
+	mockClient.On("TransactionReceipt", mock.Anything, hash).Return(withLog, nil).Once()
+	mockClient.On("TransactionByHash", mock.Anything, hash).Return(tx, false, nil)
+	gc := &ghostClient{
+		client:         mockClient,
+		ctx:            context.Background(),
+		chainId:        1,
+		account:        acc,
+		config:         cfg,
+		log:            newTestLogger(),
+		subscribeHeads: true,
+	}
+
+	var checked int
+	receipt, err := gc.WaitForReceiptMatching(context.Background(), hash, func(r *TransactionReceipt) bool {
+		checked++
+		for _, l := range r.Logs {
+			if l.Address == token && len(l.Topics) > 0 && l.Topics[0] == transferTopic {
+				return true
+			}
+		}
+		return false
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(102), receipt.BlockNumber)
+	assert.Len(t, receipt.Logs, 1)
+	assert.Equal(t, 3, checked)
+	mockClient.AssertExpectations(t)
+}
+
+func TestGhostClient_WaitForReceiptMatching_Cancelled(t *testing.T) {
+	acc, cfg := testAccountAndConfig()
+	mockClient := &internalmocks.EthClient{}
+	hash := common.HexToHash("0xabc")
+	receipt, tx := testMinedTransaction(hash, 101)
+	sub := newTestSubscription()
+	mockClient.On("SubscribeNewHead", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			heads := args.Get(1).(chan<- *types.Header)
+			go func() { heads <- &types.Header{Number: big.NewInt(102)} }()
+		}).
+		Return(sub, nil)
+	mockClient.On("TransactionReceipt", mock.Anything, hash).Return(receipt, nil)
+	mockClient.On("TransactionByHash", mock.Anything, hash).Return(tx, false, nil)
+	gc := &ghostClient{
+		client:         mockClient,
+		ctx:            context.Background(),
+		chainId:        1,
+		account:        acc,
+		config:         cfg,
+		log:            newTestLogger(),
+		subscribeHeads: true,
+	}
+
+	// -- a mined receipt that never matches keeps the wait going until ctx is done
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := gc.WaitForReceiptMatching(ctx, hash, func(r *TransactionReceipt) bool { return r.GasUsed > 50000 })
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	mockClient.AssertNumberOfCalls(t, "TransactionReceipt", 2)
+}
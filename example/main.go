@@ -84,7 +84,7 @@ func main() {
 	recipient := common.HexToAddress(receiver.Address.String())
 
 	log.WithFields(logrus.Fields{
-		"amount_eth": new(big.Float).Quo(new(big.Float).SetInt(value), big.NewFloat(1e18)).Text('f', 6),
+		"amount_eth": eth.FormatUnits(value, 18),
 		"from":       sender.Address.Hex(),
 		"to":         recipient.Hex(),
 	}).Info("Creating transaction")
@@ -102,33 +102,21 @@ func main() {
 	if err != nil {
 		log.WithError(err).Fatal("Failed to sign transaction")
 	}
-	log.WithField("tx_hash", signedTx.Hash().Hex()).Info("Transaction signed successfully")
+	log.WithField("tx", eth.DescribeTransaction(signedTx, big.NewInt(config.ChainID()))).Info("Transaction signed successfully")
 
-	// --- Send Transaction (Non-bloking) ---
-	fmt.Println("Sending transaction...")
-	receipt, err := client.SendTransaction(signedTx)
-	if err != nil {
-		log.WithError(err).Fatal("Failed to send transaction")
-	}
-
-	log.WithFields(logrus.Fields{
-		"tx_hash": receipt.TxHash.Hex(),
-		"status":  receipt.Status,
-		"from":    receipt.From.Hex(),
-		"to":      receipt.To.Hex(),
-	}).Info("Transaction sent")
-
-	// --- Wait for Confirmation (Optional) ---
-	fmt.Println("Waiting for transaction confirmation...")
-	confirmedReceipt, err := client.WaitForTransaction(receipt.TxHash)
+	// --- Send Transaction and Wait for Confirmation ---
+	fmt.Println("Sending transaction and waiting for confirmation...")
+	confirmedReceipt, err := client.SendTransactionAndWait(signedTx)
 	if err != nil {
 		log.WithError(err).Fatal("Transaction failed")
 	}
 
 	log.WithFields(logrus.Fields{
+		"tx_hash":      confirmedReceipt.TxHash.Hex(),
 		"block_number": confirmedReceipt.BlockNumber,
 		"gas_used":     confirmedReceipt.GasUsed,
-		"gas_price":    big.NewInt(0).Mul(big.NewInt(int64(confirmedReceipt.GasUsed)), signedTx.GasPrice()).Uint64(),
+		"gas_price":    confirmedReceipt.EffectiveGasPrice,
+		"fee_wei":      confirmedReceipt.Fee(),
 		"status":       confirmedReceipt.Status,
 	}).Info("Transaction confirmed")
 